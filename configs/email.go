@@ -21,6 +21,8 @@ type EmailConfig struct {
 	SMTPPassword  string
 	SMTPFromEmail string
 	SMTPFromName  string
+	// File
+	FileDir string
 }
 
 // GetEmailConfig retorna la configuración de email según el entorno
@@ -50,6 +52,8 @@ func GetEmailConfig() EmailConfig {
 		SMTPPassword:  os.Getenv("SMTP_PASSWORD"),
 		SMTPFromEmail: os.Getenv("SMTP_FROM_EMAIL"),
 		SMTPFromName:  os.Getenv("SMTP_FROM_NAME"),
+		// File
+		FileDir: os.Getenv("EMAIL_FILE_DIR"),
 	}
 
 	return config
@@ -68,6 +72,10 @@ func NewEmailService() mail.EmailService {
 	case "smtp":
 		fmt.Printf("Inicializando SMTP Email Service (Host: %s)\n", config.SMTPHost)
 		return mail.NewSMTPEmailService()
+	case "file":
+		fileService := mail.NewFileEmailService(config.FileDir)
+		fmt.Printf("Inicializando File Email Service (Directorio: %s)\n", fileService.Dir)
+		return fileService
 	case "mock":
 		fmt.Println("Inicializando Mock Email Service (solo logs)")
 		return mail.NewMockEmailService()
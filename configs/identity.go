@@ -0,0 +1,56 @@
+package configs
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"iycds2025_api/src/api/services/identity"
+)
+
+// providerNamePattern son los nombres válidos de proveedor: se usan en las URLs y en las variables de entorno
+var providerNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9-]{0,49}$`)
+
+// LoadIdentityProviders carga los proveedores OIDC listados en OIDC_PROVIDERS (separados por coma).
+// Cada proveedor se configura con OIDC_<NOMBRE>_ISSUER, _CLIENT_ID, _CLIENT_SECRET y opcionalmente
+// _REDIRECT_URL, _SCOPES y _AUTHORIZATION_URL. Sin OIDC_PROVIDERS el login externo queda deshabilitado.
+func LoadIdentityProviders(frontendURL string) (identity.Providers, error) {
+	providers := identity.Providers{}
+
+	for _, name := range strings.Split(os.Getenv("OIDC_PROVIDERS"), ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+		if !providerNamePattern.MatchString(name) {
+			return nil, fmt.Errorf("invalid identity provider name %q", name)
+		}
+		if _, exists := providers[name]; exists {
+			return nil, fmt.Errorf("identity provider %q is listed twice", name)
+		}
+
+		prefix := "OIDC_" + strings.ToUpper(strings.ReplaceAll(name, "-", "_")) + "_"
+		config := identity.OIDCConfig{
+			Name:             name,
+			Issuer:           os.Getenv(prefix + "ISSUER"),
+			ClientID:         os.Getenv(prefix + "CLIENT_ID"),
+			ClientSecret:     os.Getenv(prefix + "CLIENT_SECRET"),
+			RedirectURL:      os.Getenv(prefix + "REDIRECT_URL"),
+			Scopes:           strings.Fields(strings.ReplaceAll(os.Getenv(prefix+"SCOPES"), ",", " ")),
+			AuthorizationURL: os.Getenv(prefix + "AUTHORIZATION_URL"),
+		}
+		if config.Issuer == "" || config.ClientID == "" {
+			return nil, fmt.Errorf("identity provider %q: %sISSUER and %sCLIENT_ID are required", name, prefix, prefix)
+		}
+		// Por defecto el proveedor vuelve a la página del frontend que completa el login
+		if config.RedirectURL == "" {
+			config.RedirectURL = fmt.Sprintf("%s/auth/callback/%s", frontendURL, name)
+		}
+
+		providers[name] = identity.NewOIDCProvider(config)
+		fmt.Printf("Identity provider %s: %s\n", name, config.Issuer)
+	}
+
+	return providers, nil
+}
@@ -0,0 +1,107 @@
+package configs
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"iycds2025_api/src/api/utils"
+)
+
+// DefaultJWTIssuer es el emisor (claim iss) de los tokens si no se configura JWT_ISSUER
+const DefaultJWTIssuer = "iycds2025-api"
+
+// JWTConfig contiene la configuración de las claves de firma de los access tokens
+type JWTConfig struct {
+	Issuer      string
+	KeysDir     string // directorio con un archivo <kid>.pem por clave (privadas o solo públicas)
+	PrivateKey  string // PEM de la clave de firma, para entornos donde no se pueden montar archivos
+	ActiveKeyID string // kid de la clave con la que se firma
+}
+
+// GetJWTConfig retorna la configuración de JWT desde las variables de entorno
+func GetJWTConfig() JWTConfig {
+	issuer := os.Getenv("JWT_ISSUER")
+	if issuer == "" {
+		issuer = DefaultJWTIssuer
+	}
+
+	return JWTConfig{
+		Issuer:      issuer,
+		KeysDir:     os.Getenv("JWT_KEYS_DIR"),
+		PrivateKey:  os.Getenv("JWT_PRIVATE_KEY"),
+		ActiveKeyID: os.Getenv("JWT_ACTIVE_KEY_ID"),
+	}
+}
+
+// LoadJWTKeySet carga las claves de firma. Devuelve error si no hay ninguna clave privada
+// configurada: la API no debe arrancar firmando tokens con una clave vacía.
+func LoadJWTKeySet() (*utils.KeySet, error) {
+	config := GetJWTConfig()
+
+	if os.Getenv("JWT_SECRET") != "" {
+		fmt.Println("WARNING: JWT_SECRET ya no se usa; los tokens se firman con JWT_KEYS_DIR / JWT_PRIVATE_KEY")
+	}
+
+	var keys []*utils.SigningKey
+	var privateIDs []string
+
+	if config.KeysDir != "" {
+		paths, err := filepath.Glob(filepath.Join(config.KeysDir, "*.pem"))
+		if err != nil {
+			return nil, err
+		}
+		for _, path := range paths {
+			content, err := os.ReadFile(path)
+			if err != nil {
+				return nil, err
+			}
+			kid := strings.TrimSuffix(filepath.Base(path), ".pem")
+			key, err := utils.ParseSigningKeyPEM(kid, content)
+			if err != nil {
+				return nil, fmt.Errorf("JWT key %s: %v", path, err)
+			}
+			keys = append(keys, key)
+			if key.CanSign() {
+				privateIDs = append(privateIDs, key.ID)
+			}
+		}
+	}
+
+	inlineID := ""
+	if config.PrivateKey != "" {
+		key, err := utils.ParseSigningKeyPEM("", []byte(config.PrivateKey))
+		if err != nil {
+			return nil, fmt.Errorf("JWT_PRIVATE_KEY: %v", err)
+		}
+		if !key.CanSign() {
+			return nil, fmt.Errorf("JWT_PRIVATE_KEY must contain a private key")
+		}
+		keys = append(keys, key)
+		inlineID = key.ID
+	}
+
+	// Elegir la clave activa: la indicada, la del entorno o la única clave privada del directorio
+	activeID := config.ActiveKeyID
+	if activeID == "" {
+		switch {
+		case inlineID != "":
+			activeID = inlineID
+		case len(privateIDs) == 1:
+			activeID = privateIDs[0]
+		case len(privateIDs) > 1:
+			return nil, fmt.Errorf("%d private keys in %s: set JWT_ACTIVE_KEY_ID to choose the signing key", len(privateIDs), config.KeysDir)
+		default:
+			return nil, fmt.Errorf("no JWT signing key configured: set JWT_KEYS_DIR or JWT_PRIVATE_KEY (generate one with: jwt-keys generate)")
+		}
+	}
+
+	keySet, err := utils.NewKeySet(config.Issuer, activeID, keys)
+	if err != nil {
+		return nil, err
+	}
+
+	fmt.Printf("JWT signing key: %s (%d verification keys)\n", keySet.ActiveKeyID(), len(keys))
+	return keySet, nil
+}
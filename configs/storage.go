@@ -0,0 +1,76 @@
+package configs
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"iycds2025_api/src/api/services/storage"
+)
+
+// StorageConfig contiene la configuración del almacenamiento de archivos (fotos de los servicios)
+type StorageConfig struct {
+	Type      string // "local" (por defecto) o "s3"
+	LocalDir  string
+	PublicURL string // URL base con la que se sirven los archivos; cada clave se agrega al final
+	S3        storage.S3Config
+}
+
+// GetStorageConfig retorna la configuración del almacenamiento desde las variables de entorno. Si no se
+// configura STORAGE_PUBLIC_URL, los archivos se sirven desde la propia API en /api/media.
+func GetStorageConfig() StorageConfig {
+	storageType := strings.ToLower(os.Getenv("STORAGE_TYPE"))
+	if storageType == "" {
+		storageType = "local"
+	}
+
+	publicURL := os.Getenv("STORAGE_PUBLIC_URL")
+	if publicURL == "" {
+		port := os.Getenv("PORT")
+		if port == "" {
+			port = "8080"
+		}
+		publicURL = "http://localhost:" + port + "/api/media"
+	}
+
+	// Con un endpoint propio (MinIO, por ejemplo) se usan URLs con el bucket en la ruta, salvo S3_PATH_STYLE=false
+	endpoint := os.Getenv("S3_ENDPOINT")
+	pathStyle := endpoint != ""
+	if value := os.Getenv("S3_PATH_STYLE"); value != "" {
+		pathStyle = value == "true"
+	}
+
+	return StorageConfig{
+		Type:      storageType,
+		LocalDir:  os.Getenv("STORAGE_LOCAL_DIR"),
+		PublicURL: strings.TrimRight(publicURL, "/"),
+		S3: storage.S3Config{
+			Endpoint:        endpoint,
+			Region:          os.Getenv("S3_REGION"),
+			Bucket:          os.Getenv("S3_BUCKET"),
+			AccessKeyID:     os.Getenv("S3_ACCESS_KEY_ID"),
+			SecretAccessKey: os.Getenv("S3_SECRET_ACCESS_KEY"),
+			PathStyle:       pathStyle,
+		},
+	}
+}
+
+// NewBlobStore crea el almacenamiento configurado. Devuelve error si la configuración de S3 está
+// incompleta: la API no debe arrancar aceptando fotos que no puede guardar.
+func NewBlobStore(config StorageConfig) (storage.BlobStore, error) {
+	switch config.Type {
+	case "local":
+		store := storage.NewLocalBlobStore(config.LocalDir)
+		fmt.Printf("Inicializando almacenamiento local (Directorio: %s, URL pública: %s)\n", store.Dir, config.PublicURL)
+		return store, nil
+	case "s3":
+		store, err := storage.NewS3BlobStore(config.S3)
+		if err != nil {
+			return nil, err
+		}
+		fmt.Printf("Inicializando almacenamiento S3 (Bucket: %s, URL pública: %s)\n", config.S3.Bucket, config.PublicURL)
+		return store, nil
+	default:
+		return nil, fmt.Errorf("unknown storage type %q (expected local or s3)", config.Type)
+	}
+}
@@ -1,10 +1,12 @@
 package app
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"os"
 	"iycds2025_api/src/api/infrastructure/dependencies"
+	"iycds2025_api/src/api/infrastructure/workers"
 	"iycds2025_api/src/api/middleware"
 
 	"github.com/gin-gonic/gin"
@@ -15,7 +17,13 @@ var router *gin.Engine
 func Start() {
 	fmt.Println("Starting IYCDS2025 API")
 
-	router = createRouter()
+	var handlers *dependencies.HandlerContainer
+	router, handlers = createRouter()
+
+	// Iniciar los procesos en segundo plano (ej: envío de correos del outbox)
+	ctx, stopWorkers := context.WithCancel(context.Background())
+	defer stopWorkers()
+	startWorkers(ctx, handlers.Workers)
 
 	// Obtener el puerto desde la variable de entorno PORT
 	port := os.Getenv("PORT")
@@ -23,8 +31,7 @@ func Start() {
 		port = "8080"
 	}
 
-	appEnv := os.Getenv("APP_ENV")
-	fmt.Printf("Running API in %s environment on port %s\n", appEnv, port)
+	fmt.Printf("Running API in %s environment on port %s\n", appEnvironment(), port)
 
 	err := router.Run(":" + port)
 	if err != nil {
@@ -32,21 +39,41 @@ func Start() {
 	}
 }
 
-func createRouter() *gin.Engine {
+func createRouter() (*gin.Engine, *dependencies.HandlerContainer) {
 	router := gin.Default()
 	router.Use(middleware.CORSConfig())
 	configureEnv()
 	handlers := dependencies.Start()
 	configureURLMappings(router, handlers)
-	return router
+	return router, handlers
+}
+
+func startWorkers(ctx context.Context, backgroundWorkers []workers.Worker) {
+	for _, worker := range backgroundWorkers {
+		go worker.Run(ctx)
+	}
+}
+
+// appEnvironment devuelve el entorno de APP_ENV, o "development" si no está configurado
+func appEnvironment() string {
+	if appEnv := os.Getenv("APP_ENV"); appEnv != "" {
+		return appEnv
+	}
+	return "development"
+}
+
+// isExplicitDevelopment indica si APP_ENV se configuró como "development". Lo que expone datos
+// sensibles (como la bandeja de correos) lo exige explícitamente: un despliegue que olvidó
+// configurar APP_ENV no debe quedar en modo desarrollo.
+func isExplicitDevelopment() bool {
+	return os.Getenv("APP_ENV") == "development"
 }
 
 func configureEnv() {
-	// Configuración del entorno
-	appEnv := os.Getenv("APP_ENV")
-	if appEnv == "" {
-		appEnv = "development"
-		os.Setenv("APP_ENV", appEnv)
+	// Configuración del entorno. El valor por defecto no se escribe en APP_ENV para que el resto de
+	// la API pueda distinguirlo de un entorno configurado.
+	appEnv := appEnvironment()
+	if os.Getenv("APP_ENV") == "" {
 		log.Println("APP_ENV no configurado, usando valor por defecto:", appEnv)
 	}
 	fmt.Printf("Running in %s environment\n", appEnv)
@@ -0,0 +1,77 @@
+package app
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"iycds2025_api/configs"
+	"iycds2025_api/src/api/utils"
+)
+
+const jwtKeysUsage = "usage: jwt-keys generate [eddsa|rs256] [dir] | jwt-keys list"
+
+// JWTKeys ejecuta el subcomando para generar y revisar las claves de firma de los access tokens
+func JWTKeys(args []string) {
+	if len(args) == 0 {
+		fmt.Println(jwtKeysUsage)
+		os.Exit(2)
+	}
+
+	switch args[0] {
+	case "generate":
+		algorithm := utils.AlgorithmEdDSA
+		if len(args) > 1 {
+			switch strings.ToLower(args[1]) {
+			case "eddsa", "ed25519":
+				algorithm = utils.AlgorithmEdDSA
+			case "rs256", "rsa":
+				algorithm = utils.AlgorithmRS256
+			default:
+				log.Fatalf("Unsupported algorithm: %s", args[1])
+			}
+		}
+
+		dir := configs.GetJWTConfig().KeysDir
+		if len(args) > 2 {
+			dir = args[2]
+		}
+		if dir == "" {
+			dir = "keys"
+		}
+
+		key, content, err := utils.GenerateSigningKey(algorithm)
+		if err != nil {
+			log.Fatalf("Failed to generate key: %v", err)
+		}
+		if err := os.MkdirAll(dir, 0o700); err != nil {
+			log.Fatalf("Failed to create %s: %v", dir, err)
+		}
+		path := filepath.Join(dir, key.ID+".pem")
+		if err := os.WriteFile(path, content, 0o600); err != nil {
+			log.Fatalf("Failed to write %s: %v", path, err)
+		}
+
+		fmt.Printf("Generated %s key %s in %s\n", key.Algorithm, key.ID, path)
+		fmt.Printf("To sign with it set JWT_ACTIVE_KEY_ID=%s (or keep it as the only private key in JWT_KEYS_DIR)\n", key.ID)
+
+	case "list":
+		keySet, err := configs.LoadJWTKeySet()
+		if err != nil {
+			log.Fatalf("Failed to load JWT keys: %v", err)
+		}
+		for _, jwk := range keySet.JWKS().Keys {
+			state := "verify"
+			if jwk.Kid == keySet.ActiveKeyID() {
+				state = "sign + verify"
+			}
+			fmt.Printf("%-45s %-6s %s\n", jwk.Kid, jwk.Alg, state)
+		}
+
+	default:
+		fmt.Println(jwtKeysUsage)
+		os.Exit(2)
+	}
+}
@@ -0,0 +1,124 @@
+package app
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+
+	"iycds2025_api/configs"
+	"iycds2025_api/src/api/core/entities"
+	"iycds2025_api/src/api/repositories/database"
+)
+
+const outboxUsage = "usage: outbox status [pending|sending|sent|dead] [limit] | outbox show <id> | outbox retry <id>"
+
+// defaultOutboxListLimit es la cantidad de correos que lista "outbox status" si no se indica otra
+const defaultOutboxListLimit = 50
+
+var outboxStatuses = map[string]bool{
+	entities.OutboxStatusPending: true,
+	entities.OutboxStatusSending: true,
+	entities.OutboxStatusSent:    true,
+	entities.OutboxStatusDead:    true,
+}
+
+// Outbox ejecuta el subcomando para consultar y reintentar los correos de la cola de salida
+func Outbox(args []string) {
+	if len(args) == 0 {
+		fmt.Println(outboxUsage)
+		os.Exit(2)
+	}
+
+	db := configs.ConnectDatabase()
+	if db == nil {
+		log.Fatal("Could not connect to the database")
+	}
+	defer db.Close()
+
+	outbox := database.NewEmailOutboxRepository(db)
+	ctx := context.Background()
+
+	switch args[0] {
+	case "status":
+		status := ""
+		limit := defaultOutboxListLimit
+		for _, arg := range args[1:] {
+			if outboxStatuses[arg] {
+				status = arg
+				continue
+			}
+			value, err := strconv.Atoi(arg)
+			if err != nil || value < 1 {
+				log.Fatalf("Invalid status or limit: %s", arg)
+			}
+			limit = value
+		}
+
+		emails, err := outbox.List(ctx, status, limit)
+		if err != nil {
+			log.Fatalf("Failed to list outbox emails: %v", err)
+		}
+		if len(emails) == 0 {
+			fmt.Println("No emails in the outbox")
+		}
+		for _, email := range emails {
+			fmt.Printf("%6d  %-8s %-20s %-40s attempts=%d next=%s\n", email.ID, email.Status, email.Kind,
+				email.Recipient, email.Attempts, email.NextAttemptAt.Format("2006-01-02 15:04:05"))
+			if email.LastError != "" {
+				fmt.Printf("        last error: %s\n", email.LastError)
+			}
+		}
+
+	case "show":
+		id := parseOutboxID(args)
+		email, err := outbox.GetByID(ctx, id)
+		if err != nil {
+			log.Fatalf("Failed to get outbox email: %v", err)
+		}
+		if email == nil {
+			log.Fatalf("Outbox email %d not found", id)
+		}
+		fmt.Printf("ID:           %d\n", email.ID)
+		fmt.Printf("Kind:         %s\n", email.Kind)
+		fmt.Printf("Recipient:    %s\n", email.Recipient)
+		fmt.Printf("Status:       %s\n", email.Status)
+		fmt.Printf("Attempts:     %d\n", email.Attempts)
+		fmt.Printf("Next attempt: %s\n", email.NextAttemptAt.Format("2006-01-02 15:04:05"))
+		if email.SentAt != nil {
+			fmt.Printf("Sent at:      %s\n", email.SentAt.Format("2006-01-02 15:04:05"))
+		}
+		if email.LastError != "" {
+			fmt.Printf("Last error:   %s\n", email.LastError)
+		}
+		fmt.Printf("Created at:   %s\n", email.CreatedAt.Format("2006-01-02 15:04:05"))
+
+	case "retry":
+		id := parseOutboxID(args)
+		if err := outbox.Requeue(ctx, id); err != nil {
+			if err == sql.ErrNoRows {
+				log.Fatalf("Outbox email %d not found or not dead", id)
+			}
+			log.Fatalf("Failed to requeue outbox email: %v", err)
+		}
+		fmt.Printf("Outbox email %d requeued\n", id)
+
+	default:
+		fmt.Println(outboxUsage)
+		os.Exit(2)
+	}
+}
+
+func parseOutboxID(args []string) int64 {
+	if len(args) < 2 {
+		fmt.Println(outboxUsage)
+		os.Exit(2)
+	}
+	id, err := strconv.ParseInt(args[1], 10, 64)
+	if err != nil || id < 1 {
+		log.Fatalf("Invalid outbox email ID: %s", args[1])
+	}
+	return id
+}
@@ -1,47 +1,125 @@
 package app
 
 import (
+	"iycds2025_api/src/api/core/policy"
 	"iycds2025_api/src/api/infrastructure/dependencies"
 	"iycds2025_api/src/api/middleware"
 
 	"github.com/gin-gonic/gin"
 )
 
+// configureDevMailbox publica la bandeja de correos capturados por el backend "file". Los correos
+// incluyen enlaces de restablecimiento y verificación, así que solo se expone con APP_ENV=development
+// configurado explícitamente, no con el valor por defecto.
+func configureDevMailbox(router *gin.Engine, handlers *dependencies.HandlerContainer) {
+	if handlers.DevMailboxList == nil || handlers.DevMailboxMessage == nil || !isExplicitDevelopment() {
+		return
+	}
+	router.GET("/dev/mailbox", handlers.DevMailboxList.Handle)
+	router.GET("/dev/mailbox/:id", handlers.DevMailboxMessage.Handle)
+}
+
 func configureURLMappings(router *gin.Engine, handlers *dependencies.HandlerContainer) {
 	// Endpoint simple ping/pong
 	router.GET("/ping", handlers.Ping.Handle)
 
+	// Claves públicas para verificar los access tokens (JWKS, RFC 7517)
+	router.GET("/.well-known/jwks.json", handlers.JWKS.Handle)
+
+	configureDevMailbox(router, handlers)
+
 	// Grupo de API
 	group := router.Group("/api")
 
 	// Endpoints públicos para autenticación con rate limiting
 	group.POST("/user/login", middleware.StrictRateLimit(), handlers.UserLogin.Handle)
+	// Segundo paso del login para las cuentas con 2FA: canjea el desafío y el código por los tokens
+	group.POST("/user/login/2fa", middleware.StrictRateLimit(), handlers.UserLoginTwoFactor.Handle)
+
+	// Login con proveedores de identidad externos (OIDC): el frontend pide la URL del proveedor y
+	// después envía el code y el state con los que el proveedor lo redirigió
+	group.GET("/auth/providers", handlers.ExternalLoginProviders.Handle)
+	group.POST("/auth/providers/:provider/start", middleware.StrictRateLimit(), handlers.ExternalLoginStart.Handle)
+	group.POST("/auth/providers/:provider/callback", middleware.StrictRateLimit(), handlers.ExternalLoginCallback.Handle)
 	group.POST("/user/register", middleware.StandardRateLimit(), handlers.UserRegister.Handle)
 
+	// Renovación del access token con el refresh token (rota el refresh token)
+	group.POST("/user/refresh", middleware.StrictRateLimit(), handlers.UserRefresh.Handle)
+
 	group.POST("/user/forgot-password", middleware.StrictRateLimit(), handlers.PasswordForgot.Handle)
 	group.POST("/user/reset-password", middleware.StandardRateLimit(), handlers.PasswordReset.Handle)
 
+	// Confirmación del email con el token del enlace enviado por correo
+	group.POST("/user/verify-email", middleware.StandardRateLimit(), handlers.EmailVerify.Handle)
+
 	// Endpoint público para obtener categorías
 	group.GET("/categories", handlers.Categories.Handle)
 
 	// Endpoint público para obtener todos los servicios
 	group.GET("/services", handlers.ServiceListAll.Handle)
 
+	// Endpoint público de búsqueda de texto libre con relevancia (?q=plomero urgente palermo)
+	group.GET("/services/search", middleware.StandardRateLimit(), handlers.ServiceSearch.Handle)
+
 	// Endpoint público para obtener un servicio por ID
 	group.GET("/services/:id", handlers.ServiceGetByID.Handle)
 
 	// Endpoint público para obtener disponibilidad de un servicio
 	group.GET("/services/:id/availability", handlers.ServiceAvailability.Handle)
 
-	// Endpoint público para obtener calendario de un servicio (30 días)
+	// Endpoint público para obtener calendario de un servicio (?start=YYYY-MM-DD&days=N, por defecto 30 días desde hoy)
 	group.GET("/services/:id/calendar", handlers.ServiceCalendar.Handle)
 
+	// Endpoint público para obtener las excepciones de agenda vigentes de un servicio
+	group.GET("/services/:id/exceptions", handlers.ServiceExceptionList.Handle)
+
+	// Endpoint público para obtener las reseñas de un servicio (?page=N&page_size=N)
+	group.GET("/services/:id/reviews", handlers.ServiceReviews.Handle)
+
+	// Endpoint público con las fotos de un servicio en orden; el dueño también ve las de un servicio pausado
+	group.GET("/services/:id/images", handlers.OptionalAuth, handlers.ServiceImageList.Handle)
+
+	// Archivos subidos (fotos y miniaturas) cuando se sirven desde la API (ver STORAGE_PUBLIC_URL)
+	group.GET("/media/*key", handlers.Media.Handle)
+	group.HEAD("/media/*key", handlers.Media.Handle)
+
+	// Endpoint público con el perfil de un proveedor; el email y el teléfono solo se muestran a quien
+	// tiene una reserva aceptada con él, por eso se lee el token si viene
+	group.GET("/providers/:id", middleware.StandardRateLimit(), handlers.OptionalAuth, handlers.ProviderProfile.Handle)
+
 	// Endpoints protegidos que requieren autenticación
 	protected := group.Group("/")
-	protected.Use(middleware.AuthMiddleware())
+	protected.Use(handlers.Auth)
 	{
+		// Cierre de la sesión actual o de todas las sesiones del usuario
+		protected.POST("/user/logout", middleware.StandardRateLimit(), handlers.UserLogout.Handle)
+		protected.POST("/user/logout-all", middleware.StandardRateLimit(), handlers.UserLogoutAll.Handle)
+
+
 		// Actualización de perfil de usuario
 		protected.PUT("/user/profile", middleware.StandardRateLimit(), handlers.UserUpdate.Handle)
+
+		// Cambio de contraseña con la contraseña actual (cierra las demás sesiones)
+		protected.PUT("/user/password", middleware.StrictRateLimit(), handlers.PasswordChange.Handle)
+
+		// Reenvío del enlace de verificación (al email de la cuenta o al nuevo email pendiente)
+		protected.POST("/user/verify-email/resend", middleware.StrictRateLimit(), handlers.EmailVerificationResend.Handle)
+
+		// Autenticación en dos pasos: estado, alta con confirmación, baja y nuevos códigos de recuperación
+		protected.GET("/user/2fa", middleware.StandardRateLimit(), handlers.TwoFactorStatus.Handle)
+		protected.POST("/user/2fa/setup", middleware.StrictRateLimit(), handlers.TwoFactorSetup.Handle)
+		protected.POST("/user/2fa/confirm", middleware.StrictRateLimit(), handlers.TwoFactorConfirm.Handle)
+		protected.POST("/user/2fa/disable", middleware.StrictRateLimit(), handlers.TwoFactorDisable.Handle)
+		protected.POST("/user/2fa/recovery-codes", middleware.StrictRateLimit(), handlers.TwoFactorRecoveryCodes.Handle)
+
+		// Proveedores externos vinculados a la cuenta
+		protected.GET("/user/identities", middleware.StandardRateLimit(), handlers.UserIdentities.Handle)
+		protected.DELETE("/user/identities/:provider", middleware.StandardRateLimit(), handlers.UserIdentityUnlink.Handle)
+
+		// Exportación de datos personales y baja de la cuenta
+		protected.GET("/user/export", middleware.StrictRateLimit(), handlers.UserExport.Handle)
+		protected.DELETE("/user", middleware.StrictRateLimit(), handlers.UserDelete.Handle)
+		protected.POST("/user/deletion/cancel", middleware.StandardRateLimit(), handlers.UserDeletionCancel.Handle)
 		
 		// CRUD de servicios
 		protected.POST("/services", middleware.StandardRateLimit(), handlers.ServiceCreate.Handle)
@@ -50,6 +128,16 @@ func configureURLMappings(router *gin.Engine, handlers *dependencies.HandlerCont
 		protected.PATCH("/services/:id/status", middleware.StandardRateLimit(), handlers.ServiceUpdateStatus.Handle)
 		protected.GET("/my-services", middleware.StandardRateLimit(), handlers.ServiceList.Handle)
 		
+		// Excepciones de agenda por fecha (feriados, vacaciones, horarios especiales)
+		protected.POST("/services/:id/exceptions", middleware.StandardRateLimit(), handlers.ServiceExceptionCreate.Handle)
+		protected.PUT("/services/:id/exceptions/:exceptionId", middleware.StandardRateLimit(), handlers.ServiceExceptionUpdate.Handle)
+		protected.DELETE("/services/:id/exceptions/:exceptionId", middleware.StandardRateLimit(), handlers.ServiceExceptionDelete.Handle)
+
+		// Fotos de los servicios (multipart/form-data, campo "image"); la primera es la portada
+		protected.POST("/services/:id/images", middleware.StrictRateLimit(), handlers.ServiceImageUpload.Handle)
+		protected.PUT("/services/:id/images/order", middleware.StandardRateLimit(), handlers.ServiceImageReorder.Handle)
+		protected.DELETE("/services/:id/images/:imageId", middleware.StandardRateLimit(), handlers.ServiceImageDelete.Handle)
+
 		// Appointments de servicios (para proveedores)
 		protected.GET("/services/:id/appointments", middleware.StandardRateLimit(), handlers.ServiceAppointments.Handle)
 		
@@ -57,5 +145,38 @@ func configureURLMappings(router *gin.Engine, handlers *dependencies.HandlerCont
 		protected.POST("/appointments", middleware.StandardRateLimit(), handlers.AppointmentCreate.Handle)
 		protected.GET("/my-appointments", middleware.StandardRateLimit(), handlers.AppointmentList.Handle)
 		protected.PUT("/appointments/:id/status", middleware.StandardRateLimit(), handlers.AppointmentUpdateStatus.Handle)
+
+		// Reseñas de citas completadas y respuesta del proveedor
+		protected.POST("/appointments/:id/review", middleware.StandardRateLimit(), handlers.ReviewCreate.Handle)
+		protected.POST("/reviews/:id/reply", middleware.StandardRateLimit(), handlers.ReviewReply.Handle)
+
+		// Back-office: moderación de usuarios y auditoría (solo administradores)
+		adminGroup := protected.Group("/admin")
+		adminGroup.Use(middleware.RequireRole(policy.RoleAdmin))
+		{
+			adminGroup.GET("/users", middleware.StandardRateLimit(), handlers.AdminUserList.Handle)
+			adminGroup.POST("/users/:id/suspend", middleware.StandardRateLimit(), handlers.AdminUserSuspend.Handle)
+			adminGroup.POST("/users/:id/reactivate", middleware.StandardRateLimit(), handlers.AdminUserReactivate.Handle)
+
+			// Registro de auditoría de las acciones del back-office
+			adminGroup.GET("/audit-log", middleware.StandardRateLimit(), handlers.AdminAuditLog.Handle)
+		}
+
+		// Back-office: moderación de servicios y citas (administradores o moderadores con el permiso)
+		serviceModeration := protected.Group("/admin/services")
+		serviceModeration.Use(middleware.RequirePermission(policy.PermissionModerateServices))
+		{
+			serviceModeration.GET("", middleware.StandardRateLimit(), handlers.AdminServiceList.Handle)
+			serviceModeration.POST("/:id/deactivate", middleware.StandardRateLimit(), handlers.AdminServiceDeactivate.Handle)
+			serviceModeration.DELETE("/:id", middleware.StandardRateLimit(), handlers.AdminServiceDelete.Handle)
+		}
+
+		appointmentModeration := protected.Group("/admin/appointments")
+		appointmentModeration.Use(middleware.RequirePermission(policy.PermissionModerateAppointments))
+		{
+			appointmentModeration.GET("", middleware.StandardRateLimit(), handlers.AdminAppointmentList.Handle)
+			appointmentModeration.POST("/:id/cancel", middleware.StandardRateLimit(), handlers.AdminAppointmentCancel.Handle)
+			appointmentModeration.POST("/:id/reassign", middleware.StandardRateLimit(), handlers.AdminAppointmentReassign.Handle)
+		}
 	}
 }
@@ -0,0 +1,85 @@
+package app
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"iycds2025_api/src/api/infrastructure/dependencies"
+
+	"github.com/gin-gonic/gin"
+)
+
+// okHandler reemplaza a los handlers de la bandeja: responde 200 si la ruta quedó registrada
+type okHandler struct{}
+
+func (okHandler) Handle(c *gin.Context) { c.Status(http.StatusOK) }
+
+// setAppEnv configura APP_ENV para el test; "" la deja sin configurar. t.Setenv restaura el valor original.
+func setAppEnv(t *testing.T, value string) {
+	t.Helper()
+	t.Setenv("APP_ENV", value)
+	if value == "" {
+		os.Unsetenv("APP_ENV")
+	}
+}
+
+func mailboxStatus(handlers *dependencies.HandlerContainer) (int, int) {
+	router := gin.New()
+	configureDevMailbox(router, handlers)
+
+	list := httptest.NewRecorder()
+	router.ServeHTTP(list, httptest.NewRequest(http.MethodGet, "/dev/mailbox", nil))
+	message := httptest.NewRecorder()
+	router.ServeHTTP(message, httptest.NewRequest(http.MethodGet, "/dev/mailbox/20250113-103000.123456789-1a2b3c4d", nil))
+	return list.Code, message.Code
+}
+
+func TestDevMailboxRoutes(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	withMailbox := &dependencies.HandlerContainer{DevMailboxList: okHandler{}, DevMailboxMessage: okHandler{}}
+
+	tests := []struct {
+		name       string
+		appEnv     string
+		handlers   *dependencies.HandlerContainer
+		wantStatus int
+	}{
+		{name: "APP_ENV not set", appEnv: "", handlers: withMailbox, wantStatus: http.StatusNotFound},
+		{name: "production", appEnv: "production", handlers: withMailbox, wantStatus: http.StatusNotFound},
+		{name: "staging", appEnv: "staging", handlers: withMailbox, wantStatus: http.StatusNotFound},
+		{name: "development with another email backend", appEnv: "development", handlers: &dependencies.HandlerContainer{}, wantStatus: http.StatusNotFound},
+		{name: "explicit development", appEnv: "development", handlers: withMailbox, wantStatus: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setAppEnv(t, tt.appEnv)
+
+			list, message := mailboxStatus(tt.handlers)
+			if list != tt.wantStatus || message != tt.wantStatus {
+				t.Errorf("GET /dev/mailbox = %d and /dev/mailbox/:id = %d, want %d", list, message, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func TestConfigureEnvDefaultDoesNotEnableDevMailbox(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	setAppEnv(t, "")
+
+	// El valor por defecto ("development") solo se usa para los logs: no se escribe en APP_ENV
+	configureEnv()
+	if value, ok := os.LookupEnv("APP_ENV"); ok {
+		t.Fatalf("configureEnv set APP_ENV=%q", value)
+	}
+	if appEnvironment() != "development" {
+		t.Errorf("appEnvironment() = %q, want development", appEnvironment())
+	}
+
+	list, message := mailboxStatus(&dependencies.HandlerContainer{DevMailboxList: okHandler{}, DevMailboxMessage: okHandler{}})
+	if list != http.StatusNotFound || message != http.StatusNotFound {
+		t.Errorf("mailbox exposed with the default APP_ENV: statuses %d and %d", list, message)
+	}
+}
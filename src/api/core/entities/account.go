@@ -0,0 +1,62 @@
+package entities
+
+import "time"
+
+// Datos con los que queda una cuenta eliminada. Las citas y reseñas de la cuenta se conservan y
+// la otra parte ve este nombre en lugar del original.
+const (
+	DeletedUserName        = "Deleted user"
+	DeletedUserEmailDomain = "deleted.invalid"
+)
+
+// Formatos de la exportación de datos personales
+const (
+	ExportFormatJSON = "json"
+	ExportFormatZIP  = "zip"
+)
+
+// AccountDeletionRequest representa la solicitud de baja de la cuenta. La contraseña se pide siempre
+// que la cuenta tenga una; las cuentas creadas con un proveedor externo no la envían.
+type AccountDeletionRequest struct {
+	Password string `json:"password" validate:"omitempty,max=16"`
+}
+
+// AccountDeletionResponse informa cuándo se eliminará la cuenta
+type AccountDeletionResponse struct {
+	Message             string    `json:"message"`
+	DeletionScheduledAt time.Time `json:"deletion_scheduled_at"`
+	GracePeriodDays     int       `json:"grace_period_days"`
+}
+
+// PersonalDataExport reúne todos los datos de un usuario para entregárselos (portabilidad de datos)
+type PersonalDataExport struct {
+	ExportedAt             time.Time       `json:"exported_at"`
+	Profile                ExportProfile   `json:"profile"`
+	Identities             []*UserIdentity `json:"identities"`
+	Services               []*Service      `json:"services"`
+	AppointmentsAsClient   []*Appointment  `json:"appointments_as_client"`
+	AppointmentsAsProvider []*Appointment  `json:"appointments_as_provider"`
+	ReviewsWritten         []*Review       `json:"reviews_written"`
+	ReviewsReceived        []*Review       `json:"reviews_received"`
+	TwoFactorEnabled       bool            `json:"two_factor_enabled"`
+}
+
+// ExportProfile son los datos de la cuenta que se incluyen en la exportación (sin password ni
+// datos internos del control de intentos de login)
+type ExportProfile struct {
+	ID                  int64      `json:"id"`
+	Name                string     `json:"name"`
+	DisplayName         string     `json:"display_name"`
+	Bio                 string     `json:"bio"`
+	AvatarURL           string     `json:"avatar_url"`
+	Email               string     `json:"email"`
+	EmailVerifiedAt     *time.Time `json:"email_verified_at"`
+	Locality            string     `json:"locality"`
+	Province            string     `json:"province"`
+	Phone               string     `json:"phone"`
+	Locale              string     `json:"locale"`
+	Role                string     `json:"role"`
+	DeletionScheduledAt *time.Time `json:"deletion_scheduled_at"`
+	CreatedAt           time.Time  `json:"created_at"`
+	UpdatedAt           time.Time  `json:"updated_at"`
+}
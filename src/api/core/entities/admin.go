@@ -0,0 +1,86 @@
+package entities
+
+import "time"
+
+// Estados de cuenta por los que se filtra el listado de usuarios del back-office
+const (
+	UserStatusActive    = "active"
+	UserStatusSuspended = "suspended"
+	UserStatusDeleted   = "deleted"
+)
+
+// UserFilter representa los filtros y la página del listado de usuarios del back-office
+type UserFilter struct {
+	Query    string // texto buscado en nombre y email
+	Status   string // "active", "suspended", "deleted" o vacío para todos
+	Page     int    // página, empezando en 1
+	PageSize int    // cantidad de usuarios por página
+}
+
+// AdminUserResponse representa un usuario en el back-office (sin password)
+type AdminUserResponse struct {
+	ID                  int64      `json:"id"`
+	Name                string     `json:"name"`
+	Email               string     `json:"email"`
+	EmailVerifiedAt     *time.Time `json:"email_verified_at"`
+	Locality            string     `json:"locality"`
+	Province            string     `json:"province"`
+	Phone               string     `json:"phone"`
+	Locale              string     `json:"locale"`
+	Status              string     `json:"status"` // "active", "suspended" o "deleted"
+	SuspendedAt         *time.Time `json:"suspended_at"`
+	SuspensionReason    string     `json:"suspension_reason,omitempty"`
+	LoginLockedUntil    *time.Time `json:"login_locked_until"`    // bloqueo temporal del login por intentos fallidos
+	DeletionScheduledAt *time.Time `json:"deletion_scheduled_at"` // baja pedida por el usuario, todavía cancelable
+	DeletedAt           *time.Time `json:"deleted_at"`
+	CreatedAt           time.Time  `json:"created_at"`
+	UpdatedAt           time.Time  `json:"updated_at"`
+}
+
+// AdminUserListResponse representa una página de usuarios del back-office
+type AdminUserListResponse struct {
+	Users      []AdminUserResponse `json:"users"`
+	Total      int                 `json:"total"`
+	Page       int                 `json:"page"`
+	PageSize   int                 `json:"page_size"`
+	TotalPages int                 `json:"total_pages"`
+}
+
+// AdminServiceFilter representa los filtros y la página del listado de servicios del back-office;
+// a diferencia del catálogo público incluye los servicios inactivos
+type AdminServiceFilter struct {
+	Query    string // texto buscado en título y descripción
+	Status   string // "active", "inactive" o vacío para todos
+	Category string // categoría exacta (normalizada)
+	UserID   int64  // dueño del servicio
+	Page     int    // página, empezando en 1
+	PageSize int    // cantidad de servicios por página
+}
+
+// AdminServiceResponse representa un servicio en el listado del back-office, con su dueño
+type AdminServiceResponse struct {
+	ID            int64     `json:"id"`
+	UserID        int64     `json:"user_id"`
+	Title         string    `json:"title"`
+	Category      string    `json:"category"`
+	Price         float64   `json:"price"`
+	Status        string    `json:"status"`
+	RatingAverage float64   `json:"rating_average"`
+	ReviewCount   int       `json:"review_count"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+// AdminServiceListResponse representa una página de servicios del back-office
+type AdminServiceListResponse struct {
+	Services   []AdminServiceResponse `json:"services"`
+	Total      int                    `json:"total"`
+	Page       int                    `json:"page"`
+	PageSize   int                    `json:"page_size"`
+	TotalPages int                    `json:"total_pages"`
+}
+
+// AdminActionRequest es el motivo que acompaña una acción de moderación; queda en la auditoría
+type AdminActionRequest struct {
+	Reason string `json:"reason" validate:"required,min=3,max=255"`
+}
@@ -29,6 +29,36 @@ type AppointmentUpdate struct {
 	Status string `json:"status" validate:"required,oneof=accepted rejected cancelled completed"`
 }
 
+// AppointmentReassign representa la reasignación de una cita por un moderador a otro servicio
+// u otro horario; los campos vacíos conservan el valor actual de la cita
+type AppointmentReassign struct {
+	ServiceID int64  `json:"service_id" validate:"omitempty,min=1"`
+	Date      string `json:"date"`      // YYYY-MM-DD
+	TimeSlot  string `json:"time_slot"` // "HH:MM-HH:MM"
+	Reason    string `json:"reason" validate:"required,min=3,max=255"`
+}
+
+// AppointmentFilter representa los filtros y la página del listado de citas del back-office
+type AppointmentFilter struct {
+	Status     string // estado exacto; vacío para todos
+	ServiceID  int64
+	ClientID   int64
+	ProviderID int64
+	DateFrom   string // YYYY-MM-DD inclusive
+	DateTo     string // YYYY-MM-DD inclusive
+	Page       int    // página, empezando en 1
+	PageSize   int    // cantidad de citas por página
+}
+
+// AppointmentListResponse representa una página de citas
+type AppointmentListResponse struct {
+	Appointments []*Appointment `json:"appointments"`
+	Total        int            `json:"total"`
+	Page         int            `json:"page"`
+	PageSize     int            `json:"page_size"`
+	TotalPages   int            `json:"total_pages"`
+}
+
 // AppointmentResponse representa la respuesta de una cita con información del servicio
 type AppointmentResponse struct {
 	ID         int64           `json:"id"`
@@ -37,6 +67,9 @@ type AppointmentResponse struct {
 	ProviderID int64           `json:"provider_id"`
 	Date       string          `json:"date"`
 	TimeSlot   string          `json:"time_slot"`
+	Timezone   string          `json:"timezone"`  // zona horaria del servicio
+	StartsAt   *time.Time      `json:"starts_at"` // inicio absoluto (RFC 3339)
+	EndsAt     *time.Time      `json:"ends_at"`   // fin absoluto (RFC 3339)
 	Status     string          `json:"status"`
 	Notes      string          `json:"notes"`
 	CreatedAt  time.Time       `json:"created_at"`
@@ -45,14 +78,17 @@ type AppointmentResponse struct {
 
 // TimeSlot representa un slot de tiempo disponible
 type TimeSlot struct {
-	Time      string `json:"time"`       // "HH:MM-HH:MM"
-	Available bool   `json:"available"`  // true si está libre
+	Time      string    `json:"time"`       // "HH:MM-HH:MM"
+	StartsAt  time.Time `json:"starts_at"`  // inicio absoluto (RFC 3339)
+	EndsAt    time.Time `json:"ends_at"`    // fin absoluto (RFC 3339)
+	Available bool      `json:"available"`  // true si está libre y todavía no comenzó
 }
 
 // AvailabilityResponse representa la disponibilidad de un servicio en una fecha específica
 type AvailabilityResponse struct {
 	Date      string     `json:"date"`
 	DayOfWeek string     `json:"day_of_week"`
+	Timezone  string     `json:"timezone"`
 	TimeSlots []TimeSlot `json:"time_slots"`
 }
 
@@ -60,8 +96,8 @@ type AvailabilityResponse struct {
 type CalendarDay struct {
 	Date            string `json:"date"`              // "YYYY-MM-DD"
 	DayOfWeek       string `json:"day_of_week"`       // "monday", "tuesday", etc.
-	HasAvailability bool   `json:"has_availability"`  // true si tiene horarios configurados
-	AvailableSlots  int    `json:"available_slots"`   // número de slots disponibles
+	HasAvailability bool   `json:"has_availability"`  // true si quedan horarios libres
+	AvailableSlots  int    `json:"available_slots"`   // número de slots sin citas activas
 	TotalSlots      int    `json:"total_slots"`       // número total de slots configurados
 }
 
@@ -69,6 +105,7 @@ type CalendarDay struct {
 type CalendarResponse struct {
 	ServiceID    int           `json:"service_id"`
 	ServiceTitle string        `json:"service_title"`
+	Timezone     string        `json:"timezone"`      // zona horaria en la que se expresan las fechas
 	StartDate    string        `json:"start_date"`    // "YYYY-MM-DD"
 	EndDate      string        `json:"end_date"`      // "YYYY-MM-DD"
 	Days         []CalendarDay `json:"days"`
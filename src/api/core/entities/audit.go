@@ -0,0 +1,54 @@
+package entities
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Tipos de recurso sobre los que se registran acciones de administración
+const (
+	AuditTargetUser        = "user"
+	AuditTargetService     = "service"
+	AuditTargetAppointment = "appointment"
+)
+
+// Acciones de administración que quedan en la auditoría
+const (
+	AuditActionUserSuspend         = "user.suspend"
+	AuditActionUserReactivate      = "user.reactivate"
+	AuditActionServiceDeactivate   = "service.deactivate"
+	AuditActionServiceDelete       = "service.delete"
+	AuditActionAppointmentCancel   = "appointment.cancel"
+	AuditActionAppointmentReassign = "appointment.reassign"
+)
+
+// AuditEntry representa una acción de administración registrada en la auditoría
+type AuditEntry struct {
+	ID         int64           `json:"id"`
+	ActorID    int64           `json:"actor_id"`    // administrador que realizó la acción
+	Action     string          `json:"action"`      // ej: "user.suspend"
+	TargetType string          `json:"target_type"` // "user", "service" o "appointment"
+	TargetID   int64           `json:"target_id"`
+	Details    json.RawMessage `json:"details"` // motivo y datos de la acción (JSON)
+	IPAddress  string          `json:"ip_address"`
+	CreatedAt  time.Time       `json:"created_at"`
+}
+
+// AuditFilter representa los filtros y la página del listado de la auditoría
+type AuditFilter struct {
+	ActorID    int64
+	Action     string
+	TargetType string
+	TargetID   int64
+	Page       int // página, empezando en 1
+	PageSize   int // cantidad de entradas por página
+}
+
+// AuditLogResponse representa una página de la auditoría
+type AuditLogResponse struct {
+	Entries    []*AuditEntry `json:"entries"`
+	Total      int           `json:"total"`
+	Page       int           `json:"page"`
+	PageSize   int           `json:"page_size"`
+	TotalPages int           `json:"total_pages"`
+}
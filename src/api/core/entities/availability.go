@@ -0,0 +1,78 @@
+package entities
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// Availability representa la agenda semanal de un servicio
+type Availability struct {
+	SlotDuration  int          `json:"slot_duration"`  // duración de cada turno en minutos
+	BufferMinutes int          `json:"buffer_minutes"` // minutos libres entre turnos consecutivos
+	Monday        *DaySchedule `json:"monday,omitempty"`
+	Tuesday       *DaySchedule `json:"tuesday,omitempty"`
+	Wednesday     *DaySchedule `json:"wednesday,omitempty"`
+	Thursday      *DaySchedule `json:"thursday,omitempty"`
+	Friday        *DaySchedule `json:"friday,omitempty"`
+	Saturday      *DaySchedule `json:"saturday,omitempty"`
+	Sunday        *DaySchedule `json:"sunday,omitempty"`
+}
+
+// DaySchedule representa los horarios de atención de un día de la semana
+type DaySchedule struct {
+	Available bool        `json:"available"`
+	Ranges    []TimeRange `json:"ranges,omitempty"`
+}
+
+// TimeRange representa un rango horario de atención
+type TimeRange struct {
+	Start string `json:"start"` // "HH:MM"
+	End   string `json:"end"`   // "HH:MM"
+}
+
+// UnmarshalJSON acepta tanto el formato actual ({"ranges": [...]}) como el formato
+// anterior de un único rango por día ({"start": "09:00", "end": "18:00"})
+func (d *DaySchedule) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		Available *bool       `json:"available"`
+		Ranges    []TimeRange `json:"ranges"`
+		Start     string      `json:"start"`
+		End       string      `json:"end"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	d.Ranges = raw.Ranges
+	if len(d.Ranges) == 0 && raw.Start != "" && raw.End != "" {
+		d.Ranges = []TimeRange{{Start: raw.Start, End: raw.End}}
+	}
+
+	d.Available = len(d.Ranges) > 0
+	if raw.Available != nil && !*raw.Available {
+		d.Available = false
+	}
+
+	return nil
+}
+
+// Day devuelve los horarios configurados para un día de la semana en inglés ("monday", ...)
+func (a *Availability) Day(dayName string) *DaySchedule {
+	switch strings.ToLower(dayName) {
+	case "monday":
+		return a.Monday
+	case "tuesday":
+		return a.Tuesday
+	case "wednesday":
+		return a.Wednesday
+	case "thursday":
+		return a.Thursday
+	case "friday":
+		return a.Friday
+	case "saturday":
+		return a.Saturday
+	case "sunday":
+		return a.Sunday
+	}
+	return nil
+}
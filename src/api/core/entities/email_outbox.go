@@ -0,0 +1,26 @@
+package entities
+
+import "time"
+
+// Estados de un correo en la cola de salida
+const (
+	OutboxStatusPending = "pending" // esperando el próximo intento
+	OutboxStatusSending = "sending" // tomado por un worker
+	OutboxStatusSent    = "sent"    // entregado
+	OutboxStatusDead    = "dead"    // se agotaron los reintentos
+)
+
+// OutboxEmail representa un correo encolado para su entrega asíncrona
+type OutboxEmail struct {
+	ID            int64      `json:"id"`
+	Kind          string     `json:"kind"`      // tipo de correo, ej: "password_reset"
+	Recipient     string     `json:"recipient"` // email del destinatario
+	Payload       string     `json:"payload"`   // JSON con los datos para armar el correo
+	Status        string     `json:"status"`
+	Attempts      int        `json:"attempts"`
+	NextAttemptAt time.Time  `json:"next_attempt_at"`
+	LastError     string     `json:"last_error"`
+	SentAt        *time.Time `json:"sent_at"`
+	CreatedAt     time.Time  `json:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+}
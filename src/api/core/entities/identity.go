@@ -0,0 +1,51 @@
+package entities
+
+import "time"
+
+// ExternalIdentity es la identidad que confirma un proveedor externo (OIDC) al completar el login
+type ExternalIdentity struct {
+	Provider      string
+	Subject       string // identificador estable del usuario en el proveedor (claim sub)
+	Email         string
+	EmailVerified bool
+	Name          string
+}
+
+// UserIdentity vincula una cuenta con su identidad en un proveedor externo
+type UserIdentity struct {
+	ID          int64      `json:"-"`
+	UserID      int64      `json:"-"`
+	Provider    string     `json:"provider"`
+	Subject     string     `json:"-"`
+	Email       string     `json:"email"`
+	CreatedAt   time.Time  `json:"linked_at"`
+	LastLoginAt *time.Time `json:"last_login_at"`
+}
+
+// ExternalLoginState es el estado guardado entre el inicio del login con un proveedor y su callback:
+// el verificador PKCE y el nonce que el proveedor debe devolver en el id_token
+type ExternalLoginState struct {
+	ID           int64
+	Provider     string
+	StateHash    string
+	CodeVerifier string
+	Nonce        string
+	ExpiresAt    time.Time
+}
+
+// ExternalLoginStartResponse es la URL del proveedor a la que el frontend redirige al usuario
+type ExternalLoginStartResponse struct {
+	AuthorizationURL string `json:"authorization_url"`
+	ExpiresIn        int    `json:"expires_in"` // segundos que tiene el usuario para completar el login
+}
+
+// ExternalLoginCallback representa el retorno del proveedor: el código de autorización y el state
+type ExternalLoginCallback struct {
+	Code  string `json:"code" validate:"required,max=2048"`
+	State string `json:"state" validate:"required,max=128"`
+}
+
+// IdentityProviderInfo describe un proveedor configurado, para que el frontend muestre sus botones
+type IdentityProviderInfo struct {
+	Name string `json:"name"`
+}
@@ -0,0 +1,26 @@
+package entities
+
+import "time"
+
+// Motivos por los que falla un intento de login
+const (
+	LoginFailureUnknownEmail    = "unknown_email"
+	LoginFailureInvalidPassword = "invalid_password"
+	LoginFailureLocked          = "locked"
+	LoginFailureSuspended       = "suspended"
+	// LoginFailureTwoFactorPending indica que la contraseña fue correcta pero falta el segundo paso
+	LoginFailureTwoFactorPending = "two_factor_pending"
+	LoginFailureInvalidTwoFactor = "invalid_2fa_code"
+)
+
+// LoginAttempt representa un intento de login, exitoso o no, con el dispositivo desde el que se hizo
+type LoginAttempt struct {
+	ID            int64     `json:"id"`
+	UserID        int64     `json:"user_id"` // 0 si el email no corresponde a ninguna cuenta
+	Email         string    `json:"email"`
+	Success       bool      `json:"success"`
+	FailureReason string    `json:"failure_reason"` // vacío si el intento fue exitoso
+	IPAddress     string    `json:"ip_address"`
+	UserAgent     string    `json:"user_agent"`
+	CreatedAt     time.Time `json:"created_at"`
+}
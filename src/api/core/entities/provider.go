@@ -0,0 +1,33 @@
+package entities
+
+import "time"
+
+// ProviderProfileResponse es el perfil público de un proveedor. El email y el teléfono solo se
+// incluyen en Contact para el propio proveedor y para los clientes con una reserva aceptada.
+type ProviderProfileResponse struct {
+	ID                    int64              `json:"id"`
+	DisplayName           string             `json:"display_name"`
+	Bio                   string             `json:"bio"`
+	AvatarURL             string             `json:"avatar_url"`
+	Locality              string             `json:"locality"`
+	Province              string             `json:"province"`
+	MemberSince           time.Time          `json:"member_since"`
+	Services              []*ServiceResponse `json:"services"` // servicios activos
+	RatingAverage         float64            `json:"rating_average"`
+	ReviewCount           int                `json:"review_count"`
+	CompletedAppointments int                `json:"completed_appointments"`
+	Contact               *ProviderContact   `json:"contact,omitempty"`
+}
+
+// ProviderContact son los datos privados del proveedor
+type ProviderContact struct {
+	Email string `json:"email"`
+	Phone string `json:"phone"`
+}
+
+// ProviderSummary identifica al proveedor de un servicio, con el enlace a su perfil público
+type ProviderSummary struct {
+	ID          int64  `json:"id"`
+	DisplayName string `json:"display_name"`
+	AvatarURL   string `json:"avatar_url"`
+}
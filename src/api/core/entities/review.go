@@ -0,0 +1,39 @@
+package entities
+
+import "time"
+
+// Review representa la reseña de un cliente sobre una cita completada
+type Review struct {
+	ID            int64      `json:"id"`
+	AppointmentID int64      `json:"appointment_id"`
+	ServiceID     int64      `json:"service_id"`
+	ClientID      int64      `json:"client_id"`
+	ProviderID    int64      `json:"provider_id"`
+	Rating        int        `json:"rating"` // 1 a 5 estrellas
+	Comment       string     `json:"comment"`
+	Reply         *string    `json:"reply"` // respuesta pública del proveedor, una sola vez
+	RepliedAt     *time.Time `json:"replied_at"`
+	CreatedAt     time.Time  `json:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+}
+
+// ReviewCreate representa la solicitud de creación de una reseña
+type ReviewCreate struct {
+	Rating  int    `json:"rating" validate:"required,min=1,max=5"`
+	Comment string `json:"comment" validate:"omitempty,max=1000"`
+}
+
+// ReviewReply representa la respuesta del proveedor a una reseña
+type ReviewReply struct {
+	Reply string `json:"reply" validate:"required,min=2,max=1000"`
+}
+
+// ReviewListResponse representa una página de reseñas de un servicio
+type ReviewListResponse struct {
+	Reviews       []*Review `json:"reviews"`
+	RatingAverage float64   `json:"rating_average"`
+	ReviewCount   int       `json:"review_count"`
+	Page          int       `json:"page"`
+	PageSize      int       `json:"page_size"`
+	TotalPages    int       `json:"total_pages"`
+}
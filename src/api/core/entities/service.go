@@ -4,18 +4,21 @@ import "time"
 
 // Service representa un servicio en el sistema
 type Service struct {
-	ID           int64     `json:"id"`
-	Title        string    `json:"title"`
-	Description  string    `json:"description"`
-	UserID       int64     `json:"user_id"`
-	Category     string    `json:"category"`
-	Price        float64   `json:"price"`
-	Availability string    `json:"availability"` // JSON string con días y horarios
-	Zones        string    `json:"zones"`        // JSON string con zonas de cobertura
-	Status       string    `json:"status"`       // "active" o "inactive"
-	ImageURL     string    `json:"image_url"`
-	CreatedAt    time.Time `json:"created_at"`
-	UpdatedAt    time.Time `json:"updated_at"`
+	ID            int64     `json:"id"`
+	Title         string    `json:"title"`
+	Description   string    `json:"description"`
+	UserID        int64     `json:"user_id"`
+	Category      string    `json:"category"`
+	Price         float64   `json:"price"`
+	Availability  string    `json:"availability"` // JSON string con días y horarios
+	Timezone      string    `json:"timezone"`     // zona horaria IANA, ej: "America/Argentina/Buenos_Aires"
+	Zones         string    `json:"zones"`        // JSON string con zonas de cobertura
+	Status        string    `json:"status"`       // "active" o "inactive"
+	ImageURL      string    `json:"image_url"` // foto de portada: la primera de service_images o, sin fotos, la URL cargada antes de las subidas
+	RatingAverage float64   `json:"rating_average"` // promedio de las reseñas, 0 si no tiene
+	ReviewCount   int       `json:"review_count"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
 }
 
 // ServiceCreate representa la solicitud de creación de servicio
@@ -24,9 +27,9 @@ type ServiceCreate struct {
 	Description  string                 `json:"description" validate:"required,min=100,max=1000"`
 	Category     string                 `json:"category" validate:"required,min=2,max=50"`
 	Price        float64                `json:"price" validate:"required,min=0"`
-	Availability map[string]interface{} `json:"availability" validate:"required"`
+	Availability *Availability          `json:"availability" validate:"required"`
+	Timezone     string                 `json:"timezone" validate:"omitempty,max=64"`
 	Zones        []Zone                 `json:"zones" validate:"required,min=1"`
-	ImageURL     string                 `json:"image_url" validate:"omitempty,url"`
 }
 
 // ServiceUpdate representa la solicitud de actualización de servicio
@@ -35,9 +38,9 @@ type ServiceUpdate struct {
 	Description  string                 `json:"description" validate:"omitempty,min=100,max=1000"`
 	Category     string                 `json:"category" validate:"omitempty,min=2,max=50"`
 	Price        *float64               `json:"price" validate:"omitempty,min=0"`
-	Availability map[string]interface{} `json:"availability" validate:"omitempty"`
+	Availability *Availability          `json:"availability" validate:"omitempty"`
+	Timezone     string                 `json:"timezone" validate:"omitempty,max=64"`
 	Zones        []Zone                 `json:"zones" validate:"omitempty,min=1"`
-	ImageURL     string                 `json:"image_url" validate:"omitempty,url"`
 }
 
 // Zone representa una zona de cobertura
@@ -49,17 +52,24 @@ type Zone struct {
 
 // ServiceResponse representa la respuesta de un servicio
 type ServiceResponse struct {
-	ID           int64                  `json:"id"`
-	Title        string                 `json:"title"`
-	Description  string                 `json:"description"`
-	Category     string                 `json:"category"`
-	Price        float64                `json:"price"`
-	Availability map[string]interface{} `json:"availability"`
-	Zones        []Zone                 `json:"zones"`
-	Status       string                 `json:"status"`
-	ImageURL     string                 `json:"image_url"`
-	CreatedAt    time.Time              `json:"created_at"`
-	UpdatedAt    time.Time              `json:"updated_at"`
+	ID            int64         `json:"id"`
+	Title         string        `json:"title"`
+	Description   string        `json:"description"`
+	Category      string        `json:"category"`
+	Price         float64       `json:"price"`
+	Availability  *Availability `json:"availability"`
+	Timezone      string        `json:"timezone"`
+	Zones         []Zone        `json:"zones"`
+	Status        string        `json:"status"`
+	ImageURL      string        `json:"image_url"`
+	RatingAverage float64       `json:"rating_average"`
+	ReviewCount   int           `json:"review_count"`
+	CreatedAt     time.Time     `json:"created_at"`
+	UpdatedAt     time.Time     `json:"updated_at"`
+
+	ProviderID int64            `json:"provider_id"`
+	Provider   *ProviderSummary `json:"provider,omitempty"` // solo en el detalle del servicio
+	Images     []*ServiceImage  `json:"images,omitempty"`   // solo en el detalle del servicio
 }
 
 // ServiceUpdateStatus representa la solicitud de actualización de estado
@@ -67,8 +77,24 @@ type ServiceUpdateStatus struct {
 	Status string `json:"status" validate:"required,oneof=active inactive"`
 }
 
+// ServiceFilter representa los filtros, el orden y la página del catálogo público de servicios
+type ServiceFilter struct {
+	Category string   // categoría exacta (normalizada)
+	Province string   // provincia de alguna de las zonas de cobertura
+	Locality string   // localidad de alguna de las zonas de cobertura
+	MinPrice *float64 // precio mínimo inclusive
+	MaxPrice *float64 // precio máximo inclusive
+	Query    string   // texto libre buscado en título y descripción
+	Sort     string   // "newest", "oldest", "price_asc", "price_desc", "title"
+	Page     int      // página, empezando en 1
+	PageSize int      // cantidad de servicios por página
+}
+
 // ServiceListResponse representa la respuesta de lista de servicios
 type ServiceListResponse struct {
-	Services []ServiceResponse `json:"services"`
-	Total    int               `json:"total"`
+	Services   []ServiceResponse `json:"services"`
+	Total      int               `json:"total"`                 // total de servicios que cumplen los filtros
+	Page       int               `json:"page,omitempty"`        // página devuelta (solo en listados paginados)
+	PageSize   int               `json:"page_size,omitempty"`   // tamaño de página
+	TotalPages int               `json:"total_pages,omitempty"` // cantidad total de páginas
 }
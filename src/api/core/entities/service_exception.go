@@ -0,0 +1,26 @@
+package entities
+
+import "time"
+
+// ServiceException representa una excepción a la agenda semanal de un servicio
+// para un rango de fechas: feriados, vacaciones u horarios especiales
+type ServiceException struct {
+	ID        int64       `json:"id"`
+	ServiceID int64       `json:"service_id"`
+	StartDate string      `json:"start_date"` // YYYY-MM-DD
+	EndDate   string      `json:"end_date"`   // YYYY-MM-DD, inclusive
+	Type      string      `json:"type"`       // "closed" o "custom_hours"
+	Ranges    []TimeRange `json:"ranges"`     // horarios que reemplazan a los semanales cuando type = "custom_hours"
+	Reason    string      `json:"reason"`
+	CreatedAt time.Time   `json:"created_at"`
+	UpdatedAt time.Time   `json:"updated_at"`
+}
+
+// ServiceExceptionRequest representa la solicitud de creación o actualización de una excepción
+type ServiceExceptionRequest struct {
+	StartDate string      `json:"start_date" validate:"required"`
+	EndDate   string      `json:"end_date" validate:"omitempty"` // por defecto igual a start_date
+	Type      string      `json:"type" validate:"required,oneof=closed custom_hours"`
+	Ranges    []TimeRange `json:"ranges" validate:"omitempty"`
+	Reason    string      `json:"reason" validate:"omitempty,max=255"`
+}
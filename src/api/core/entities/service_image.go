@@ -0,0 +1,30 @@
+package entities
+
+import "time"
+
+// MaxServiceImages es la cantidad máxima de fotos por servicio
+const MaxServiceImages = 10
+
+// ServiceImage es una foto subida de un servicio. Las URLs se arman al subirla con la URL pública
+// del almacenamiento; las claves se usan para borrar los archivos.
+type ServiceImage struct {
+	ID              int64     `json:"id"`
+	ServiceID       int64     `json:"service_id"`
+	Position        int       `json:"position"` // orden de la foto, empezando en 0; la primera es la portada
+	ImageKey        string    `json:"-"`
+	ThumbnailKey    string    `json:"-"`
+	URL             string    `json:"url"`
+	ThumbnailURL    string    `json:"thumbnail_url"`
+	ContentType     string    `json:"content_type"`
+	Width           int       `json:"width"`
+	Height          int       `json:"height"`
+	ThumbnailWidth  int       `json:"thumbnail_width"`
+	ThumbnailHeight int       `json:"thumbnail_height"`
+	SizeBytes       int       `json:"size_bytes"`
+	CreatedAt       time.Time `json:"created_at"`
+}
+
+// ServiceImageOrderRequest representa el nuevo orden de las fotos de un servicio
+type ServiceImageOrderRequest struct {
+	ImageIDs []int64 `json:"image_ids" validate:"required,min=1,max=10"`
+}
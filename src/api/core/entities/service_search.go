@@ -0,0 +1,32 @@
+package entities
+
+// ServiceSearchQuery representa una búsqueda de texto libre ya tokenizada
+type ServiceSearchQuery struct {
+	Terms    []string // términos normalizados (minúsculas, sin acentos ni stopwords)
+	Page     int      // página, empezando en 1
+	PageSize int      // cantidad de resultados por página
+}
+
+// ServiceSearchHit representa un servicio encontrado con su puntaje de relevancia
+type ServiceSearchHit struct {
+	Service *Service
+	Score   float64
+}
+
+// ServiceSearchResult representa un resultado de búsqueda con los fragmentos resaltados
+type ServiceSearchResult struct {
+	Service          ServiceResponse `json:"service"`
+	Score            float64         `json:"score"`
+	TitleHighlight   string          `json:"title_highlight"`   // título con <mark> en los términos encontrados
+	SnippetHighlight string          `json:"snippet_highlight"` // fragmento de la descripción con <mark>
+}
+
+// ServiceSearchResponse representa la respuesta de la búsqueda de servicios
+type ServiceSearchResponse struct {
+	Query      string                `json:"query"`
+	Results    []ServiceSearchResult `json:"results"`
+	Total      int                   `json:"total"`
+	Page       int                   `json:"page"`
+	PageSize   int                   `json:"page_size"`
+	TotalPages int                   `json:"total_pages"`
+}
@@ -0,0 +1,44 @@
+package entities
+
+import "time"
+
+// Session representa un inicio de sesión de un usuario en un dispositivo.
+// Los access tokens llevan el ID de la sesión; revocarla invalida todos sus tokens.
+type Session struct {
+	ID         int64      `json:"id"`
+	UserID     int64      `json:"user_id"`
+	UserAgent  string     `json:"user_agent"`
+	IPAddress  string     `json:"ip_address"`
+	ExpiresAt  time.Time  `json:"expires_at"` // vencimiento del refresh token vigente
+	RevokedAt  *time.Time `json:"revoked_at"`
+	LastUsedAt time.Time  `json:"last_used_at"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// IsActive indica si la sesión no fue revocada ni venció
+func (s *Session) IsActive(now time.Time) bool {
+	return s.RevokedAt == nil && now.Before(s.ExpiresAt)
+}
+
+// ClientInfo identifica el dispositivo desde el que se hace un request
+type ClientInfo struct {
+	UserAgent string
+	IPAddress string
+}
+
+// AuthTokens es la respuesta de login y refresh
+type AuthTokens struct {
+	Token            string `json:"token"` // igual a access_token; se mantiene para los clientes existentes
+	AccessToken      string `json:"access_token"`
+	RefreshToken     string `json:"refresh_token"`
+	TokenType        string `json:"token_type"`
+	ExpiresIn        int    `json:"expires_in"`         // segundos de vida del access token
+	RefreshExpiresIn int    `json:"refresh_expires_in"` // segundos de vida del refresh token
+
+	DeletionScheduledAt *time.Time `json:"deletion_scheduled_at,omitempty"` // la cuenta tiene una baja programada para esta fecha
+}
+
+// RefreshTokenRequest representa la solicitud de un nuevo access token
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token" validate:"required"`
+}
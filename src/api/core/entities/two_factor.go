@@ -0,0 +1,77 @@
+package entities
+
+import "time"
+
+// UserTOTP es el secreto TOTP de un usuario. Mientras ConfirmedAt sea nil la activación está pendiente
+// y el login no pide el segundo paso.
+type UserTOTP struct {
+	UserID       int64
+	Secret       string
+	ConfirmedAt  *time.Time
+	LastUsedStep int64 // último intervalo aceptado, para no aceptar dos veces el mismo código
+}
+
+// IsEnabled indica si el usuario confirmó la activación del 2FA
+func (t *UserTOTP) IsEnabled() bool {
+	return t != nil && t.ConfirmedAt != nil
+}
+
+// LoginChallenge es el desafío pendiente entre la contraseña y el código del segundo paso del login
+type LoginChallenge struct {
+	ID        int64
+	UserID    int64
+	Attempts  int
+	ExpiresAt time.Time
+	UsedAt    *time.Time
+}
+
+// TwoFactorChallenge es la respuesta del login cuando la cuenta tiene 2FA: en lugar de los tokens
+// se entrega un token de desafío de corta duración para canjear junto con el código
+type TwoFactorChallenge struct {
+	TwoFactorRequired bool   `json:"two_factor_required"`
+	ChallengeToken    string `json:"challenge_token"`
+	ExpiresIn         int    `json:"expires_in"` // segundos de vida del desafío
+}
+
+// LoginResult es el resultado del login: los tokens de sesión o, si la cuenta tiene 2FA, el desafío
+type LoginResult struct {
+	Tokens    *AuthTokens
+	Challenge *TwoFactorChallenge
+}
+
+// TwoFactorLogin representa el segundo paso del login. Code acepta un código TOTP de la app
+// o uno de los códigos de recuperación.
+type TwoFactorLogin struct {
+	ChallengeToken string `json:"challenge_token" validate:"required"`
+	Code           string `json:"code" validate:"required,max=20"`
+}
+
+// TwoFactorCode representa una solicitud que se confirma con un código TOTP o de recuperación
+type TwoFactorCode struct {
+	Code string `json:"code" validate:"required,max=20"`
+}
+
+// TwoFactorDisable representa la solicitud para desactivar el 2FA: se pide la contraseña y un código.
+// Las cuentas sin contraseña envían solo el código.
+type TwoFactorDisable struct {
+	Password string `json:"password" validate:"omitempty,min=8,max=16"`
+	Code     string `json:"code" validate:"required,max=20"`
+}
+
+// TwoFactorSetupResponse es el secreto nuevo para registrar en la app de autenticación
+type TwoFactorSetupResponse struct {
+	Secret     string `json:"secret"`
+	OTPAuthURI string `json:"otpauth_uri"` // para mostrar como código QR
+}
+
+// TwoFactorStatusResponse es el estado del 2FA de la cuenta
+type TwoFactorStatusResponse struct {
+	Enabled                bool       `json:"enabled"`
+	EnabledAt              *time.Time `json:"enabled_at,omitempty"`
+	RecoveryCodesRemaining int        `json:"recovery_codes_remaining"`
+}
+
+// RecoveryCodesResponse son los códigos de recuperación recién generados; se muestran una sola vez
+type RecoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
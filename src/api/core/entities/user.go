@@ -2,6 +2,18 @@ package entities
 
 import "time"
 
+// Idiomas en los que se envían las comunicaciones al usuario
+const (
+	LocaleSpanish    = "es"
+	LocaleEnglish    = "en"
+	LocalePortuguese = "pt"
+	// DefaultLocale es el idioma de los usuarios que no eligieron otro
+	DefaultLocale = LocaleSpanish
+)
+
+// SupportedLocales son los idiomas disponibles
+var SupportedLocales = []string{LocaleSpanish, LocaleEnglish, LocalePortuguese}
+
 type User struct {
 	ID         int64     `json:"id"`
 	Name       string    `json:"name"`
@@ -10,9 +22,65 @@ type User struct {
 	Locality   string    `json:"locality"`
 	Province   string    `json:"province"`
 	Phone      string    `json:"phone"`
+	Locale     string    `json:"locale"` // idioma preferido: "es", "en" o "pt"
 	FirstLogin bool      `json:"first_login"`
 	CreatedAt  time.Time `json:"created_at"`
 	UpdatedAt  time.Time `json:"updated_at"`
+
+	SuspendedAt      *time.Time `json:"suspended_at"`      // cuenta suspendida por un administrador; nil si está activa
+	SuspensionReason string     `json:"suspension_reason"` // motivo de la suspensión
+
+	EmailVerifiedAt *time.Time `json:"email_verified_at"` // fecha en que se confirmó el email; nil si falta verificarlo
+
+	FailedLoginCount int        `json:"failed_login_count"` // intentos de login fallidos consecutivos
+	LockedUntil      *time.Time `json:"locked_until"`       // el login está bloqueado hasta esta fecha; nil si no hay bloqueo
+
+	DeletionRequestedAt *time.Time `json:"deletion_requested_at"` // el usuario pidió eliminar la cuenta; nil si no hay baja pendiente
+	DeletionScheduledAt *time.Time `json:"deletion_scheduled_at"` // fecha en que vence el período de gracia y se anonimiza la cuenta
+	DeletedAt           *time.Time `json:"deleted_at"`            // la cuenta ya fue eliminada y sus datos personales anonimizados
+
+	DisplayName string `json:"display_name"` // nombre público del perfil de proveedor; vacío usa Name
+	Bio         string `json:"bio"`
+	AvatarURL   string `json:"avatar_url"`
+}
+
+// PublicName devuelve el nombre que se muestra en el perfil público
+func (u *User) PublicName() string {
+	if u.DisplayName != "" {
+		return u.DisplayName
+	}
+	return u.Name
+}
+
+// IsSuspended indica si la cuenta fue suspendida y no puede iniciar sesión
+func (u *User) IsSuspended() bool {
+	return u.SuspendedAt != nil
+}
+
+// IsEmailVerified indica si el usuario confirmó que el email de la cuenta es suyo
+func (u *User) IsEmailVerified() bool {
+	return u.EmailVerifiedAt != nil
+}
+
+// HasPassword indica si la cuenta tiene contraseña; las cuentas creadas con un proveedor externo
+// no tienen hasta que la definen con el restablecimiento de contraseña
+func (u *User) HasPassword() bool {
+	return u.Password != ""
+}
+
+// IsDeleted indica si la cuenta ya fue eliminada (sus datos personales fueron anonimizados)
+func (u *User) IsDeleted() bool {
+	return u.DeletedAt != nil
+}
+
+// IsDeletionPending indica si la eliminación de la cuenta está programada y se puede cancelar
+func (u *User) IsDeletionPending() bool {
+	return u.DeletionScheduledAt != nil && u.DeletedAt == nil
+}
+
+// IsLoginLocked indica si el login de la cuenta está bloqueado temporalmente por intentos fallidos
+func (u *User) IsLoginLocked(now time.Time) bool {
+	return u.LockedUntil != nil && now.Before(*u.LockedUntil)
 }
 
 // UserRegister representa la solicitud de registro de usuario
@@ -24,6 +92,7 @@ type UserRegister struct {
 	Locality        string `json:"locality" validate:"required,min=2,max=100"`
 	Province        string `json:"province" validate:"required,min=2,max=100"`
 	Phone           string `json:"phone" validate:"omitempty,min=10,max=20"`
+	Locale          string `json:"locale" validate:"omitempty,oneof=es en pt"`
 }
 
 // UserUpdate representa la solicitud de actualización de usuario
@@ -33,6 +102,13 @@ type UserUpdate struct {
 	Locality string `json:"locality" validate:"omitempty,min=2,max=100"`
 	Province string `json:"province" validate:"omitempty,min=2,max=100"`
 	Phone    string `json:"phone" validate:"omitempty,min=10,max=20"`
+	Locale   string `json:"locale" validate:"omitempty,oneof=es en pt"`
+
+	// Campos del perfil público. Son punteros para distinguir "no enviado" de "" (borrar el valor);
+	// avatar_url se valida en el use case porque un string vacío no es una URL
+	DisplayName *string `json:"display_name" validate:"omitempty,max=100"`
+	Bio         *string `json:"bio" validate:"omitempty,max=1000"`
+	AvatarURL   *string `json:"avatar_url" validate:"omitempty,max=500"`
 }
 
 // UserResponse representa la respuesta de información de usuario (sin password)
@@ -43,6 +119,22 @@ type UserResponse struct {
 	Locality  string `json:"locality"`
 	Province  string `json:"province"`
 	Phone     string `json:"phone"`
+	Locale    string `json:"locale"`
 	CreatedAt string `json:"created_at"`
 	UpdatedAt string `json:"updated_at"`
+
+	EmailVerified bool   `json:"email_verified"`
+	HasPassword   bool   `json:"has_password"`
+	PendingEmail  string `json:"pending_email,omitempty"` // nuevo email que espera confirmación; el email de la cuenta no cambia hasta verificarlo
+
+	DisplayName string `json:"display_name"`
+	Bio         string `json:"bio"`
+	AvatarURL   string `json:"avatar_url"`
+
+	DeletionScheduledAt *time.Time `json:"deletion_scheduled_at,omitempty"` // la cuenta se eliminará en esta fecha salvo que se cancele la baja
+}
+
+// VerifyEmail representa la solicitud para confirmar un email con el token recibido por correo
+type VerifyEmail struct {
+	Token string `json:"token" validate:"required"`
 }
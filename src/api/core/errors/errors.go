@@ -1,6 +1,22 @@
 package errors
 
-import "fmt"
+import (
+	stderrors "errors"
+	"fmt"
+)
+
+// ErrSlotTaken se devuelve cuando el horario solicitado ya tiene una cita activa
+var ErrSlotTaken = stderrors.New("time slot is already taken")
+
+// ErrAlreadyReviewed se devuelve cuando la cita ya tiene una reseña
+var ErrAlreadyReviewed = stderrors.New("appointment is already reviewed")
+
+// ErrRefreshTokenReused se devuelve cuando se presenta un refresh token que ya fue rotado;
+// indica que el token pudo haber sido robado y la sesión completa queda revocada
+var ErrRefreshTokenReused = stderrors.New("refresh token was already used")
+
+// ErrServiceImageLimit se devuelve cuando el servicio ya tiene la cantidad máxima de fotos
+var ErrServiceImageLimit = stderrors.New("service image limit reached")
 
 type APIError struct {
 	Code    int    `json:"code"`
@@ -26,6 +42,13 @@ func NewUnauthorized(message string) *APIError {
 	}
 }
 
+func NewForbidden(message string) *APIError {
+	return &APIError{
+		Code:    403,
+		Message: message,
+	}
+}
+
 func NewNotFound(message string) *APIError {
 	return &APIError{
 		Code:    404,
@@ -33,6 +56,44 @@ func NewNotFound(message string) *APIError {
 	}
 }
 
+func NewConflict(message string) *APIError {
+	return &APIError{
+		Code:    409,
+		Message: message,
+	}
+}
+
+// NewPayloadTooLarge indica que el archivo o el cuerpo enviado supera el tamaño permitido
+func NewPayloadTooLarge(message string) *APIError {
+	return &APIError{
+		Code:    413,
+		Message: message,
+	}
+}
+
+// NewUnsupportedMediaType indica que el tipo del archivo enviado no se acepta
+func NewUnsupportedMediaType(message string) *APIError {
+	return &APIError{
+		Code:    415,
+		Message: message,
+	}
+}
+
+func NewTooManyRequests(message string) *APIError {
+	return &APIError{
+		Code:    429,
+		Message: message,
+	}
+}
+
+// NewBadGateway indica que falló un servicio externo del que depende la operación
+func NewBadGateway(message string) *APIError {
+	return &APIError{
+		Code:    502,
+		Message: message,
+	}
+}
+
 func NewInternalServerError(message string) *APIError {
 	return &APIError{
 		Code:    500,
@@ -9,8 +9,22 @@ type Appointment interface {
 	Create(ctx context.Context, appointment *entities.AppointmentCreate, clientID int64) (*entities.Appointment, error)
 	GetByID(ctx context.Context, id int64) (*entities.Appointment, error)
 	GetByClientID(ctx context.Context, clientID int64) ([]*entities.Appointment, error)
+	GetByProviderID(ctx context.Context, providerID int64) ([]*entities.Appointment, error)
 	GetByServiceID(ctx context.Context, serviceID int64) ([]*entities.Appointment, error)
 	GetByServiceIDAndDate(ctx context.Context, serviceID int64, date string) ([]*entities.Appointment, error)
+	GetByServiceIDAndDateRange(ctx context.Context, serviceID int64, startDate string, endDate string) ([]*entities.Appointment, error)
 	UpdateStatus(ctx context.Context, id int64, status string, userID int64) error
 	Delete(ctx context.Context, id int64, clientID int64) error
+	// List devuelve una página de citas de cualquier usuario que cumplen el filtro y el total de coincidencias
+	List(ctx context.Context, filter *entities.AppointmentFilter) ([]*entities.Appointment, int, error)
+	// Reassign mueve una cita activa a otro servicio, fecha u horario. Devuelve errors.ErrSlotTaken si el
+	// horario está ocupado y sql.ErrNoRows si la cita ya no está activa o el servicio no existe o está inactivo.
+	Reassign(ctx context.Context, id int64, serviceID int64, date string, timeSlot string) (*entities.Appointment, error)
+	// CountUpcomingByUser cuenta las citas pendientes o aceptadas desde la fecha indicada en las que el
+	// usuario es cliente o proveedor
+	CountUpcomingByUser(ctx context.Context, userID int64, fromDate string) (int, error)
+	// CountCompletedByProvider cuenta las citas completadas de los servicios del proveedor
+	CountCompletedByProvider(ctx context.Context, providerID int64) (int, error)
+	// HasAcceptedBooking indica si el cliente tiene alguna cita aceptada o completada con el proveedor
+	HasAcceptedBooking(ctx context.Context, clientID int64, providerID int64) (bool, error)
 }
@@ -0,0 +1,12 @@
+package interfaces
+
+import (
+	"context"
+
+	"iycds2025_api/src/api/core/entities"
+)
+
+type AuditLog interface {
+	Record(ctx context.Context, entry *entities.AuditEntry) error
+	List(ctx context.Context, filter *entities.AuditFilter) ([]*entities.AuditEntry, int, error)
+}
@@ -0,0 +1,23 @@
+package interfaces
+
+import (
+	"context"
+	"time"
+
+	"iycds2025_api/src/api/core/entities"
+)
+
+type EmailOutbox interface {
+	Enqueue(ctx context.Context, kind string, recipient string, payload string) (int64, error)
+	// ClaimDue toma hasta limit correos listos para enviar y los reserva durante lease
+	ClaimDue(ctx context.Context, limit int, lease time.Duration) ([]*entities.OutboxEmail, error)
+	MarkSent(ctx context.Context, id int64) error
+	// MarkFailed registra el error y programa un nuevo intento dentro de retryIn
+	MarkFailed(ctx context.Context, id int64, lastError string, retryIn time.Duration) error
+	// MarkDead registra el error y descarta el correo sin más reintentos
+	MarkDead(ctx context.Context, id int64, lastError string) error
+	// Requeue vuelve a poner en cola un correo descartado
+	Requeue(ctx context.Context, id int64) error
+	GetByID(ctx context.Context, id int64) (*entities.OutboxEmail, error)
+	List(ctx context.Context, status string, limit int) ([]*entities.OutboxEmail, error)
+}
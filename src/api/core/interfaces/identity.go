@@ -0,0 +1,25 @@
+package interfaces
+
+import (
+	"context"
+
+	"iycds2025_api/src/api/core/entities"
+)
+
+type Identity interface {
+	// CreateLoginState guarda el estado de un login con un proveedor externo (se recibe solo el hash del state)
+	CreateLoginState(ctx context.Context, state *entities.ExternalLoginState) error
+	// ConsumeLoginState marca el estado como usado y lo devuelve; nil si no existe, venció o ya se usó
+	ConsumeLoginState(ctx context.Context, stateHash string) (*entities.ExternalLoginState, error)
+
+	GetByProviderSubject(ctx context.Context, provider string, subject string) (*entities.UserIdentity, error)
+	ListByUser(ctx context.Context, userID int64) ([]*entities.UserIdentity, error)
+	// Link vincula una identidad externa a una cuenta existente
+	Link(ctx context.Context, identity *entities.UserIdentity) error
+	// CreateUserWithIdentity crea una cuenta sin contraseña junto con su identidad externa
+	CreateUserWithIdentity(ctx context.Context, user *entities.User, identity *entities.UserIdentity) error
+	// RecordLogin actualiza la fecha del último login y el email informado por el proveedor
+	RecordLogin(ctx context.Context, id int64, email string) error
+	// Unlink quita la identidad del proveedor; devuelve false si la cuenta no la tenía vinculada
+	Unlink(ctx context.Context, userID int64, provider string) (bool, error)
+}
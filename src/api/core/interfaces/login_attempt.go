@@ -0,0 +1,11 @@
+package interfaces
+
+import (
+	"context"
+
+	"iycds2025_api/src/api/core/entities"
+)
+
+type LoginAttempt interface {
+	Record(ctx context.Context, attempt *entities.LoginAttempt) error
+}
@@ -0,0 +1,22 @@
+package interfaces
+
+import (
+	"context"
+
+	"iycds2025_api/src/api/core/entities"
+)
+
+type Review interface {
+	// Create guarda la reseña de una cita y recalcula el promedio del servicio
+	Create(ctx context.Context, appointment *entities.Appointment, review *entities.ReviewCreate) (*entities.Review, error)
+	GetByID(ctx context.Context, id int64) (*entities.Review, error)
+	GetByServiceID(ctx context.Context, serviceID int64, page int, pageSize int) ([]*entities.Review, int, error)
+	// GetByClientID devuelve todas las reseñas que escribió el usuario
+	GetByClientID(ctx context.Context, clientID int64) ([]*entities.Review, error)
+	// GetByProviderID devuelve todas las reseñas que recibieron los servicios del usuario
+	GetByProviderID(ctx context.Context, providerID int64) ([]*entities.Review, error)
+	// GetProviderRating devuelve el promedio y la cantidad de reseñas de todos los servicios del proveedor
+	GetProviderRating(ctx context.Context, providerID int64) (float64, int, error)
+	// Reply guarda la respuesta del proveedor; devuelve sql.ErrNoRows si no es suya o ya tiene respuesta
+	Reply(ctx context.Context, id int64, providerID int64, reply string) (*entities.Review, error)
+}
@@ -9,8 +9,10 @@ type Service interface {
 	Create(ctx context.Context, service *entities.ServiceCreate, userID int64) (*entities.Service, error)
 	GetByID(ctx context.Context, id int64) (*entities.Service, error)
 	GetByUserID(ctx context.Context, userID int64) ([]*entities.Service, error)
-	GetAllActive(ctx context.Context) ([]*entities.Service, error)
+	Search(ctx context.Context, filter *entities.ServiceFilter) ([]*entities.Service, int, error)
 	Update(ctx context.Context, id int64, service *entities.ServiceUpdate, userID int64) (*entities.Service, error)
 	UpdateStatus(ctx context.Context, id int64, status string, userID int64) error
 	Delete(ctx context.Context, id int64, userID int64) error
+	// ListForAdmin devuelve una página de servicios de cualquier estado y dueño y el total de coincidencias
+	ListForAdmin(ctx context.Context, filter *entities.AdminServiceFilter) ([]*entities.Service, int, error)
 }
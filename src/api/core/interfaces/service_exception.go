@@ -0,0 +1,15 @@
+package interfaces
+
+import (
+	"context"
+
+	"iycds2025_api/src/api/core/entities"
+)
+
+type ServiceException interface {
+	Create(ctx context.Context, serviceID int64, exception *entities.ServiceExceptionRequest) (*entities.ServiceException, error)
+	GetByID(ctx context.Context, id int64) (*entities.ServiceException, error)
+	GetByServiceIDAndDateRange(ctx context.Context, serviceID int64, startDate string, endDate string) ([]*entities.ServiceException, error)
+	Update(ctx context.Context, id int64, exception *entities.ServiceExceptionRequest) (*entities.ServiceException, error)
+	Delete(ctx context.Context, id int64) error
+}
@@ -0,0 +1,18 @@
+package interfaces
+
+import (
+	"context"
+
+	"iycds2025_api/src/api/core/entities"
+)
+
+type ServiceImage interface {
+	// Create agrega la foto al final del servicio; devuelve errors.ErrServiceImageLimit si ya tiene el máximo
+	Create(ctx context.Context, image *entities.ServiceImage) (*entities.ServiceImage, error)
+	GetByID(ctx context.Context, id int64) (*entities.ServiceImage, error)
+	GetByServiceID(ctx context.Context, serviceID int64) ([]*entities.ServiceImage, error)
+	Delete(ctx context.Context, id int64) error
+	// Reorder asigna las posiciones según el orden de imageIDs. Devuelve sql.ErrNoRows si la lista no
+	// tiene exactamente las fotos del servicio, cada una una vez.
+	Reorder(ctx context.Context, serviceID int64, imageIDs []int64) error
+}
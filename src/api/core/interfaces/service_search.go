@@ -0,0 +1,16 @@
+package interfaces
+
+import (
+	"context"
+	"iycds2025_api/src/api/core/entities"
+)
+
+// ServiceSearch es el índice de búsqueda de texto libre sobre los servicios
+type ServiceSearch interface {
+	// Index agrega o actualiza un servicio en el índice
+	Index(ctx context.Context, service *entities.Service) error
+	// Remove quita un servicio del índice
+	Remove(ctx context.Context, serviceID int64) error
+	// Search devuelve una página de servicios activos ordenados por relevancia y el total de coincidencias
+	Search(ctx context.Context, query *entities.ServiceSearchQuery) ([]*entities.ServiceSearchHit, int, error)
+}
@@ -0,0 +1,21 @@
+package interfaces
+
+import (
+	"context"
+	"time"
+
+	"iycds2025_api/src/api/core/entities"
+)
+
+type Session interface {
+	// Create abre una sesión con su primer refresh token (se recibe solo el hash)
+	Create(ctx context.Context, userID int64, refreshTokenHash string, expiresAt time.Time, client entities.ClientInfo) (*entities.Session, error)
+	GetByID(ctx context.Context, id int64) (*entities.Session, error)
+	// Rotate reemplaza un refresh token vigente por uno nuevo. Devuelve nil si el token no existe,
+	// venció o su sesión fue revocada, y errors.ErrRefreshTokenReused si ya había sido rotado.
+	Rotate(ctx context.Context, refreshTokenHash string, newRefreshTokenHash string, expiresAt time.Time) (*entities.Session, error)
+	// Revoke revoca una sesión del usuario
+	Revoke(ctx context.Context, id int64, userID int64) error
+	// RevokeAllForUser revoca todas las sesiones activas del usuario salvo exceptID (0 para revocar todas)
+	RevokeAllForUser(ctx context.Context, userID int64, exceptID int64) (int64, error)
+}
@@ -0,0 +1,37 @@
+package interfaces
+
+import (
+	"context"
+	"time"
+
+	"iycds2025_api/src/api/core/entities"
+)
+
+type TwoFactor interface {
+	// GetTOTP devuelve el secreto TOTP del usuario, confirmado o pendiente; nil si nunca inició la activación
+	GetTOTP(ctx context.Context, userID int64) (*entities.UserTOTP, error)
+	// SaveTOTPSecret guarda un secreto pendiente de confirmar; no reemplaza uno ya confirmado
+	SaveTOTPSecret(ctx context.Context, userID int64, secret string) error
+	// EnableTOTP confirma el secreto pendiente y reemplaza los códigos de recuperación (se reciben solo los hashes)
+	EnableTOTP(ctx context.Context, userID int64, step int64, recoveryCodeHashes []string) error
+	// DisableTOTP borra el secreto, los códigos de recuperación y los desafíos pendientes del usuario
+	DisableTOTP(ctx context.Context, userID int64) error
+	// UseTOTPStep marca el intervalo como usado; devuelve false si ya se había aceptado ese intervalo o uno posterior
+	UseTOTPStep(ctx context.Context, userID int64, step int64) (bool, error)
+
+	// ReplaceRecoveryCodes invalida los códigos de recuperación del usuario y guarda los nuevos
+	ReplaceRecoveryCodes(ctx context.Context, userID int64, codeHashes []string) error
+	// UseRecoveryCode consume un código de recuperación; devuelve false si no existe o ya fue usado
+	UseRecoveryCode(ctx context.Context, userID int64, codeHash string) (bool, error)
+	// CountRecoveryCodes devuelve cuántos códigos de recuperación quedan sin usar
+	CountRecoveryCodes(ctx context.Context, userID int64) (int, error)
+
+	// CreateChallenge guarda un desafío de login (se recibe solo el hash del token)
+	CreateChallenge(ctx context.Context, userID int64, tokenHash string, expiresAt time.Time, client entities.ClientInfo) error
+	// GetChallenge devuelve un desafío vigente y sin usar; nil si no existe, venció o ya se canjeó
+	GetChallenge(ctx context.Context, tokenHash string) (*entities.LoginChallenge, error)
+	// RecordChallengeFailure suma un código incorrecto al desafío y devuelve los intentos acumulados
+	RecordChallengeFailure(ctx context.Context, id int64) (int, error)
+	// ConsumeChallenge marca el desafío como usado; devuelve false si otro request ya lo canjeó
+	ConsumeChallenge(ctx context.Context, id int64) (bool, error)
+}
@@ -16,4 +16,42 @@ type User interface {
 	GetRole(ctx context.Context, userID int64) (string, error)
 	CreatePasswordResetToken(ctx context.Context, userID int64) (string, time.Time, error)
 	ResetPassword(ctx context.Context, token string, newPassword string) error
+	// ChangePassword guarda la nueva contraseña ya hasheada y revoca las demás sesiones; devuelve sql.ErrNoRows si el usuario no existe
+	ChangePassword(ctx context.Context, userID int64, hashedPassword string, currentSessionID int64) (int64, error)
+
+	// CreateEmailVerificationToken crea el token para confirmar el email indicado (el de la cuenta o uno nuevo)
+	CreateEmailVerificationToken(ctx context.Context, userID int64, email string) (string, time.Time, error)
+	// GetPendingVerificationEmail devuelve el email que espera confirmación, o "" si no hay ninguno
+	GetPendingVerificationEmail(ctx context.Context, userID int64) (string, error)
+	// VerifyEmail confirma el email del token y devuelve el usuario actualizado
+	VerifyEmail(ctx context.Context, token string) (*entities.User, error)
+
+	// RecordLoginFailure suma un intento de login fallido y devuelve los fallidos consecutivos dentro de window
+	RecordLoginFailure(ctx context.Context, userID int64, window time.Duration) (int, error)
+	// LockLogin bloquea el login de la cuenta hasta la fecha indicada
+	LockLogin(ctx context.Context, userID int64, until time.Time) error
+	// ResetLoginFailures borra los intentos fallidos y el bloqueo de la cuenta
+	ResetLoginFailures(ctx context.Context, userID int64) error
+
+	// Consultas y acciones del back-office
+
+	// List devuelve una página de usuarios que cumplen el filtro y el total de coincidencias
+	List(ctx context.Context, filter *entities.UserFilter) ([]*entities.User, int, error)
+	// Suspend suspende la cuenta y cierra todas sus sesiones; devuelve sql.ErrNoRows si el usuario no existe
+	Suspend(ctx context.Context, userID int64, reason string) error
+	// Reactivate levanta la suspensión; devuelve sql.ErrNoRows si el usuario no existe
+	Reactivate(ctx context.Context, userID int64) error
+
+	// Baja de cuentas
+
+	// ScheduleDeletion programa la eliminación de la cuenta, desactiva sus servicios y cierra todas sus
+	// sesiones; devuelve sql.ErrNoRows si el usuario no existe o ya fue eliminado
+	ScheduleDeletion(ctx context.Context, userID int64, scheduledAt time.Time) error
+	// CancelDeletion cancela la baja programada; devuelve false si no había ninguna pendiente
+	CancelDeletion(ctx context.Context, userID int64) (bool, error)
+	// ListDueDeletions devuelve los IDs de las cuentas cuyo período de gracia ya venció
+	ListDueDeletions(ctx context.Context, limit int) ([]int64, error)
+	// Anonymize borra los datos personales de una cuenta con la baja vencida y la marca como eliminada;
+	// devuelve sql.ErrNoRows si la baja se canceló o la cuenta ya estaba eliminada
+	Anonymize(ctx context.Context, userID int64) error
 }
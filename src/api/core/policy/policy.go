@@ -0,0 +1,83 @@
+package policy
+
+import (
+	"iycds2025_api/src/api/core/entities"
+)
+
+// Roles asignados en la tabla user_roles
+const (
+	RoleUser  = "user"
+	RoleAdmin = "admin"
+)
+
+// Permisos asignados en la tabla user_permissions. El rol admin tiene todos los permisos.
+const (
+	PermissionRead                 = "read"
+	PermissionModerateServices     = "services:moderate"
+	PermissionModerateAppointments = "appointments:moderate"
+)
+
+// Actor es el usuario autenticado que realiza una operación, con el rol y los permisos de su token
+type Actor struct {
+	UserID      int64
+	Role        string
+	Permissions []string
+}
+
+// IsAdmin indica si el actor tiene el rol de administrador
+func (a Actor) IsAdmin() bool {
+	return a.Role == RoleAdmin
+}
+
+// HasRole indica si el actor tiene alguno de los roles indicados
+func (a Actor) HasRole(roles ...string) bool {
+	for _, role := range roles {
+		if a.Role == role {
+			return true
+		}
+	}
+	return false
+}
+
+// Can indica si el actor tiene el permiso indicado
+func (a Actor) Can(permission string) bool {
+	if a.IsAdmin() {
+		return true
+	}
+	for _, p := range a.Permissions {
+		if p == permission {
+			return true
+		}
+	}
+	return false
+}
+
+// CanManageService indica si el actor puede editar, pausar o eliminar un servicio,
+// administrar su agenda y ver sus citas: el dueño o un moderador de servicios
+func CanManageService(actor Actor, service *entities.Service) bool {
+	return service.UserID == actor.UserID || actor.Can(PermissionModerateServices)
+}
+
+// CanUpdateAppointmentStatus indica si el actor puede pasar una cita al estado indicado.
+// El proveedor acepta, rechaza y completa; cualquiera de las dos partes puede cancelar.
+// Un moderador de citas puede realizar cualquier cambio de estado.
+func CanUpdateAppointmentStatus(actor Actor, appointment *entities.Appointment, status string) bool {
+	if actor.Can(PermissionModerateAppointments) {
+		return true
+	}
+
+	switch status {
+	case "accepted", "rejected", "completed":
+		return appointment.ProviderID == actor.UserID
+	case "cancelled":
+		return appointment.ClientID == actor.UserID || appointment.ProviderID == actor.UserID
+	default:
+		return false
+	}
+}
+
+// CanReassignAppointment indica si el actor puede mover una cita a otro servicio u horario;
+// es una acción de moderación que las partes no pueden hacer por su cuenta
+func CanReassignAppointment(actor Actor) bool {
+	return actor.Can(PermissionModerateAppointments)
+}
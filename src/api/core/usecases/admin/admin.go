@@ -0,0 +1,82 @@
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"iycds2025_api/src/api/core/entities"
+	"iycds2025_api/src/api/core/errors"
+	"iycds2025_api/src/api/core/interfaces"
+	"iycds2025_api/src/api/core/policy"
+)
+
+const (
+	// DefaultPageSize es la cantidad de resultados por página de los listados del back-office
+	DefaultPageSize = 20
+	// MaxPageSize es la cantidad máxima de resultados que se pueden pedir por página
+	MaxPageSize = 100
+)
+
+// requireAdmin verifica que el actor sea administrador. Las rutas ya lo exigen con
+// RequireRole; se repite acá para que los casos de uso no dependan de cómo se los expone.
+func requireAdmin(actor policy.Actor) error {
+	if !actor.IsAdmin() {
+		return errors.NewForbidden("Only administrators can perform this action")
+	}
+	return nil
+}
+
+// requirePermission verifica que el actor tenga el permiso de moderación indicado (el admin
+// los tiene todos). Las rutas ya lo exigen con RequirePermission.
+func requirePermission(actor policy.Actor, permission string) error {
+	if !actor.Can(permission) {
+		return errors.NewForbidden("You don't have permission to perform this action")
+	}
+	return nil
+}
+
+// normalizePage valida la página pedida y completa los valores por defecto
+func normalizePage(page *int, pageSize *int) error {
+	if *page == 0 {
+		*page = 1
+	}
+	if *page < 1 {
+		return errors.NewBadRequest("Page must be greater than 0")
+	}
+	if *pageSize == 0 {
+		*pageSize = DefaultPageSize
+	}
+	if *pageSize < 1 || *pageSize > MaxPageSize {
+		return errors.NewBadRequest(fmt.Sprintf("Page size must be between 1 and %d", MaxPageSize))
+	}
+	return nil
+}
+
+func totalPages(total int, pageSize int) int {
+	return (total + pageSize - 1) / pageSize
+}
+
+// recordAudit registra una acción de administración. La acción ya se realizó: si el registro
+// falla solo se deja constancia en los logs.
+func recordAudit(ctx context.Context, auditLog interfaces.AuditLog, actor policy.Actor, client entities.ClientInfo, action string, targetType string, targetID int64, details map[string]interface{}) {
+	entry := &entities.AuditEntry{
+		ActorID:    actor.UserID,
+		Action:     action,
+		TargetType: targetType,
+		TargetID:   targetID,
+		IPAddress:  client.IPAddress,
+	}
+	if details != nil {
+		encoded, err := json.Marshal(details)
+		if err != nil {
+			fmt.Printf("Error encoding audit details for %s %s %d: %v\n", action, targetType, targetID, err)
+		} else {
+			entry.Details = encoded
+		}
+	}
+
+	if err := auditLog.Record(ctx, entry); err != nil {
+		fmt.Printf("Error recording audit entry %s %s %d by user %d: %v\n", action, targetType, targetID, actor.UserID, err)
+	}
+}
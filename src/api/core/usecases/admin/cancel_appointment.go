@@ -0,0 +1,50 @@
+package admin
+
+import (
+	"context"
+
+	"iycds2025_api/src/api/core/entities"
+	"iycds2025_api/src/api/core/errors"
+	"iycds2025_api/src/api/core/interfaces"
+	"iycds2025_api/src/api/core/policy"
+	"iycds2025_api/src/api/core/usecases/appointment"
+)
+
+type CancelAppointment interface {
+	Execute(ctx context.Context, appointmentID int64, request *entities.AdminActionRequest, actor policy.Actor, client entities.ClientInfo) error
+}
+
+type CancelAppointmentImpl struct {
+	Appointment             interfaces.Appointment
+	UpdateAppointmentStatus appointment.UpdateAppointmentStatus
+	AuditLog                interfaces.AuditLog
+}
+
+// Execute cancela cualquier cita; las dos partes reciben el aviso de cancelación
+func (uc *CancelAppointmentImpl) Execute(ctx context.Context, appointmentID int64, request *entities.AdminActionRequest, actor policy.Actor, client entities.ClientInfo) error {
+	if err := requirePermission(actor, policy.PermissionModerateAppointments); err != nil {
+		return err
+	}
+
+	existing, err := uc.Appointment.GetByID(ctx, appointmentID)
+	if err != nil {
+		return errors.NewInternalServerError("Failed to get appointment: " + err.Error())
+	}
+	if existing == nil {
+		return errors.NewNotFound("Appointment not found")
+	}
+
+	if err := uc.UpdateAppointmentStatus.Execute(ctx, appointmentID, "cancelled", actor); err != nil {
+		return err
+	}
+
+	recordAudit(ctx, uc.AuditLog, actor, client, entities.AuditActionAppointmentCancel, entities.AuditTargetAppointment, appointmentID, map[string]interface{}{
+		"reason":          request.Reason,
+		"previous_status": existing.Status,
+		"service_id":      existing.ServiceID,
+		"client_id":       existing.ClientID,
+		"provider_id":     existing.ProviderID,
+	})
+
+	return nil
+}
@@ -0,0 +1,49 @@
+package admin
+
+import (
+	"context"
+
+	"iycds2025_api/src/api/core/entities"
+	"iycds2025_api/src/api/core/errors"
+	"iycds2025_api/src/api/core/interfaces"
+	"iycds2025_api/src/api/core/policy"
+	"iycds2025_api/src/api/core/usecases/service"
+)
+
+type DeactivateService interface {
+	Execute(ctx context.Context, serviceID int64, request *entities.AdminActionRequest, actor policy.Actor, client entities.ClientInfo) error
+}
+
+type DeactivateServiceImpl struct {
+	Service             interfaces.Service
+	UpdateServiceStatus service.UpdateServiceStatus
+	AuditLog            interfaces.AuditLog
+}
+
+// Execute pausa el servicio de cualquier proveedor: deja de aparecer en el catálogo y no acepta reservas
+func (uc *DeactivateServiceImpl) Execute(ctx context.Context, serviceID int64, request *entities.AdminActionRequest, actor policy.Actor, client entities.ClientInfo) error {
+	if err := requirePermission(actor, policy.PermissionModerateServices); err != nil {
+		return err
+	}
+
+	existing, err := uc.Service.GetByID(ctx, serviceID)
+	if err != nil {
+		return errors.NewInternalServerError("Failed to get service: " + err.Error())
+	}
+	if existing == nil {
+		return errors.NewNotFound("Service not found")
+	}
+
+	// El caso de uso del proveedor aplica la política (un moderador puede pausar cualquier servicio)
+	if err := uc.UpdateServiceStatus.Execute(ctx, serviceID, "inactive", actor); err != nil {
+		return err
+	}
+
+	recordAudit(ctx, uc.AuditLog, actor, client, entities.AuditActionServiceDeactivate, entities.AuditTargetService, serviceID, map[string]interface{}{
+		"reason":   request.Reason,
+		"owner_id": existing.UserID,
+		"title":    existing.Title,
+	})
+
+	return nil
+}
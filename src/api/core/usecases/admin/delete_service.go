@@ -0,0 +1,51 @@
+package admin
+
+import (
+	"context"
+
+	"iycds2025_api/src/api/core/entities"
+	"iycds2025_api/src/api/core/errors"
+	"iycds2025_api/src/api/core/interfaces"
+	"iycds2025_api/src/api/core/policy"
+	"iycds2025_api/src/api/core/usecases/service"
+)
+
+type DeleteService interface {
+	Execute(ctx context.Context, serviceID int64, request *entities.AdminActionRequest, actor policy.Actor, client entities.ClientInfo) error
+}
+
+type DeleteServiceImpl struct {
+	Service       interfaces.Service
+	DeleteService service.DeleteService
+	AuditLog      interfaces.AuditLog
+}
+
+// Execute elimina de manera definitiva el servicio de cualquier proveedor
+func (uc *DeleteServiceImpl) Execute(ctx context.Context, serviceID int64, request *entities.AdminActionRequest, actor policy.Actor, client entities.ClientInfo) error {
+	if err := requirePermission(actor, policy.PermissionModerateServices); err != nil {
+		return err
+	}
+
+	// Guardar los datos del servicio antes de eliminarlo para que queden en la auditoría
+	existing, err := uc.Service.GetByID(ctx, serviceID)
+	if err != nil {
+		return errors.NewInternalServerError("Failed to get service: " + err.Error())
+	}
+	if existing == nil {
+		return errors.NewNotFound("Service not found")
+	}
+
+	if err := uc.DeleteService.Execute(ctx, serviceID, actor); err != nil {
+		return err
+	}
+
+	recordAudit(ctx, uc.AuditLog, actor, client, entities.AuditActionServiceDelete, entities.AuditTargetService, serviceID, map[string]interface{}{
+		"reason":   request.Reason,
+		"owner_id": existing.UserID,
+		"title":    existing.Title,
+		"category": existing.Category,
+		"status":   existing.Status,
+	})
+
+	return nil
+}
@@ -0,0 +1,63 @@
+package admin
+
+import (
+	"context"
+
+	"iycds2025_api/src/api/core/entities"
+	"iycds2025_api/src/api/core/errors"
+	"iycds2025_api/src/api/core/interfaces"
+	"iycds2025_api/src/api/core/policy"
+	"iycds2025_api/src/api/utils"
+)
+
+// validAppointmentStatuses son los estados por los que se puede filtrar el listado de citas
+var validAppointmentStatuses = map[string]bool{
+	"pending":   true,
+	"accepted":  true,
+	"rejected":  true,
+	"cancelled": true,
+	"completed": true,
+}
+
+type ListAppointments interface {
+	Execute(ctx context.Context, filter *entities.AppointmentFilter, actor policy.Actor) (*entities.AppointmentListResponse, error)
+}
+
+type ListAppointmentsImpl struct {
+	Appointment interfaces.Appointment
+}
+
+func (uc *ListAppointmentsImpl) Execute(ctx context.Context, filter *entities.AppointmentFilter, actor policy.Actor) (*entities.AppointmentListResponse, error) {
+	if err := requirePermission(actor, policy.PermissionModerateAppointments); err != nil {
+		return nil, err
+	}
+
+	if filter.Status != "" && !validAppointmentStatuses[filter.Status] {
+		return nil, errors.NewBadRequest("Invalid status. Valid values: pending, accepted, rejected, cancelled, completed")
+	}
+	if filter.DateFrom != "" && !utils.ValidateDateFormat(filter.DateFrom) {
+		return nil, errors.NewBadRequest("Invalid from date format. Use YYYY-MM-DD")
+	}
+	if filter.DateTo != "" && !utils.ValidateDateFormat(filter.DateTo) {
+		return nil, errors.NewBadRequest("Invalid to date format. Use YYYY-MM-DD")
+	}
+	if filter.DateFrom != "" && filter.DateTo != "" && filter.DateTo < filter.DateFrom {
+		return nil, errors.NewBadRequest("to date cannot be before from date")
+	}
+	if err := normalizePage(&filter.Page, &filter.PageSize); err != nil {
+		return nil, err
+	}
+
+	appointments, total, err := uc.Appointment.List(ctx, filter)
+	if err != nil {
+		return nil, errors.NewInternalServerError("Failed to get appointments: " + err.Error())
+	}
+
+	return &entities.AppointmentListResponse{
+		Appointments: appointments,
+		Total:        total,
+		Page:         filter.Page,
+		PageSize:     filter.PageSize,
+		TotalPages:   totalPages(total, filter.PageSize),
+	}, nil
+}
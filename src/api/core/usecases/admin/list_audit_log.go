@@ -0,0 +1,46 @@
+package admin
+
+import (
+	"context"
+
+	"iycds2025_api/src/api/core/entities"
+	"iycds2025_api/src/api/core/errors"
+	"iycds2025_api/src/api/core/interfaces"
+	"iycds2025_api/src/api/core/policy"
+)
+
+type ListAuditLog interface {
+	Execute(ctx context.Context, filter *entities.AuditFilter, actor policy.Actor) (*entities.AuditLogResponse, error)
+}
+
+type ListAuditLogImpl struct {
+	AuditLog interfaces.AuditLog
+}
+
+func (uc *ListAuditLogImpl) Execute(ctx context.Context, filter *entities.AuditFilter, actor policy.Actor) (*entities.AuditLogResponse, error) {
+	if err := requireAdmin(actor); err != nil {
+		return nil, err
+	}
+
+	switch filter.TargetType {
+	case "", entities.AuditTargetUser, entities.AuditTargetService, entities.AuditTargetAppointment:
+	default:
+		return nil, errors.NewBadRequest("Invalid target_type. Allowed values: user, service, appointment")
+	}
+	if err := normalizePage(&filter.Page, &filter.PageSize); err != nil {
+		return nil, err
+	}
+
+	entries, total, err := uc.AuditLog.List(ctx, filter)
+	if err != nil {
+		return nil, errors.NewInternalServerError("Failed to get audit log: " + err.Error())
+	}
+
+	return &entities.AuditLogResponse{
+		Entries:    entries,
+		Total:      total,
+		Page:       filter.Page,
+		PageSize:   filter.PageSize,
+		TotalPages: totalPages(total, filter.PageSize),
+	}, nil
+}
@@ -0,0 +1,74 @@
+package admin
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"iycds2025_api/src/api/core/entities"
+	"iycds2025_api/src/api/core/errors"
+	"iycds2025_api/src/api/core/interfaces"
+	"iycds2025_api/src/api/core/policy"
+	"iycds2025_api/src/api/utils"
+)
+
+type ListServices interface {
+	Execute(ctx context.Context, filter *entities.AdminServiceFilter, actor policy.Actor) (*entities.AdminServiceListResponse, error)
+}
+
+type ListServicesImpl struct {
+	Service interfaces.Service
+}
+
+func (uc *ListServicesImpl) Execute(ctx context.Context, filter *entities.AdminServiceFilter, actor policy.Actor) (*entities.AdminServiceListResponse, error) {
+	if err := requirePermission(actor, policy.PermissionModerateServices); err != nil {
+		return nil, err
+	}
+
+	filter.Query = strings.TrimSpace(filter.Query)
+	if len(filter.Query) > maxQueryLength {
+		return nil, errors.NewBadRequest(fmt.Sprintf("Search query cannot exceed %d characters", maxQueryLength))
+	}
+	if filter.Status != "" && filter.Status != "active" && filter.Status != "inactive" {
+		return nil, errors.NewBadRequest("Invalid status. Allowed values: active, inactive")
+	}
+	if filter.Category != "" {
+		normalizedCategory, ok := utils.NormalizeCategory(filter.Category)
+		if !ok {
+			return nil, errors.NewBadRequest("Invalid category. Valid categories are: " + strings.Join(utils.GetValidCategories(), ", "))
+		}
+		filter.Category = normalizedCategory
+	}
+	if err := normalizePage(&filter.Page, &filter.PageSize); err != nil {
+		return nil, err
+	}
+
+	services, total, err := uc.Service.ListForAdmin(ctx, filter)
+	if err != nil {
+		return nil, errors.NewInternalServerError("Failed to get services: " + err.Error())
+	}
+
+	responses := make([]entities.AdminServiceResponse, len(services))
+	for i, service := range services {
+		responses[i] = entities.AdminServiceResponse{
+			ID:            service.ID,
+			UserID:        service.UserID,
+			Title:         service.Title,
+			Category:      service.Category,
+			Price:         service.Price,
+			Status:        service.Status,
+			RatingAverage: service.RatingAverage,
+			ReviewCount:   service.ReviewCount,
+			CreatedAt:     service.CreatedAt,
+			UpdatedAt:     service.UpdatedAt,
+		}
+	}
+
+	return &entities.AdminServiceListResponse{
+		Services:   responses,
+		Total:      total,
+		Page:       filter.Page,
+		PageSize:   filter.PageSize,
+		TotalPages: totalPages(total, filter.PageSize),
+	}, nil
+}
@@ -0,0 +1,90 @@
+package admin
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"iycds2025_api/src/api/core/entities"
+	"iycds2025_api/src/api/core/errors"
+	"iycds2025_api/src/api/core/interfaces"
+	"iycds2025_api/src/api/core/policy"
+)
+
+// maxQueryLength limita el largo del texto de búsqueda de los listados
+const maxQueryLength = 100
+
+type ListUsers interface {
+	Execute(ctx context.Context, filter *entities.UserFilter, actor policy.Actor) (*entities.AdminUserListResponse, error)
+}
+
+type ListUsersImpl struct {
+	User interfaces.User
+}
+
+func (uc *ListUsersImpl) Execute(ctx context.Context, filter *entities.UserFilter, actor policy.Actor) (*entities.AdminUserListResponse, error) {
+	if err := requireAdmin(actor); err != nil {
+		return nil, err
+	}
+
+	filter.Query = strings.TrimSpace(filter.Query)
+	if len(filter.Query) > maxQueryLength {
+		return nil, errors.NewBadRequest(fmt.Sprintf("Search query cannot exceed %d characters", maxQueryLength))
+	}
+	if filter.Status != "" && filter.Status != entities.UserStatusActive && filter.Status != entities.UserStatusSuspended && filter.Status != entities.UserStatusDeleted {
+		return nil, errors.NewBadRequest("Invalid status. Allowed values: active, suspended, deleted")
+	}
+	if err := normalizePage(&filter.Page, &filter.PageSize); err != nil {
+		return nil, err
+	}
+
+	users, total, err := uc.User.List(ctx, filter)
+	if err != nil {
+		return nil, errors.NewInternalServerError("Failed to get users: " + err.Error())
+	}
+
+	responses := make([]entities.AdminUserResponse, len(users))
+	for i, user := range users {
+		responses[i] = toAdminUserResponse(user)
+	}
+
+	return &entities.AdminUserListResponse{
+		Users:      responses,
+		Total:      total,
+		Page:       filter.Page,
+		PageSize:   filter.PageSize,
+		TotalPages: totalPages(total, filter.PageSize),
+	}, nil
+}
+
+// toAdminUserResponse convierte un usuario a su representación en el back-office (sin password)
+func toAdminUserResponse(user *entities.User) entities.AdminUserResponse {
+	status := entities.UserStatusActive
+	if user.IsDeleted() {
+		status = entities.UserStatusDeleted
+	} else if user.IsSuspended() {
+		status = entities.UserStatusSuspended
+	}
+
+	response := entities.AdminUserResponse{
+		ID:               user.ID,
+		Name:             user.Name,
+		Email:            user.Email,
+		EmailVerifiedAt:  user.EmailVerifiedAt,
+		Locality:         user.Locality,
+		Province:         user.Province,
+		Phone:            user.Phone,
+		Locale:           user.Locale,
+		Status:           status,
+		SuspendedAt:      user.SuspendedAt,
+		SuspensionReason: user.SuspensionReason,
+		LoginLockedUntil: user.LockedUntil,
+		DeletedAt:        user.DeletedAt,
+		CreatedAt:        user.CreatedAt,
+		UpdatedAt:        user.UpdatedAt,
+	}
+	if user.IsDeletionPending() {
+		response.DeletionScheduledAt = user.DeletionScheduledAt
+	}
+	return response
+}
@@ -0,0 +1,57 @@
+package admin
+
+import (
+	"context"
+	"database/sql"
+
+	"iycds2025_api/src/api/core/entities"
+	"iycds2025_api/src/api/core/errors"
+	"iycds2025_api/src/api/core/interfaces"
+	"iycds2025_api/src/api/core/policy"
+)
+
+type ReactivateUser interface {
+	Execute(ctx context.Context, userID int64, request *entities.AdminActionRequest, actor policy.Actor, client entities.ClientInfo) (*entities.AdminUserResponse, error)
+}
+
+type ReactivateUserImpl struct {
+	User     interfaces.User
+	AuditLog interfaces.AuditLog
+}
+
+// Execute levanta la suspensión de la cuenta; el usuario vuelve a poder iniciar sesión
+func (uc *ReactivateUserImpl) Execute(ctx context.Context, userID int64, request *entities.AdminActionRequest, actor policy.Actor, client entities.ClientInfo) (*entities.AdminUserResponse, error) {
+	if err := requireAdmin(actor); err != nil {
+		return nil, err
+	}
+
+	user, err := uc.User.GetByID(ctx, userID)
+	if err != nil {
+		return nil, errors.NewInternalServerError("Failed to get user: " + err.Error())
+	}
+	if user == nil {
+		return nil, errors.NewNotFound("User not found")
+	}
+	if !user.IsSuspended() {
+		return nil, errors.NewBadRequest("User is not suspended")
+	}
+
+	if err := uc.User.Reactivate(ctx, userID); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.NewNotFound("User not found")
+		}
+		return nil, errors.NewInternalServerError("Failed to reactivate user: " + err.Error())
+	}
+
+	recordAudit(ctx, uc.AuditLog, actor, client, entities.AuditActionUserReactivate, entities.AuditTargetUser, userID, map[string]interface{}{
+		"reason":            request.Reason,
+		"email":             user.Email,
+		"suspended_at":      user.SuspendedAt,
+		"suspension_reason": user.SuspensionReason,
+	})
+
+	user.SuspendedAt = nil
+	user.SuspensionReason = ""
+	response := toAdminUserResponse(user)
+	return &response, nil
+}
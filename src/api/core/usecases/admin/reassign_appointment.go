@@ -0,0 +1,61 @@
+package admin
+
+import (
+	"context"
+
+	"iycds2025_api/src/api/core/entities"
+	"iycds2025_api/src/api/core/errors"
+	"iycds2025_api/src/api/core/interfaces"
+	"iycds2025_api/src/api/core/policy"
+	"iycds2025_api/src/api/core/usecases/appointment"
+)
+
+type ReassignAppointment interface {
+	Execute(ctx context.Context, appointmentID int64, request *entities.AppointmentReassign, actor policy.Actor, client entities.ClientInfo) (*entities.AppointmentResponse, error)
+}
+
+type ReassignAppointmentImpl struct {
+	Appointment         interfaces.Appointment
+	ReassignAppointment appointment.ReassignAppointment
+	AuditLog            interfaces.AuditLog
+}
+
+// Execute mueve una cita activa a otro servicio, fecha u horario
+func (uc *ReassignAppointmentImpl) Execute(ctx context.Context, appointmentID int64, request *entities.AppointmentReassign, actor policy.Actor, client entities.ClientInfo) (*entities.AppointmentResponse, error) {
+	if err := requirePermission(actor, policy.PermissionModerateAppointments); err != nil {
+		return nil, err
+	}
+
+	existing, err := uc.Appointment.GetByID(ctx, appointmentID)
+	if err != nil {
+		return nil, errors.NewInternalServerError("Failed to get appointment: " + err.Error())
+	}
+	if existing == nil {
+		return nil, errors.NewNotFound("Appointment not found")
+	}
+
+	response, err := uc.ReassignAppointment.Execute(ctx, appointmentID, request, actor)
+	if err != nil {
+		return nil, err
+	}
+
+	recordAudit(ctx, uc.AuditLog, actor, client, entities.AuditActionAppointmentReassign, entities.AuditTargetAppointment, appointmentID, map[string]interface{}{
+		"reason": request.Reason,
+		"from": map[string]interface{}{
+			"service_id":  existing.ServiceID,
+			"provider_id": existing.ProviderID,
+			"date":        existing.Date,
+			"time_slot":   existing.TimeSlot,
+			"status":      existing.Status,
+		},
+		"to": map[string]interface{}{
+			"service_id":  response.Service.ID,
+			"provider_id": response.ProviderID,
+			"date":        response.Date,
+			"time_slot":   response.TimeSlot,
+			"status":      response.Status,
+		},
+	})
+
+	return response, nil
+}
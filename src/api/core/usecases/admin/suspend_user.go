@@ -0,0 +1,67 @@
+package admin
+
+import (
+	"context"
+	"database/sql"
+
+	"iycds2025_api/src/api/core/entities"
+	"iycds2025_api/src/api/core/errors"
+	"iycds2025_api/src/api/core/interfaces"
+	"iycds2025_api/src/api/core/policy"
+)
+
+type SuspendUser interface {
+	Execute(ctx context.Context, userID int64, request *entities.AdminActionRequest, actor policy.Actor, client entities.ClientInfo) (*entities.AdminUserResponse, error)
+}
+
+type SuspendUserImpl struct {
+	User     interfaces.User
+	AuditLog interfaces.AuditLog
+}
+
+// Execute suspende la cuenta: el usuario no puede iniciar sesión y sus sesiones abiertas se cierran
+func (uc *SuspendUserImpl) Execute(ctx context.Context, userID int64, request *entities.AdminActionRequest, actor policy.Actor, client entities.ClientInfo) (*entities.AdminUserResponse, error) {
+	if err := requireAdmin(actor); err != nil {
+		return nil, err
+	}
+	if userID == actor.UserID {
+		return nil, errors.NewBadRequest("You cannot suspend your own account")
+	}
+
+	user, err := uc.User.GetByID(ctx, userID)
+	if err != nil {
+		return nil, errors.NewInternalServerError("Failed to get user: " + err.Error())
+	}
+	if user == nil {
+		return nil, errors.NewNotFound("User not found")
+	}
+
+	// Un administrador no puede suspender a otro: primero hay que quitarle el rol
+	role, err := uc.User.GetRole(ctx, userID)
+	if err != nil {
+		return nil, errors.NewInternalServerError("Failed to get user role: " + err.Error())
+	}
+	if role == policy.RoleAdmin {
+		return nil, errors.NewForbidden("Administrators cannot be suspended")
+	}
+
+	if err := uc.User.Suspend(ctx, userID, request.Reason); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.NewNotFound("User not found")
+		}
+		return nil, errors.NewInternalServerError("Failed to suspend user: " + err.Error())
+	}
+
+	recordAudit(ctx, uc.AuditLog, actor, client, entities.AuditActionUserSuspend, entities.AuditTargetUser, userID, map[string]interface{}{
+		"reason":        request.Reason,
+		"email":         user.Email,
+		"was_suspended": user.IsSuspended(),
+	})
+
+	user, err = uc.User.GetByID(ctx, userID)
+	if err != nil || user == nil {
+		return nil, errors.NewInternalServerError("Failed to get suspended user")
+	}
+	response := toAdminUserResponse(user)
+	return &response, nil
+}
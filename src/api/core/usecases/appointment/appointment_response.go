@@ -0,0 +1,53 @@
+package appointment
+
+import (
+	"iycds2025_api/src/api/core/entities"
+	"iycds2025_api/src/api/utils"
+)
+
+// toServiceSummary devuelve una versión básica del servicio para incluir en una cita,
+// sin parsear availability y zones
+func toServiceSummary(service *entities.Service) entities.ServiceResponse {
+	return entities.ServiceResponse{
+		ID:            service.ID,
+		Title:         service.Title,
+		Description:   service.Description,
+		Category:      service.Category,
+		Price:         service.Price,
+		Timezone:      service.Timezone,
+		Status:        service.Status,
+		ImageURL:      service.ImageURL,
+		RatingAverage: service.RatingAverage,
+		ReviewCount:   service.ReviewCount,
+		CreatedAt:     service.CreatedAt,
+		UpdatedAt:     service.UpdatedAt,
+		ProviderID:    service.UserID,
+	}
+}
+
+// toAppointmentResponse convierte una cita a su representación de respuesta, agregando
+// el inicio y el fin absolutos calculados en la zona horaria del servicio
+func toAppointmentResponse(appointment *entities.Appointment, serviceResponse entities.ServiceResponse) *entities.AppointmentResponse {
+	loc := utils.LoadLocation(serviceResponse.Timezone)
+
+	response := &entities.AppointmentResponse{
+		ID:         appointment.ID,
+		Service:    serviceResponse,
+		ClientID:   appointment.ClientID,
+		ProviderID: appointment.ProviderID,
+		Date:       appointment.Date,
+		TimeSlot:   appointment.TimeSlot,
+		Timezone:   loc.String(),
+		Status:     appointment.Status,
+		Notes:      appointment.Notes,
+		CreatedAt:  appointment.CreatedAt,
+		UpdatedAt:  appointment.UpdatedAt,
+	}
+
+	if startsAt, endsAt, err := utils.SlotInstants(appointment.Date, appointment.TimeSlot, loc); err == nil {
+		response.StartsAt = &startsAt
+		response.EndsAt = &endsAt
+	}
+
+	return response
+}
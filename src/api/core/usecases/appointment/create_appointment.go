@@ -7,6 +7,7 @@ import (
 	"iycds2025_api/src/api/core/entities"
 	"iycds2025_api/src/api/core/errors"
 	"iycds2025_api/src/api/core/interfaces"
+	"iycds2025_api/src/api/services/mail"
 	"iycds2025_api/src/api/utils"
 )
 
@@ -15,22 +16,34 @@ type CreateAppointment interface {
 }
 
 type CreateAppointmentImpl struct {
-	Service     interfaces.Service
-	Appointment interfaces.Appointment
+	Service          interfaces.Service
+	Appointment      interfaces.Appointment
+	ServiceException interfaces.ServiceException
+	User             interfaces.User
+	EmailService     mail.EmailService
+	FrontendURL      string
 }
 
 func (uc *CreateAppointmentImpl) Execute(ctx context.Context, appointmentReq *entities.AppointmentCreate, clientID int64) (*entities.AppointmentResponse, error) {
+	// Solo las cuentas con el email verificado pueden reservar
+	client, err := uc.User.GetByID(ctx, clientID)
+	if err != nil {
+		return nil, errors.NewInternalServerError("Failed to get user: " + err.Error())
+	}
+	if client == nil {
+		return nil, errors.NewNotFound("User not found")
+	}
+	if !client.IsEmailVerified() {
+		return nil, errors.NewForbidden("Email address is not verified")
+	}
+
 	// Validar formato de fecha y hora
 	if !utils.ValidateDateFormat(appointmentReq.Date) {
 		return nil, errors.NewBadRequest("Invalid date format. Use YYYY-MM-DD")
 	}
 
-	if !utils.IsDateInFuture(appointmentReq.Date) {
-		return nil, errors.NewBadRequest("Cannot create appointments for past dates")
-	}
-
 	// Validar formato de time slot (debe ser HH:MM-HH:MM)
-	if !uc.validateTimeSlotFormat(appointmentReq.TimeSlot) {
+	if !validateTimeSlotFormat(appointmentReq.TimeSlot) {
 		return nil, errors.NewBadRequest("Invalid time slot format. Use HH:MM-HH:MM")
 	}
 
@@ -46,109 +59,38 @@ func (uc *CreateAppointmentImpl) Execute(ctx context.Context, appointmentReq *en
 		return nil, errors.NewBadRequest("Service is not active")
 	}
 
+	// Verificar que la fecha no sea pasada en la zona horaria del servicio
+	loc := utils.LoadLocation(service.Timezone)
+	if !utils.IsDateInFuture(appointmentReq.Date, loc) {
+		return nil, errors.NewBadRequest("Cannot create appointments for past dates")
+	}
+
 	// Verificar que el cliente no sea el mismo que el proveedor
 	if service.UserID == clientID {
 		return nil, errors.NewBadRequest("Cannot create appointment for your own service")
 	}
 
-	// Verificar que el horario esté dentro de la disponibilidad del servicio
-	dayOfWeek, err := utils.GetDayOfWeek(appointmentReq.Date)
-	if err != nil {
-		return nil, errors.NewBadRequest("Invalid date: " + err.Error())
-	}
-
-	dayAvailability, err := utils.GetDayAvailabilityFromJSON(service.Availability, dayOfWeek)
-	if err != nil {
-		return nil, errors.NewInternalServerError("Failed to parse service availability: " + err.Error())
-	}
-
-	if !dayAvailability.Available {
-		return nil, errors.NewBadRequest("Service is not available on " + dayOfWeek + "s")
-	}
-
-	// Verificar que el time slot está dentro del rango de disponibilidad
-	if !uc.isTimeSlotInRange(appointmentReq.TimeSlot, dayAvailability.Start, dayAvailability.End) {
-		return nil, errors.NewBadRequest("Time slot is outside service availability hours")
+	// Verificar que el horario es uno de los turnos del servicio para esa fecha y que no comenzó
+	if err := validateBookableSlot(ctx, uc.ServiceException, service, appointmentReq.Date, appointmentReq.TimeSlot); err != nil {
+		return nil, err
 	}
 
 	// Crear la cita
 	appointment, err := uc.Appointment.Create(ctx, appointmentReq, clientID)
 	if err != nil {
+		if err == errors.ErrSlotTaken {
+			return nil, errors.NewConflict("Time slot is already occupied")
+		}
 		if err == sql.ErrNoRows {
-			return nil, errors.NewBadRequest("Time slot is already occupied or service not found")
+			return nil, errors.NewNotFound("Service not found")
 		}
 		return nil, errors.NewInternalServerError("Failed to create appointment: " + err.Error())
 	}
 
-	// Convertir a response con información del servicio
-	return uc.toAppointmentResponse(appointment, service)
-}
-
-func (uc *CreateAppointmentImpl) validateTimeSlotFormat(timeSlot string) bool {
-	// Formato debe ser HH:MM-HH:MM
-	if len(timeSlot) != 11 || timeSlot[5] != '-' {
-		return false
-	}
-
-	startTime := timeSlot[:5]
-	endTime := timeSlot[6:]
-
-	return utils.ValidateTimeFormat(startTime) && utils.ValidateTimeFormat(endTime)
-}
+	// Avisar al proveedor de la nueva reserva
+	notifier := &appointmentNotifier{users: uc.User, emailService: uc.EmailService, frontendURL: uc.FrontendURL}
+	notifier.notifyNewAppointment(ctx, appointment, service)
 
-func (uc *CreateAppointmentImpl) isTimeSlotInRange(timeSlot, start, end string) bool {
-	// Generar todos los slots válidos para el rango
-	validSlots, err := utils.GenerateTimeSlots(start, end)
-	if err != nil {
-		return false
-	}
-
-	// Verificar si el slot solicitado está en la lista de válidos
-	for _, validSlot := range validSlots {
-		if validSlot == timeSlot {
-			return true
-		}
-	}
-
-	return false
-}
-
-func (uc *CreateAppointmentImpl) toAppointmentResponse(appointment *entities.Appointment, service *entities.Service) (*entities.AppointmentResponse, error) {
-	// Convertir servicio a response (reutilizar lógica existente)
-	serviceResponse, err := uc.convertServiceToResponse(service)
-	if err != nil {
-		return nil, err
-	}
-
-	return &entities.AppointmentResponse{
-		ID:         appointment.ID,
-		Service:    *serviceResponse,
-		ClientID:   appointment.ClientID,
-		ProviderID: appointment.ProviderID,
-		Date:       appointment.Date,
-		TimeSlot:   appointment.TimeSlot,
-		Status:     appointment.Status,
-		Notes:      appointment.Notes,
-		CreatedAt:  appointment.CreatedAt,
-		UpdatedAt:  appointment.UpdatedAt,
-	}, nil
-}
-
-func (uc *CreateAppointmentImpl) convertServiceToResponse(service *entities.Service) (*entities.ServiceResponse, error) {
-	// Esta función duplica la lógica de los otros use cases de servicio
-	// En un refactor futuro, se podría extraer a un utility común
-	
-	// Por simplicidad, returnamos una versión básica sin parsear availability y zones
-	return &entities.ServiceResponse{
-		ID:          service.ID,
-		Title:       service.Title,
-		Description: service.Description,
-		Category:    service.Category,
-		Price:       service.Price,
-		Status:      service.Status,
-		ImageURL:    service.ImageURL,
-		CreatedAt:   service.CreatedAt,
-		UpdatedAt:   service.UpdatedAt,
-		// availability y zones se pueden parsear aquí si es necesario
-	}, nil
+	// Convertir a response con información del servicio
+	return toAppointmentResponse(appointment, toServiceSummary(service)), nil
 }
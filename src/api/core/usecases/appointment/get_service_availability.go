@@ -2,6 +2,7 @@ package appointment
 
 import (
 	"context"
+	"time"
 
 	"iycds2025_api/src/api/core/entities"
 	"iycds2025_api/src/api/core/errors"
@@ -14,8 +15,9 @@ type GetServiceAvailability interface {
 }
 
 type GetServiceAvailabilityImpl struct {
-	Service     interfaces.Service
-	Appointment interfaces.Appointment
+	Service          interfaces.Service
+	Appointment      interfaces.Appointment
+	ServiceException interfaces.ServiceException
 }
 
 func (uc *GetServiceAvailabilityImpl) Execute(ctx context.Context, serviceID int64, date string) (*entities.AvailabilityResponse, error) {
@@ -24,11 +26,6 @@ func (uc *GetServiceAvailabilityImpl) Execute(ctx context.Context, serviceID int
 		return nil, errors.NewBadRequest("Invalid date format. Use YYYY-MM-DD")
 	}
 
-	// Verificar que la fecha no sea pasada
-	if !utils.IsDateInFuture(date) {
-		return nil, errors.NewBadRequest("Cannot check availability for past dates")
-	}
-
 	// Obtener el servicio
 	service, err := uc.Service.GetByID(ctx, serviceID)
 	if err != nil {
@@ -41,57 +38,76 @@ func (uc *GetServiceAvailabilityImpl) Execute(ctx context.Context, serviceID int
 		return nil, errors.NewBadRequest("Service is not active")
 	}
 
+	// Verificar que la fecha no sea pasada en la zona horaria del servicio
+	loc := utils.LoadLocation(service.Timezone)
+	if !utils.IsDateInFuture(date, loc) {
+		return nil, errors.NewBadRequest("Cannot check availability for past dates")
+	}
+
 	// Obtener el día de la semana
 	dayOfWeek, err := utils.GetDayOfWeek(date)
 	if err != nil {
 		return nil, errors.NewBadRequest("Invalid date: " + err.Error())
 	}
 
-	// Obtener la disponibilidad del día desde el JSON del servicio
-	dayAvailability, err := utils.GetDayAvailabilityFromJSON(service.Availability, dayOfWeek)
+	// Obtener la disponibilidad semanal del servicio
+	availability, err := utils.ParseAvailability(service.Availability)
 	if err != nil {
 		return nil, errors.NewInternalServerError("Failed to parse service availability: " + err.Error())
 	}
 
-	if !dayAvailability.Available {
-		// El servicio no está disponible este día de la semana
+	// Obtener las excepciones de agenda que cubren la fecha
+	exceptions, err := uc.ServiceException.GetByServiceIDAndDateRange(ctx, serviceID, date, date)
+	if err != nil {
+		return nil, errors.NewInternalServerError("Failed to get service exceptions: " + err.Error())
+	}
+
+	// Generar todos los slots posibles para la fecha
+	allSlots, err := utils.GenerateDateSlots(availability, exceptions, date)
+	if err != nil {
+		return nil, errors.NewBadRequest("Invalid time range in service availability: " + err.Error())
+	}
+
+	if len(allSlots) == 0 {
+		// El servicio no atiende en esta fecha
 		return &entities.AvailabilityResponse{
 			Date:      date,
 			DayOfWeek: dayOfWeek,
+			Timezone:  loc.String(),
 			TimeSlots: []entities.TimeSlot{},
 		}, nil
 	}
 
-	// Generar todos los slots posibles para el día
-	allSlots, err := utils.GenerateTimeSlots(dayAvailability.Start, dayAvailability.End)
-	if err != nil {
-		return nil, errors.NewBadRequest("Invalid time range in service availability: " + err.Error())
-	}
-
 	// Obtener citas ocupadas para esta fecha y servicio
 	occupiedAppointments, err := uc.Appointment.GetByServiceIDAndDate(ctx, serviceID, date)
 	if err != nil {
 		return nil, errors.NewInternalServerError("Failed to get occupied slots: " + err.Error())
 	}
 
-	// Crear mapa de slots ocupados para búsqueda rápida
-	occupiedSlots := make(map[string]bool)
+	occupiedSlots := make([]string, 0, len(occupiedAppointments))
 	for _, appointment := range occupiedAppointments {
-		occupiedSlots[appointment.TimeSlot] = true
+		occupiedSlots = append(occupiedSlots, appointment.TimeSlot)
 	}
 
-	// Construir respuesta con disponibilidad de cada slot
+	// Construir respuesta con disponibilidad de cada slot; los que ya comenzaron no se pueden reservar
 	timeSlots := make([]entities.TimeSlot, len(allSlots))
 	for i, slot := range allSlots {
+		startsAt, endsAt, err := utils.SlotInstants(date, slot, loc)
+		if err != nil {
+			return nil, errors.NewInternalServerError("Invalid time slot: " + err.Error())
+		}
 		timeSlots[i] = entities.TimeSlot{
 			Time:      slot,
-			Available: !occupiedSlots[slot],
+			StartsAt:  startsAt,
+			EndsAt:    endsAt,
+			Available: !utils.IsSlotOccupied(slot, occupiedSlots) && startsAt.After(time.Now()),
 		}
 	}
 
 	return &entities.AvailabilityResponse{
 		Date:      date,
 		DayOfWeek: dayOfWeek,
+		Timezone:  loc.String(),
 		TimeSlots: timeSlots,
 	}, nil
 }
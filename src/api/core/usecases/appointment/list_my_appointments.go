@@ -29,52 +29,18 @@ func (uc *ListMyAppointmentsImpl) Execute(ctx context.Context, clientID int64) (
 	for _, appointment := range appointments {
 		// Obtener información del servicio
 		service, err := uc.Service.GetByID(ctx, appointment.ServiceID)
-		if err != nil {
+		if err != nil || service == nil {
 			// Si no se puede obtener el servicio, continuar con información básica
-			serviceResponse := &entities.ServiceResponse{
-				ID:    appointment.ServiceID,
-				Title: "Service not available",
-			}
-			responses = append(responses, &entities.AppointmentResponse{
-				ID:         appointment.ID,
-				Service:    *serviceResponse,
-				ClientID:   appointment.ClientID,
+			serviceResponse := entities.ServiceResponse{
+				ID:         appointment.ServiceID,
+				Title:      "Service not available",
 				ProviderID: appointment.ProviderID,
-				Date:       appointment.Date,
-				TimeSlot:   appointment.TimeSlot,
-				Status:     appointment.Status,
-				Notes:      appointment.Notes,
-				CreatedAt:  appointment.CreatedAt,
-				UpdatedAt:  appointment.UpdatedAt,
-			})
+			}
+			responses = append(responses, toAppointmentResponse(appointment, serviceResponse))
 			continue
 		}
 
-		// Convertir servicio a response
-		serviceResponse := &entities.ServiceResponse{
-			ID:          service.ID,
-			Title:       service.Title,
-			Description: service.Description,
-			Category:    service.Category,
-			Price:       service.Price,
-			Status:      service.Status,
-			ImageURL:    service.ImageURL,
-			CreatedAt:   service.CreatedAt,
-			UpdatedAt:   service.UpdatedAt,
-		}
-
-		responses = append(responses, &entities.AppointmentResponse{
-			ID:         appointment.ID,
-			Service:    *serviceResponse,
-			ClientID:   appointment.ClientID,
-			ProviderID: appointment.ProviderID,
-			Date:       appointment.Date,
-			TimeSlot:   appointment.TimeSlot,
-			Status:     appointment.Status,
-			Notes:      appointment.Notes,
-			CreatedAt:  appointment.CreatedAt,
-			UpdatedAt:  appointment.UpdatedAt,
-		})
+		responses = append(responses, toAppointmentResponse(appointment, toServiceSummary(service)))
 	}
 
 	return responses, nil
@@ -6,10 +6,11 @@ import (
 	"iycds2025_api/src/api/core/entities"
 	"iycds2025_api/src/api/core/errors"
 	"iycds2025_api/src/api/core/interfaces"
+	"iycds2025_api/src/api/core/policy"
 )
 
 type ListServiceAppointments interface {
-	Execute(ctx context.Context, serviceID int64, providerID int64) ([]*entities.AppointmentResponse, error)
+	Execute(ctx context.Context, serviceID int64, actor policy.Actor) ([]*entities.AppointmentResponse, error)
 }
 
 type ListServiceAppointmentsImpl struct {
@@ -17,8 +18,8 @@ type ListServiceAppointmentsImpl struct {
 	Appointment interfaces.Appointment
 }
 
-func (uc *ListServiceAppointmentsImpl) Execute(ctx context.Context, serviceID int64, providerID int64) ([]*entities.AppointmentResponse, error) {
-	// Verificar que el servicio existe y pertenece al proveedor
+func (uc *ListServiceAppointmentsImpl) Execute(ctx context.Context, serviceID int64, actor policy.Actor) ([]*entities.AppointmentResponse, error) {
+	// Verificar que el servicio existe y que el usuario puede ver sus citas (proveedor o moderador)
 	service, err := uc.Service.GetByID(ctx, serviceID)
 	if err != nil {
 		return nil, errors.NewInternalServerError("Failed to get service: " + err.Error())
@@ -26,8 +27,8 @@ func (uc *ListServiceAppointmentsImpl) Execute(ctx context.Context, serviceID in
 	if service == nil {
 		return nil, errors.NewNotFound("Service not found")
 	}
-	if service.UserID != providerID {
-		return nil, errors.NewUnauthorized("You don't have permission to view appointments for this service")
+	if !policy.CanManageService(actor, service) {
+		return nil, errors.NewForbidden("You don't have permission to view appointments for this service")
 	}
 
 	// Obtener appointments del servicio
@@ -39,30 +40,7 @@ func (uc *ListServiceAppointmentsImpl) Execute(ctx context.Context, serviceID in
 	// Convertir a response
 	var responses []*entities.AppointmentResponse
 	for _, appointment := range appointments {
-		serviceResponse := &entities.ServiceResponse{
-			ID:          service.ID,
-			Title:       service.Title,
-			Description: service.Description,
-			Category:    service.Category,
-			Price:       service.Price,
-			Status:      service.Status,
-			ImageURL:    service.ImageURL,
-			CreatedAt:   service.CreatedAt,
-			UpdatedAt:   service.UpdatedAt,
-		}
-
-		responses = append(responses, &entities.AppointmentResponse{
-			ID:         appointment.ID,
-			Service:    *serviceResponse,
-			ClientID:   appointment.ClientID,
-			ProviderID: appointment.ProviderID,
-			Date:       appointment.Date,
-			TimeSlot:   appointment.TimeSlot,
-			Status:     appointment.Status,
-			Notes:      appointment.Notes,
-			CreatedAt:  appointment.CreatedAt,
-			UpdatedAt:  appointment.UpdatedAt,
-		})
+		responses = append(responses, toAppointmentResponse(appointment, toServiceSummary(service)))
 	}
 
 	return responses, nil
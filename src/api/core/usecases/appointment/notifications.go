@@ -0,0 +1,77 @@
+package appointment
+
+import (
+	"context"
+	"fmt"
+
+	"iycds2025_api/src/api/core/entities"
+	"iycds2025_api/src/api/core/interfaces"
+	"iycds2025_api/src/api/services/mail"
+	"iycds2025_api/src/api/utils"
+)
+
+// appointmentNotifier envía las notificaciones por correo del ciclo de vida de una cita.
+// Los errores de envío solo se registran: la operación sobre la cita ya se guardó.
+type appointmentNotifier struct {
+	users        interfaces.User
+	emailService mail.EmailService
+	frontendURL  string
+}
+
+// notifyNewAppointment avisa al proveedor que recibió una reserva
+func (n *appointmentNotifier) notifyNewAppointment(ctx context.Context, appointment *entities.Appointment, service *entities.Service) {
+	n.notify(ctx, appointment, service, appointment.ClientID, appointment.ProviderID, func(to mail.Recipient, data mail.AppointmentEmailData) error {
+		return n.emailService.SendNewAppointmentEmail(to, data)
+	})
+}
+
+// notifyStatusChange avisa a la otra parte que quien actuó cambió el estado de la cita.
+// Si actuó un moderador se avisa a las dos partes.
+func (n *appointmentNotifier) notifyStatusChange(ctx context.Context, appointment *entities.Appointment, service *entities.Service, actorID int64) {
+	var recipientIDs []int64
+	switch actorID {
+	case appointment.ClientID:
+		recipientIDs = []int64{appointment.ProviderID}
+	case appointment.ProviderID:
+		recipientIDs = []int64{appointment.ClientID}
+	default:
+		recipientIDs = []int64{appointment.ClientID, appointment.ProviderID}
+	}
+
+	for _, recipientID := range recipientIDs {
+		n.notify(ctx, appointment, service, actorID, recipientID, func(to mail.Recipient, data mail.AppointmentEmailData) error {
+			return n.emailService.SendAppointmentStatusEmail(to, data)
+		})
+	}
+}
+
+func (n *appointmentNotifier) notify(ctx context.Context, appointment *entities.Appointment, service *entities.Service, actorID, recipientID int64, send func(mail.Recipient, mail.AppointmentEmailData) error) {
+	recipient, err := n.users.GetByID(ctx, recipientID)
+	if err != nil || recipient == nil {
+		fmt.Printf("Error getting recipient %d for appointment %d notification: %v\n", recipientID, appointment.ID, err)
+		return
+	}
+
+	// Sin nombre, la plantilla usa "Un usuario" en el idioma del destinatario
+	counterpartName := ""
+	if actor, err := n.users.GetByID(ctx, actorID); err == nil && actor != nil {
+		counterpartName = actor.Name
+	}
+
+	data := mail.AppointmentEmailData{
+		AppointmentID:   appointment.ID,
+		ServiceTitle:    service.Title,
+		Date:            appointment.Date,
+		TimeSlot:        appointment.TimeSlot,
+		Timezone:        utils.LoadLocation(service.Timezone).String(),
+		Status:          appointment.Status,
+		CounterpartName: counterpartName,
+		Notes:           appointment.Notes,
+		AppointmentURL:  fmt.Sprintf("%s/appointments/%d", n.frontendURL, appointment.ID),
+	}
+
+	to := mail.Recipient{Email: recipient.Email, Name: recipient.Name, Locale: recipient.Locale}
+	if err := send(to, data); err != nil {
+		fmt.Printf("Error sending appointment %d notification to %s: %v\n", appointment.ID, recipient.Email, err)
+	}
+}
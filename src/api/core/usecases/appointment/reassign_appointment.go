@@ -0,0 +1,107 @@
+package appointment
+
+import (
+	"context"
+	"database/sql"
+
+	"iycds2025_api/src/api/core/entities"
+	"iycds2025_api/src/api/core/errors"
+	"iycds2025_api/src/api/core/interfaces"
+	"iycds2025_api/src/api/core/policy"
+	"iycds2025_api/src/api/services/mail"
+	"iycds2025_api/src/api/utils"
+)
+
+type ReassignAppointment interface {
+	Execute(ctx context.Context, appointmentID int64, reassignReq *entities.AppointmentReassign, actor policy.Actor) (*entities.AppointmentResponse, error)
+}
+
+type ReassignAppointmentImpl struct {
+	Appointment      interfaces.Appointment
+	Service          interfaces.Service
+	ServiceException interfaces.ServiceException
+	User             interfaces.User
+	EmailService     mail.EmailService
+	FrontendURL      string
+}
+
+func (uc *ReassignAppointmentImpl) Execute(ctx context.Context, appointmentID int64, reassignReq *entities.AppointmentReassign, actor policy.Actor) (*entities.AppointmentResponse, error) {
+	if !policy.CanReassignAppointment(actor) {
+		return nil, errors.NewForbidden("You don't have permission to reassign appointments")
+	}
+
+	appointment, err := uc.Appointment.GetByID(ctx, appointmentID)
+	if err != nil {
+		return nil, errors.NewInternalServerError("Failed to get appointment: " + err.Error())
+	}
+	if appointment == nil {
+		return nil, errors.NewNotFound("Appointment not found")
+	}
+	if appointment.Status != "pending" && appointment.Status != "accepted" {
+		return nil, errors.NewBadRequest("Can only reassign pending or accepted appointments")
+	}
+
+	// Los campos que no se indican conservan el valor actual
+	serviceID, date, timeSlot := appointment.ServiceID, appointment.Date, appointment.TimeSlot
+	if reassignReq.ServiceID != 0 {
+		serviceID = reassignReq.ServiceID
+	}
+	if reassignReq.Date != "" {
+		date = reassignReq.Date
+	}
+	if reassignReq.TimeSlot != "" {
+		timeSlot = reassignReq.TimeSlot
+	}
+	if serviceID == appointment.ServiceID && date == appointment.Date && timeSlot == appointment.TimeSlot {
+		return nil, errors.NewBadRequest("The appointment already has this service, date and time slot")
+	}
+
+	if !utils.ValidateDateFormat(date) {
+		return nil, errors.NewBadRequest("Invalid date format. Use YYYY-MM-DD")
+	}
+	if !validateTimeSlotFormat(timeSlot) {
+		return nil, errors.NewBadRequest("Invalid time slot format. Use HH:MM-HH:MM")
+	}
+
+	// El servicio de destino debe existir, estar activo y no ser del propio cliente
+	service, err := uc.Service.GetByID(ctx, serviceID)
+	if err != nil {
+		return nil, errors.NewInternalServerError("Failed to get service: " + err.Error())
+	}
+	if service == nil {
+		return nil, errors.NewNotFound("Service not found")
+	}
+	if service.Status != "active" {
+		return nil, errors.NewBadRequest("Service is not active")
+	}
+	if service.UserID == appointment.ClientID {
+		return nil, errors.NewBadRequest("Cannot reassign an appointment to a service of its own client")
+	}
+
+	if !utils.IsDateInFuture(date, utils.LoadLocation(service.Timezone)) {
+		return nil, errors.NewBadRequest("Cannot reassign appointments to past dates")
+	}
+	if err := validateBookableSlot(ctx, uc.ServiceException, service, date, timeSlot); err != nil {
+		return nil, err
+	}
+
+	updated, err := uc.Appointment.Reassign(ctx, appointmentID, serviceID, date, timeSlot)
+	if err != nil {
+		if err == errors.ErrSlotTaken {
+			return nil, errors.NewConflict("Time slot is already occupied")
+		}
+		if err == sql.ErrNoRows {
+			// La cita dejó de estar activa o el servicio se desactivó mientras tanto
+			return nil, errors.NewConflict("The appointment or the service changed, please retry")
+		}
+		return nil, errors.NewInternalServerError("Failed to reassign appointment: " + err.Error())
+	}
+
+	// Si cambió el proveedor, para él es una reserva nueva que tiene que aceptar
+	if updated.ProviderID != appointment.ProviderID {
+		notifier := &appointmentNotifier{users: uc.User, emailService: uc.EmailService, frontendURL: uc.FrontendURL}
+		notifier.notifyNewAppointment(ctx, updated, service)
+	}
+
+	return toAppointmentResponse(updated, toServiceSummary(service)), nil
+}
@@ -0,0 +1,69 @@
+package appointment
+
+import (
+	"context"
+
+	"iycds2025_api/src/api/core/entities"
+	"iycds2025_api/src/api/core/errors"
+	"iycds2025_api/src/api/core/interfaces"
+	"iycds2025_api/src/api/utils"
+)
+
+// validateBookableSlot verifica que el horario esté dentro de la disponibilidad del servicio para
+// la fecha, teniendo en cuenta sus excepciones de agenda, y que todavía no haya comenzado
+func validateBookableSlot(ctx context.Context, serviceExceptions interfaces.ServiceException, service *entities.Service, date string, timeSlot string) error {
+	availability, err := utils.ParseAvailability(service.Availability)
+	if err != nil {
+		return errors.NewInternalServerError("Failed to parse service availability: " + err.Error())
+	}
+
+	// Las excepciones de agenda (días cerrados u horarios especiales) tienen prioridad
+	exceptions, err := serviceExceptions.GetByServiceIDAndDateRange(ctx, service.ID, date, date)
+	if err != nil {
+		return errors.NewInternalServerError("Failed to get service exceptions: " + err.Error())
+	}
+
+	validSlots, err := utils.GenerateDateSlots(availability, exceptions, date)
+	if err != nil {
+		return errors.NewInternalServerError("Invalid time range in service availability: " + err.Error())
+	}
+
+	if len(validSlots) == 0 {
+		return errors.NewBadRequest("Service is not available on " + date)
+	}
+
+	// Verificar que el time slot coincide con uno de los turnos del día
+	if !isValidTimeSlot(timeSlot, validSlots) {
+		return errors.NewBadRequest("Time slot is outside service availability hours")
+	}
+
+	// Un turno de hoy que ya comenzó no se puede reservar
+	if utils.HasSlotStarted(date, timeSlot, utils.LoadLocation(service.Timezone)) {
+		return errors.NewBadRequest("Cannot book a time slot that has already started")
+	}
+
+	return nil
+}
+
+// validateTimeSlotFormat verifica que el time slot tenga el formato HH:MM-HH:MM
+func validateTimeSlotFormat(timeSlot string) bool {
+	if len(timeSlot) != 11 || timeSlot[5] != '-' {
+		return false
+	}
+
+	startTime := timeSlot[:5]
+	endTime := timeSlot[6:]
+
+	return utils.ValidateTimeFormat(startTime) && utils.ValidateTimeFormat(endTime)
+}
+
+// isValidTimeSlot verifica si el slot solicitado está en la lista de turnos válidos
+func isValidTimeSlot(timeSlot string, validSlots []string) bool {
+	for _, validSlot := range validSlots {
+		if validSlot == timeSlot {
+			return true
+		}
+	}
+
+	return false
+}
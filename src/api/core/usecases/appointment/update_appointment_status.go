@@ -3,20 +3,27 @@ package appointment
 import (
 	"context"
 	"database/sql"
+	"fmt"
 
 	"iycds2025_api/src/api/core/errors"
 	"iycds2025_api/src/api/core/interfaces"
+	"iycds2025_api/src/api/core/policy"
+	"iycds2025_api/src/api/services/mail"
 )
 
 type UpdateAppointmentStatus interface {
-	Execute(ctx context.Context, appointmentID int64, status string, userID int64) error
+	Execute(ctx context.Context, appointmentID int64, status string, actor policy.Actor) error
 }
 
 type UpdateAppointmentStatusImpl struct {
-	Appointment interfaces.Appointment
+	Appointment  interfaces.Appointment
+	Service      interfaces.Service
+	User         interfaces.User
+	EmailService mail.EmailService
+	FrontendURL  string
 }
 
-func (uc *UpdateAppointmentStatusImpl) Execute(ctx context.Context, appointmentID int64, status string, userID int64) error {
+func (uc *UpdateAppointmentStatusImpl) Execute(ctx context.Context, appointmentID int64, status string, actor policy.Actor) error {
 	// Obtener la cita para verificar permisos
 	appointment, err := uc.Appointment.GetByID(ctx, appointmentID)
 	if err != nil {
@@ -26,12 +33,12 @@ func (uc *UpdateAppointmentStatusImpl) Execute(ctx context.Context, appointmentI
 		return errors.NewNotFound("Appointment not found")
 	}
 
-	// Verificar permisos según el estado a actualizar
+	// Verificar permisos según el estado a actualizar: el proveedor acepta, rechaza y completa,
+	// cualquiera de las partes cancela y un moderador puede hacer cualquier cambio
 	switch status {
 	case "accepted", "rejected":
-		// Solo el proveedor puede aceptar/rechazar
-		if appointment.ProviderID != userID {
-			return errors.NewUnauthorized("Only the service provider can accept or reject appointments")
+		if !policy.CanUpdateAppointmentStatus(actor, appointment, status) {
+			return errors.NewForbidden("Only the service provider can accept or reject appointments")
 		}
 		// Solo se pueden aceptar/rechazar citas pendientes
 		if appointment.Status != "pending" {
@@ -39,9 +46,8 @@ func (uc *UpdateAppointmentStatusImpl) Execute(ctx context.Context, appointmentI
 		}
 
 	case "cancelled":
-		// Tanto cliente como proveedor pueden cancelar
-		if appointment.ClientID != userID && appointment.ProviderID != userID {
-			return errors.NewUnauthorized("You don't have permission to cancel this appointment")
+		if !policy.CanUpdateAppointmentStatus(actor, appointment, status) {
+			return errors.NewForbidden("You don't have permission to cancel this appointment")
 		}
 		// No se pueden cancelar citas ya completadas o rechazadas
 		if appointment.Status == "completed" || appointment.Status == "rejected" {
@@ -49,9 +55,8 @@ func (uc *UpdateAppointmentStatusImpl) Execute(ctx context.Context, appointmentI
 		}
 
 	case "completed":
-		// Solo el proveedor puede marcar como completada
-		if appointment.ProviderID != userID {
-			return errors.NewUnauthorized("Only the service provider can mark appointments as completed")
+		if !policy.CanUpdateAppointmentStatus(actor, appointment, status) {
+			return errors.NewForbidden("Only the service provider can mark appointments as completed")
 		}
 		// Solo se pueden completar citas aceptadas
 		if appointment.Status != "accepted" {
@@ -62,8 +67,8 @@ func (uc *UpdateAppointmentStatusImpl) Execute(ctx context.Context, appointmentI
 		return errors.NewBadRequest("Invalid status. Valid values: accepted, rejected, cancelled, completed")
 	}
 
-	// Actualizar el estado
-	err = uc.Appointment.UpdateStatus(ctx, appointmentID, status, userID)
+	// Actualizar el estado (a nombre del proveedor, aunque actúe un moderador)
+	err = uc.Appointment.UpdateStatus(ctx, appointmentID, status, appointment.ProviderID)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return errors.NewNotFound("Appointment not found or you don't have permission")
@@ -71,5 +76,15 @@ func (uc *UpdateAppointmentStatusImpl) Execute(ctx context.Context, appointmentI
 		return errors.NewInternalServerError("Failed to update appointment status: " + err.Error())
 	}
 
+	// Avisar a la otra parte del cambio de estado
+	service, err := uc.Service.GetByID(ctx, appointment.ServiceID)
+	if err != nil || service == nil {
+		fmt.Printf("Error getting service %d for appointment %d notification: %v\n", appointment.ServiceID, appointmentID, err)
+		return nil
+	}
+	appointment.Status = status
+	notifier := &appointmentNotifier{users: uc.User, emailService: uc.EmailService, frontendURL: uc.FrontendURL}
+	notifier.notifyStatusChange(ctx, appointment, service, actor.UserID)
+
 	return nil
 }
@@ -0,0 +1,40 @@
+package exception
+
+import (
+	"context"
+
+	"iycds2025_api/src/api/core/entities"
+	"iycds2025_api/src/api/core/errors"
+	"iycds2025_api/src/api/core/interfaces"
+	"iycds2025_api/src/api/core/policy"
+)
+
+type CreateServiceException interface {
+	Execute(ctx context.Context, serviceID int64, exceptionReq *entities.ServiceExceptionRequest, actor policy.Actor) (*entities.ServiceException, error)
+}
+
+type CreateServiceExceptionImpl struct {
+	Service          interfaces.Service
+	ServiceException interfaces.ServiceException
+}
+
+func (uc *CreateServiceExceptionImpl) Execute(ctx context.Context, serviceID int64, exceptionReq *entities.ServiceExceptionRequest, actor policy.Actor) (*entities.ServiceException, error) {
+	// Verificar que el servicio existe y pertenece al usuario
+	service, err := getOwnedService(ctx, uc.Service, serviceID, actor)
+	if err != nil {
+		return nil, err
+	}
+
+	// Validar fechas y horarios
+	if err := validateExceptionRequest(service, exceptionReq); err != nil {
+		return nil, err
+	}
+
+	// Crear la excepción
+	exception, err := uc.ServiceException.Create(ctx, serviceID, exceptionReq)
+	if err != nil {
+		return nil, errors.NewInternalServerError("Failed to create service exception: " + err.Error())
+	}
+
+	return exception, nil
+}
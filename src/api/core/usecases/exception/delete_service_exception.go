@@ -0,0 +1,46 @@
+package exception
+
+import (
+	"context"
+	"database/sql"
+
+	"iycds2025_api/src/api/core/errors"
+	"iycds2025_api/src/api/core/interfaces"
+	"iycds2025_api/src/api/core/policy"
+)
+
+type DeleteServiceException interface {
+	Execute(ctx context.Context, serviceID int64, exceptionID int64, actor policy.Actor) error
+}
+
+type DeleteServiceExceptionImpl struct {
+	Service          interfaces.Service
+	ServiceException interfaces.ServiceException
+}
+
+func (uc *DeleteServiceExceptionImpl) Execute(ctx context.Context, serviceID int64, exceptionID int64, actor policy.Actor) error {
+	// Verificar que el servicio existe y pertenece al usuario
+	if _, err := getOwnedService(ctx, uc.Service, serviceID, actor); err != nil {
+		return err
+	}
+
+	// Verificar que la excepción pertenece al servicio
+	existing, err := uc.ServiceException.GetByID(ctx, exceptionID)
+	if err != nil {
+		return errors.NewInternalServerError("Failed to get service exception: " + err.Error())
+	}
+	if existing == nil || existing.ServiceID != serviceID {
+		return errors.NewNotFound("Service exception not found")
+	}
+
+	// Eliminar la excepción
+	err = uc.ServiceException.Delete(ctx, exceptionID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return errors.NewNotFound("Service exception not found")
+		}
+		return errors.NewInternalServerError("Failed to delete service exception: " + err.Error())
+	}
+
+	return nil
+}
@@ -0,0 +1,49 @@
+package exception
+
+import (
+	"context"
+	"time"
+
+	"iycds2025_api/src/api/core/entities"
+	"iycds2025_api/src/api/core/errors"
+	"iycds2025_api/src/api/core/interfaces"
+	"iycds2025_api/src/api/utils"
+)
+
+// listHorizonYears es la cantidad de años hacia adelante que se listan
+const listHorizonYears = 2
+
+type ListServiceExceptions interface {
+	Execute(ctx context.Context, serviceID int64) ([]*entities.ServiceException, error)
+}
+
+type ListServiceExceptionsImpl struct {
+	Service          interfaces.Service
+	ServiceException interfaces.ServiceException
+}
+
+func (uc *ListServiceExceptionsImpl) Execute(ctx context.Context, serviceID int64) ([]*entities.ServiceException, error) {
+	// Verificar que el servicio existe
+	service, err := uc.Service.GetByID(ctx, serviceID)
+	if err != nil {
+		return nil, errors.NewInternalServerError("Failed to get service: " + err.Error())
+	}
+	if service == nil {
+		return nil, errors.NewNotFound("Service not found")
+	}
+
+	// Solo interesan las excepciones vigentes o futuras en la zona horaria del servicio
+	now := time.Now().In(utils.LoadLocation(service.Timezone))
+	today := now.Format("2006-01-02")
+	until := now.AddDate(listHorizonYears, 0, 0).Format("2006-01-02")
+
+	exceptions, err := uc.ServiceException.GetByServiceIDAndDateRange(ctx, serviceID, today, until)
+	if err != nil {
+		return nil, errors.NewInternalServerError("Failed to get service exceptions: " + err.Error())
+	}
+	if exceptions == nil {
+		exceptions = []*entities.ServiceException{}
+	}
+
+	return exceptions, nil
+}
@@ -0,0 +1,53 @@
+package exception
+
+import (
+	"context"
+	"database/sql"
+
+	"iycds2025_api/src/api/core/entities"
+	"iycds2025_api/src/api/core/errors"
+	"iycds2025_api/src/api/core/interfaces"
+	"iycds2025_api/src/api/core/policy"
+)
+
+type UpdateServiceException interface {
+	Execute(ctx context.Context, serviceID int64, exceptionID int64, exceptionReq *entities.ServiceExceptionRequest, actor policy.Actor) (*entities.ServiceException, error)
+}
+
+type UpdateServiceExceptionImpl struct {
+	Service          interfaces.Service
+	ServiceException interfaces.ServiceException
+}
+
+func (uc *UpdateServiceExceptionImpl) Execute(ctx context.Context, serviceID int64, exceptionID int64, exceptionReq *entities.ServiceExceptionRequest, actor policy.Actor) (*entities.ServiceException, error) {
+	// Verificar que el servicio existe y pertenece al usuario
+	service, err := getOwnedService(ctx, uc.Service, serviceID, actor)
+	if err != nil {
+		return nil, err
+	}
+
+	// Verificar que la excepción pertenece al servicio
+	existing, err := uc.ServiceException.GetByID(ctx, exceptionID)
+	if err != nil {
+		return nil, errors.NewInternalServerError("Failed to get service exception: " + err.Error())
+	}
+	if existing == nil || existing.ServiceID != serviceID {
+		return nil, errors.NewNotFound("Service exception not found")
+	}
+
+	// Validar fechas y horarios
+	if err := validateExceptionRequest(service, exceptionReq); err != nil {
+		return nil, err
+	}
+
+	// Actualizar la excepción
+	exception, err := uc.ServiceException.Update(ctx, exceptionID, exceptionReq)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.NewNotFound("Service exception not found")
+		}
+		return nil, errors.NewInternalServerError("Failed to update service exception: " + err.Error())
+	}
+
+	return exception, nil
+}
@@ -0,0 +1,74 @@
+package exception
+
+import (
+	"context"
+	"time"
+
+	"iycds2025_api/src/api/core/entities"
+	"iycds2025_api/src/api/core/errors"
+	"iycds2025_api/src/api/core/interfaces"
+	"iycds2025_api/src/api/core/policy"
+	"iycds2025_api/src/api/utils"
+)
+
+// maxExceptionDays limita la duración de una excepción (por ejemplo, unas vacaciones largas)
+const maxExceptionDays = 366
+
+// getOwnedService obtiene el servicio y verifica que el usuario puede administrar su agenda (dueño o moderador)
+func getOwnedService(ctx context.Context, services interfaces.Service, serviceID int64, actor policy.Actor) (*entities.Service, error) {
+	service, err := services.GetByID(ctx, serviceID)
+	if err != nil {
+		return nil, errors.NewInternalServerError("Failed to get service: " + err.Error())
+	}
+	if service == nil {
+		return nil, errors.NewNotFound("Service not found")
+	}
+	if !policy.CanManageService(actor, service) {
+		return nil, errors.NewForbidden("You don't have permission to manage exceptions for this service")
+	}
+
+	return service, nil
+}
+
+// validateExceptionRequest valida las fechas y los horarios de una excepción y normaliza el request
+func validateExceptionRequest(service *entities.Service, exceptionReq *entities.ServiceExceptionRequest) error {
+	if exceptionReq.EndDate == "" {
+		exceptionReq.EndDate = exceptionReq.StartDate
+	}
+
+	start, err := time.Parse("2006-01-02", exceptionReq.StartDate)
+	if err != nil {
+		return errors.NewBadRequest("Invalid start_date format. Use YYYY-MM-DD")
+	}
+	end, err := time.Parse("2006-01-02", exceptionReq.EndDate)
+	if err != nil {
+		return errors.NewBadRequest("Invalid end_date format. Use YYYY-MM-DD")
+	}
+	if end.Before(start) {
+		return errors.NewBadRequest("end_date cannot be before start_date")
+	}
+	if end.Sub(start) > maxExceptionDays*24*time.Hour {
+		return errors.NewBadRequest("An exception cannot span more than one year")
+	}
+	if !utils.IsDateInFuture(exceptionReq.EndDate, utils.LoadLocation(service.Timezone)) {
+		return errors.NewBadRequest("Cannot create exceptions for past dates")
+	}
+
+	switch exceptionReq.Type {
+	case utils.ExceptionClosed:
+		// Un día cerrado no tiene horarios
+		exceptionReq.Ranges = nil
+	case utils.ExceptionCustomHours:
+		availability, err := utils.ParseAvailability(service.Availability)
+		if err != nil {
+			return errors.NewInternalServerError("Failed to parse service availability: " + err.Error())
+		}
+		if err := utils.ValidateTimeRanges(availability, exceptionReq.Ranges); err != nil {
+			return errors.NewBadRequest("Invalid ranges: " + err.Error())
+		}
+	default:
+		return errors.NewBadRequest("Invalid type. Allowed values: closed, custom_hours")
+	}
+
+	return nil
+}
@@ -0,0 +1,220 @@
+package login
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"iycds2025_api/src/api/core/entities"
+	"iycds2025_api/src/api/core/errors"
+	"iycds2025_api/src/api/core/interfaces"
+	"iycds2025_api/src/api/services/identity"
+	"iycds2025_api/src/api/utils"
+)
+
+// externalLoginTTL es el tiempo que tiene el usuario para autenticarse en el proveedor y volver
+const externalLoginTTL = 10 * time.Minute
+
+type StartExternalLogin interface {
+	Execute(ctx context.Context, providerName string) (*entities.ExternalLoginStartResponse, error)
+}
+
+type StartExternalLoginImpl struct {
+	Identity  interfaces.Identity
+	Providers identity.Providers
+}
+
+// Execute inicia el login con un proveedor externo: guarda el state, el nonce y el verificador PKCE
+// y devuelve la URL del proveedor a la que el frontend redirige al usuario
+func (uc *StartExternalLoginImpl) Execute(ctx context.Context, providerName string) (*entities.ExternalLoginStartResponse, error) {
+	provider, ok := uc.Providers[providerName]
+	if !ok {
+		return nil, errors.NewNotFound("Identity provider not found")
+	}
+
+	state, stateHash, err := utils.GenerateRefreshToken()
+	if err != nil {
+		return nil, errors.NewInternalServerError("Failed to generate login state")
+	}
+	nonce, _, err := utils.GenerateRefreshToken()
+	if err != nil {
+		return nil, errors.NewInternalServerError("Failed to generate login nonce")
+	}
+	codeVerifier, codeChallenge, err := identity.GeneratePKCE()
+	if err != nil {
+		return nil, errors.NewInternalServerError("Failed to generate PKCE verifier")
+	}
+
+	authorizationURL, err := provider.AuthCodeURL(ctx, state, nonce, codeChallenge)
+	if err != nil {
+		fmt.Printf("Error starting %s login: %v\n", providerName, err)
+		return nil, errors.NewBadGateway("Identity provider is not available")
+	}
+
+	loginState := &entities.ExternalLoginState{
+		Provider:     providerName,
+		StateHash:    stateHash,
+		CodeVerifier: codeVerifier,
+		Nonce:        nonce,
+		ExpiresAt:    time.Now().Add(externalLoginTTL),
+	}
+	if err := uc.Identity.CreateLoginState(ctx, loginState); err != nil {
+		return nil, errors.NewInternalServerError("Failed to save login state")
+	}
+
+	return &entities.ExternalLoginStartResponse{
+		AuthorizationURL: authorizationURL,
+		ExpiresIn:        int(externalLoginTTL.Seconds()),
+	}, nil
+}
+
+type ExternalLogin interface {
+	Execute(ctx context.Context, providerName string, request *entities.ExternalLoginCallback, client entities.ClientInfo) (*entities.LoginResult, error)
+}
+
+type ExternalLoginImpl struct {
+	User         interfaces.User
+	Session      interfaces.Session
+	LoginAttempt interfaces.LoginAttempt
+	TwoFactor    interfaces.TwoFactor
+	Identity     interfaces.Identity
+	Providers    identity.Providers
+	Keys         *utils.KeySet
+}
+
+// Execute completa el login con un proveedor externo. La identidad se busca por (proveedor, sub); si
+// es la primera vez se vincula a la cuenta con el mismo email, solo si el proveedor y la cuenta lo
+// tienen verificado, o se crea una cuenta nueva sin contraseña. Con 2FA activa se devuelve el desafío
+// del segundo paso igual que en el login con contraseña.
+func (uc *ExternalLoginImpl) Execute(ctx context.Context, providerName string, request *entities.ExternalLoginCallback, client entities.ClientInfo) (*entities.LoginResult, error) {
+	guard := &loginGuard{users: uc.User, attempts: uc.LoginAttempt}
+
+	provider, ok := uc.Providers[providerName]
+	if !ok {
+		return nil, errors.NewNotFound("Identity provider not found")
+	}
+
+	state, err := uc.Identity.ConsumeLoginState(ctx, utils.HashToken(request.State))
+	if err != nil {
+		return nil, errors.NewInternalServerError("Failed to get login state")
+	}
+	if state == nil || state.Provider != providerName {
+		return nil, errors.NewBadRequest("Invalid or expired login state")
+	}
+
+	external, err := provider.Exchange(ctx, request.Code, state.CodeVerifier, state.Nonce)
+	if err != nil {
+		fmt.Printf("Error completing %s login: %v\n", providerName, err)
+		return nil, errors.NewUnauthorized("Identity provider login failed")
+	}
+
+	user, linked, err := uc.resolveUser(ctx, external)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.IsSuspended() {
+		guard.recordAttempt(ctx, user.ID, user.Email, entities.LoginFailureSuspended, client)
+		return nil, errors.NewForbidden("Account is suspended")
+	}
+
+	if err := uc.Identity.RecordLogin(ctx, linked.ID, external.Email); err != nil {
+		fmt.Printf("Error recording %s login for user %d: %v\n", providerName, user.ID, err)
+	}
+
+	totp, err := uc.TwoFactor.GetTOTP(ctx, user.ID)
+	if err != nil {
+		return nil, errors.NewInternalServerError("Failed to get two-factor settings")
+	}
+	if totp.IsEnabled() {
+		challenge, err := createChallenge(ctx, uc.TwoFactor, user.ID, client)
+		if err != nil {
+			return nil, err
+		}
+		guard.recordAttempt(ctx, user.ID, user.Email, entities.LoginFailureTwoFactorPending, client)
+		return &entities.LoginResult{Challenge: challenge}, nil
+	}
+
+	tokens, err := startSession(ctx, uc.Keys, uc.User, uc.Session, user, client)
+	if err != nil {
+		return nil, err
+	}
+	guard.recordAttempt(ctx, user.ID, user.Email, "", client)
+
+	return &entities.LoginResult{Tokens: tokens}, nil
+}
+
+// resolveUser devuelve la cuenta de la identidad externa, vinculándola o creándola si hace falta
+func (uc *ExternalLoginImpl) resolveUser(ctx context.Context, external *entities.ExternalIdentity) (*entities.User, *entities.UserIdentity, error) {
+	linked, err := uc.Identity.GetByProviderSubject(ctx, external.Provider, external.Subject)
+	if err != nil {
+		return nil, nil, errors.NewInternalServerError("Failed to get identity: " + err.Error())
+	}
+	if linked != nil {
+		user, err := uc.User.GetByID(ctx, linked.UserID)
+		if err != nil {
+			return nil, nil, errors.NewInternalServerError("Failed to get user: " + err.Error())
+		}
+		if user == nil {
+			return nil, nil, errors.NewUnauthorized("Identity provider login failed")
+		}
+		return user, linked, nil
+	}
+
+	// Sin un email verificado por el proveedor no se puede vincular ni crear la cuenta: cualquiera
+	// podría registrarse en el proveedor con el email de otra persona
+	if external.Email == "" || !external.EmailVerified {
+		return nil, nil, errors.NewForbidden("Identity provider did not confirm a verified email address")
+	}
+
+	linked = &entities.UserIdentity{Provider: external.Provider, Subject: external.Subject, Email: external.Email}
+
+	user, err := uc.User.GetByEmail(ctx, external.Email)
+	if err != nil {
+		return nil, nil, errors.NewInternalServerError("Failed to get user: " + err.Error())
+	}
+	if user != nil {
+		// Una cuenta con el email sin verificar pudo crearla otra persona con una contraseña que
+		// conoce; vincularla le daría acceso a la cuenta del verdadero dueño del email
+		if !user.IsEmailVerified() {
+			return nil, nil, errors.NewConflict("An account with this email already exists. Log in with your password and verify your email first")
+		}
+
+		linked.UserID = user.ID
+		if err := uc.Identity.Link(ctx, linked); err != nil {
+			if _, ok := err.(*errors.APIError); ok {
+				return nil, nil, err
+			}
+			return nil, nil, errors.NewInternalServerError("Failed to link identity: " + err.Error())
+		}
+		fmt.Printf("Linked %s identity to user %d\n", external.Provider, user.ID)
+		return user, linked, nil
+	}
+
+	// Cuenta nueva sin contraseña; el email ya viene verificado por el proveedor
+	verifiedAt := time.Now()
+	user = &entities.User{
+		Name:            displayName(external),
+		Email:           external.Email,
+		Locale:          entities.DefaultLocale,
+		EmailVerifiedAt: &verifiedAt,
+	}
+	if err := uc.Identity.CreateUserWithIdentity(ctx, user, linked); err != nil {
+		if _, ok := err.(*errors.APIError); ok {
+			return nil, nil, err
+		}
+		return nil, nil, errors.NewInternalServerError("Failed to create user: " + err.Error())
+	}
+	fmt.Printf("Created user %d from %s identity\n", user.ID, external.Provider)
+
+	return user, linked, nil
+}
+
+// displayName usa el nombre informado por el proveedor o, si no hay, la parte local del email
+func displayName(external *entities.ExternalIdentity) string {
+	if external.Name != "" {
+		return external.Name
+	}
+	return strings.SplitN(external.Email, "@", 2)[0]
+}
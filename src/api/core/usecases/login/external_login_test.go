@@ -0,0 +1,116 @@
+package login
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"iycds2025_api/src/api/core/entities"
+	"iycds2025_api/src/api/core/errors"
+	"iycds2025_api/src/api/core/interfaces"
+)
+
+func (f *fakeUsers) GetByEmail(_ context.Context, email string) (*entities.User, error) {
+	if f.user == nil || f.user.Email != email {
+		return nil, nil
+	}
+	return f.user, nil
+}
+
+// fakeIdentities guarda las vinculaciones y las cuentas creadas a partir de identidades externas
+type fakeIdentities struct {
+	interfaces.Identity
+	existing *entities.UserIdentity
+	linked   []*entities.UserIdentity
+	created  []*entities.User
+}
+
+func (f *fakeIdentities) GetByProviderSubject(_ context.Context, provider string, subject string) (*entities.UserIdentity, error) {
+	if f.existing != nil && f.existing.Provider == provider && f.existing.Subject == subject {
+		return f.existing, nil
+	}
+	return nil, nil
+}
+
+func (f *fakeIdentities) Link(_ context.Context, identity *entities.UserIdentity) error {
+	f.linked = append(f.linked, identity)
+	return nil
+}
+
+func (f *fakeIdentities) CreateUserWithIdentity(_ context.Context, user *entities.User, identity *entities.UserIdentity) error {
+	user.ID = 99
+	identity.UserID = user.ID
+	f.created = append(f.created, user)
+	return nil
+}
+
+func TestExternalLoginResolveUser(t *testing.T) {
+	verifiedAt := time.Now()
+	verifiedUser := &entities.User{ID: 3, Email: "ana@example.com", EmailVerifiedAt: &verifiedAt}
+	unverifiedUser := &entities.User{ID: 3, Email: "ana@example.com"}
+
+	tests := []struct {
+		name          string
+		existing      *entities.UserIdentity
+		user          *entities.User
+		emailVerified bool
+		email         string
+		wantUserID    int64
+		wantCode      int
+		wantLinked    bool
+		wantCreated   bool
+	}{
+		{name: "verified email links existing account", user: verifiedUser, emailVerified: true, email: "ana@example.com", wantUserID: 3, wantLinked: true},
+		{name: "unverified email does not link", user: verifiedUser, emailVerified: false, email: "ana@example.com", wantCode: http.StatusForbidden},
+		{name: "account with unverified email is not linked", user: unverifiedUser, emailVerified: true, email: "ana@example.com", wantCode: http.StatusConflict},
+		{name: "verified email creates account", emailVerified: true, email: "nuevo@example.com", wantUserID: 99, wantCreated: true},
+		{name: "unverified email does not create account", emailVerified: false, email: "nuevo@example.com", wantCode: http.StatusForbidden},
+		{name: "missing email", emailVerified: true, email: "", wantCode: http.StatusForbidden},
+		{
+			// La identidad ya vinculada entra sin volver a mirar el email del proveedor
+			name:          "already linked identity",
+			existing:      &entities.UserIdentity{ID: 5, UserID: 3, Provider: "mock", Subject: "user-1"},
+			user:          unverifiedUser,
+			emailVerified: false,
+			email:         "otro@example.com",
+			wantUserID:    3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			identities := &fakeIdentities{existing: tt.existing}
+			uc := &ExternalLoginImpl{User: &fakeUsers{user: tt.user}, Identity: identities}
+			external := &entities.ExternalIdentity{Provider: "mock", Subject: "user-1", Email: tt.email, EmailVerified: tt.emailVerified}
+
+			user, _, err := uc.resolveUser(context.Background(), external)
+			if tt.wantCode != 0 {
+				apiErr, ok := err.(*errors.APIError)
+				if !ok || apiErr.Code != tt.wantCode {
+					t.Fatalf("got (%v, %v), want API error %d", user, err, tt.wantCode)
+				}
+			} else {
+				if err != nil {
+					t.Fatalf("resolveUser: %v", err)
+				}
+				if user.ID != tt.wantUserID {
+					t.Errorf("user ID = %d, want %d", user.ID, tt.wantUserID)
+				}
+			}
+
+			if linked := len(identities.linked) > 0; linked != tt.wantLinked {
+				t.Errorf("linked = %v, want %v", linked, tt.wantLinked)
+			}
+			if tt.wantLinked && identities.linked[0].UserID != tt.user.ID {
+				t.Errorf("identity linked to user %d, want %d", identities.linked[0].UserID, tt.user.ID)
+			}
+			if created := len(identities.created) > 0; created != tt.wantCreated {
+				t.Errorf("created = %v, want %v", created, tt.wantCreated)
+			}
+			if tt.wantCreated && !identities.created[0].IsEmailVerified() {
+				t.Error("account created from a verified identity has an unverified email")
+			}
+		})
+	}
+}
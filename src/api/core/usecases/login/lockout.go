@@ -0,0 +1,99 @@
+package login
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"iycds2025_api/src/api/core/entities"
+	"iycds2025_api/src/api/core/interfaces"
+	"iycds2025_api/src/api/services/mail"
+)
+
+// Protección del login por cuenta, independiente de la IP: frena a quien prueba contraseñas
+// desde muchas direcciones (credential stuffing) contra la misma cuenta
+const (
+	// delayAfterFailures es la cantidad de fallos consecutivos a partir de la cual cada intento debe esperar
+	delayAfterFailures = 3
+	// baseLoginDelay es la primera espera; se duplica con cada fallo siguiente
+	baseLoginDelay = 2 * time.Second
+	// lockoutAfterFailures es la cantidad de fallos consecutivos que bloquea la cuenta y avisa al dueño
+	lockoutAfterFailures = 10
+	// lockoutDuration es la duración del bloqueo; cada fallo posterior lo vuelve a aplicar
+	lockoutDuration = 15 * time.Minute
+	// failureWindow es el tiempo sin fallos después del cual el conteo vuelve a empezar
+	failureWindow = time.Hour
+)
+
+// loginDelay devuelve cuánto tiempo queda bloqueado el login después de la cantidad indicada de
+// fallos consecutivos: nada al principio, luego 2s, 4s, 8s... y el bloqueo completo al llegar al límite
+func loginDelay(failures int) time.Duration {
+	if failures >= lockoutAfterFailures {
+		return lockoutDuration
+	}
+	if failures < delayAfterFailures {
+		return 0
+	}
+	return baseLoginDelay << uint(failures-delayAfterFailures)
+}
+
+// loginGuard registra los intentos y aplica la protección por cuenta; lo comparten los dos pasos
+// del login para que los códigos 2FA incorrectos cuenten igual que las contraseñas incorrectas
+type loginGuard struct {
+	users        interfaces.User
+	attempts     interfaces.LoginAttempt
+	emailService mail.EmailService
+	frontendURL  string
+}
+
+// recordAttempt registra el intento de login; un error al registrarlo no impide responder
+func (g *loginGuard) recordAttempt(ctx context.Context, userID int64, email string, failureReason string, client entities.ClientInfo) {
+	attempt := &entities.LoginAttempt{
+		UserID:        userID,
+		Email:         email,
+		Success:       failureReason == "",
+		FailureReason: failureReason,
+		IPAddress:     client.IPAddress,
+		UserAgent:     client.UserAgent,
+	}
+	if err := g.attempts.Record(ctx, attempt); err != nil {
+		fmt.Printf("Error recording login attempt for %s: %v\n", email, err)
+	}
+}
+
+// registerFailure suma el fallo a la cuenta y aplica la espera o el bloqueo que corresponda
+func (g *loginGuard) registerFailure(ctx context.Context, user *entities.User, client entities.ClientInfo) {
+	failures, err := g.users.RecordLoginFailure(ctx, user.ID, failureWindow)
+	if err != nil {
+		fmt.Printf("Error recording login failure for user %d: %v\n", user.ID, err)
+		return
+	}
+
+	delay := loginDelay(failures)
+	if delay == 0 {
+		return
+	}
+
+	lockedUntil := time.Now().Add(delay)
+	if err := g.users.LockLogin(ctx, user.ID, lockedUntil); err != nil {
+		fmt.Printf("Error locking login for user %d: %v\n", user.ID, err)
+		return
+	}
+
+	// Se avisa una sola vez, al bloquear la cuenta; los fallos siguientes solo extienden el bloqueo
+	if failures != lockoutAfterFailures {
+		return
+	}
+	fmt.Printf("Login locked for user %d after %d failed attempts (last from %s)\n", user.ID, failures, client.IPAddress)
+
+	to := mail.Recipient{Email: user.Email, Name: user.Name, Locale: user.Locale}
+	data := mail.AccountLockedData{
+		FailedAttempts:    failures,
+		LockedUntil:       lockedUntil.UTC().Format("2006-01-02 15:04 UTC"),
+		IPAddress:         client.IPAddress,
+		ForgotPasswordURL: fmt.Sprintf("%s/forgot-password", g.frontendURL),
+	}
+	if err := g.emailService.SendAccountLockedEmail(to, data); err != nil {
+		fmt.Printf("Error queueing account locked email to %s: %v\n", user.Email, err)
+	}
+}
@@ -0,0 +1,29 @@
+package login
+
+import (
+	"context"
+	"database/sql"
+
+	"iycds2025_api/src/api/core/errors"
+	"iycds2025_api/src/api/core/interfaces"
+)
+
+type Logout interface {
+	Execute(ctx context.Context, userID int64, sessionID int64) error
+}
+
+type LogoutImpl struct {
+	Session interfaces.Session
+}
+
+// Execute revoca la sesión actual: su refresh token y sus access tokens dejan de valer
+func (uc *LogoutImpl) Execute(ctx context.Context, userID int64, sessionID int64) error {
+	if err := uc.Session.Revoke(ctx, sessionID, userID); err != nil {
+		if err == sql.ErrNoRows {
+			return errors.NewNotFound("Session not found")
+		}
+		return errors.NewInternalServerError("Failed to revoke session: " + err.Error())
+	}
+
+	return nil
+}
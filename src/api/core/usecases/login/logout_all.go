@@ -0,0 +1,26 @@
+package login
+
+import (
+	"context"
+
+	"iycds2025_api/src/api/core/errors"
+	"iycds2025_api/src/api/core/interfaces"
+)
+
+type LogoutAll interface {
+	Execute(ctx context.Context, userID int64) (int64, error)
+}
+
+type LogoutAllImpl struct {
+	Session interfaces.Session
+}
+
+// Execute revoca todas las sesiones del usuario, incluida la actual ("cerrar sesión en todos los dispositivos")
+func (uc *LogoutAllImpl) Execute(ctx context.Context, userID int64) (int64, error) {
+	revoked, err := uc.Session.RevokeAllForUser(ctx, userID, 0)
+	if err != nil {
+		return 0, errors.NewInternalServerError("Failed to revoke sessions: " + err.Error())
+	}
+
+	return revoked, nil
+}
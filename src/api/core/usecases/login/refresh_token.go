@@ -0,0 +1,57 @@
+package login
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"iycds2025_api/src/api/core/entities"
+	"iycds2025_api/src/api/core/errors"
+	"iycds2025_api/src/api/core/interfaces"
+	"iycds2025_api/src/api/utils"
+)
+
+type RefreshToken interface {
+	Execute(ctx context.Context, request *entities.RefreshTokenRequest) (*entities.AuthTokens, error)
+}
+
+type RefreshTokenImpl struct {
+	User    interfaces.User
+	Session interfaces.Session
+	Keys    *utils.KeySet
+}
+
+// Execute rota el refresh token: el presentado deja de valer y se entrega uno nuevo junto con
+// un access token nuevo
+func (uc *RefreshTokenImpl) Execute(ctx context.Context, request *entities.RefreshTokenRequest) (*entities.AuthTokens, error) {
+	newRefreshToken, newRefreshTokenHash, err := utils.GenerateRefreshToken()
+	if err != nil {
+		return nil, errors.NewInternalServerError("Failed to generate refresh token")
+	}
+	expiresAt := time.Now().Add(utils.RefreshTokenTTL)
+
+	session, err := uc.Session.Rotate(ctx, utils.HashToken(request.RefreshToken), newRefreshTokenHash, expiresAt)
+	if err != nil {
+		if err == errors.ErrRefreshTokenReused {
+			fmt.Printf("Refresh token reuse detected, session revoked\n")
+			return nil, errors.NewUnauthorized("Refresh token has already been used. Please log in again")
+		}
+		return nil, errors.NewInternalServerError("Failed to refresh session: " + err.Error())
+	}
+	if session == nil {
+		return nil, errors.NewUnauthorized("Invalid or expired refresh token")
+	}
+
+	user, err := uc.User.GetByID(ctx, session.UserID)
+	if err != nil {
+		return nil, errors.NewInternalServerError("Failed to get user: " + err.Error())
+	}
+	if user == nil {
+		return nil, errors.NewUnauthorized("Invalid or expired refresh token")
+	}
+	if user.IsSuspended() {
+		return nil, errors.NewForbidden("Account is suspended")
+	}
+
+	return issueTokens(ctx, uc.Keys, uc.User, user, session.ID, newRefreshToken, expiresAt)
+}
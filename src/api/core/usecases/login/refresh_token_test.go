@@ -0,0 +1,124 @@
+package login
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"iycds2025_api/src/api/core/entities"
+	"iycds2025_api/src/api/core/errors"
+	"iycds2025_api/src/api/core/interfaces"
+	"iycds2025_api/src/api/utils"
+)
+
+// fakeSessions responde Rotate con el resultado configurado y guarda los hashes recibidos
+type fakeSessions struct {
+	interfaces.Session
+	session    *entities.Session
+	err        error
+	gotHash    string
+	gotNewHash string
+}
+
+func (f *fakeSessions) Rotate(_ context.Context, refreshTokenHash string, newRefreshTokenHash string, _ time.Time) (*entities.Session, error) {
+	f.gotHash = refreshTokenHash
+	f.gotNewHash = newRefreshTokenHash
+	return f.session, f.err
+}
+
+// fakeUsers devuelve siempre el mismo usuario
+type fakeUsers struct {
+	interfaces.User
+	user *entities.User
+}
+
+func (f *fakeUsers) GetByID(_ context.Context, userID int64) (*entities.User, error) {
+	if f.user == nil || f.user.ID != userID {
+		return nil, nil
+	}
+	return f.user, nil
+}
+
+func (f *fakeUsers) GetPermissions(context.Context, int64) ([]string, error) {
+	return []string{"services:create"}, nil
+}
+
+func (f *fakeUsers) GetRole(context.Context, int64) (string, error) {
+	return "provider", nil
+}
+
+func newTestKeySet(t *testing.T) *utils.KeySet {
+	t.Helper()
+	key, _, err := utils.GenerateSigningKey(utils.AlgorithmEdDSA)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keys, err := utils.NewKeySet("test", key.ID, []*utils.SigningKey{key})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return keys
+}
+
+func TestRefreshTokenRotates(t *testing.T) {
+	keys := newTestKeySet(t)
+	sessions := &fakeSessions{session: &entities.Session{ID: 7, UserID: 3}}
+	uc := &RefreshTokenImpl{User: &fakeUsers{user: &entities.User{ID: 3}}, Session: sessions, Keys: keys}
+
+	tokens, err := uc.Execute(context.Background(), &entities.RefreshTokenRequest{RefreshToken: "presented"})
+	if err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+
+	// Solo viajan hashes al repositorio, y el token nuevo corresponde al hash guardado
+	if sessions.gotHash != utils.HashToken("presented") {
+		t.Errorf("Rotate received hash %q, want hash of the presented token", sessions.gotHash)
+	}
+	if tokens.RefreshToken == "presented" || utils.HashToken(tokens.RefreshToken) != sessions.gotNewHash {
+		t.Error("returned refresh token does not match the hash stored by Rotate")
+	}
+
+	claims, err := keys.ParseJWT(tokens.AccessToken)
+	if err != nil {
+		t.Fatalf("ParseJWT: %v", err)
+	}
+	if claims.ID != 3 || claims.SessionID != 7 {
+		t.Errorf("access token claims = user %d session %d, want user 3 session 7", claims.ID, claims.SessionID)
+	}
+}
+
+func TestRefreshTokenRejected(t *testing.T) {
+	suspendedAt := time.Now()
+	tests := []struct {
+		name     string
+		session  *entities.Session
+		err      error
+		user     *entities.User
+		wantCode int
+	}{
+		{name: "reused token", err: errors.ErrRefreshTokenReused, user: &entities.User{ID: 3}, wantCode: http.StatusUnauthorized},
+		{name: "expired or revoked", user: &entities.User{ID: 3}, wantCode: http.StatusUnauthorized},
+		{name: "deleted user", session: &entities.Session{ID: 7, UserID: 3}, wantCode: http.StatusUnauthorized},
+		{name: "suspended user", session: &entities.Session{ID: 7, UserID: 3}, user: &entities.User{ID: 3, SuspendedAt: &suspendedAt}, wantCode: http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc := &RefreshTokenImpl{
+				User:    &fakeUsers{user: tt.user},
+				Session: &fakeSessions{session: tt.session, err: tt.err},
+				Keys:    newTestKeySet(t),
+			}
+
+			tokens, err := uc.Execute(context.Background(), &entities.RefreshTokenRequest{RefreshToken: "presented"})
+			if tokens != nil {
+				t.Fatalf("got tokens %+v, want none", tokens)
+			}
+			apiErr, ok := err.(*errors.APIError)
+			if !ok || apiErr.Code != tt.wantCode {
+				t.Fatalf("got error %v, want API error %d", err, tt.wantCode)
+			}
+		})
+	}
+}
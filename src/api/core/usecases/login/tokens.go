@@ -0,0 +1,63 @@
+package login
+
+import (
+	"context"
+	"time"
+
+	"iycds2025_api/src/api/core/entities"
+	"iycds2025_api/src/api/core/errors"
+	"iycds2025_api/src/api/core/interfaces"
+	"iycds2025_api/src/api/utils"
+)
+
+// issueTokens arma la respuesta de login/refresh: un access token nuevo para la sesión, con el rol
+// y los permisos actuales del usuario, junto con el refresh token ya guardado
+func issueTokens(ctx context.Context, keys *utils.KeySet, users interfaces.User, user *entities.User, sessionID int64, refreshToken string, refreshExpiresAt time.Time) (*entities.AuthTokens, error) {
+	permissions, err := users.GetPermissions(ctx, user.ID)
+	if err != nil {
+		return nil, errors.NewInternalServerError("Failed to fetch permissions")
+	}
+
+	role, err := users.GetRole(ctx, user.ID)
+	if err != nil {
+		return nil, errors.NewInternalServerError("Failed to fetch role")
+	}
+
+	accessToken, err := keys.GenerateJWT(user.ID, sessionID, role, permissions, user.FirstLogin, user.IsEmailVerified())
+	if err != nil {
+		return nil, errors.NewInternalServerError("Failed to generate JWT token")
+	}
+
+	tokens := &entities.AuthTokens{
+		Token:            accessToken,
+		AccessToken:      accessToken,
+		RefreshToken:     refreshToken,
+		TokenType:        "Bearer",
+		ExpiresIn:        int(utils.AccessTokenTTL.Seconds()),
+		RefreshExpiresIn: int(time.Until(refreshExpiresAt).Seconds()),
+	}
+
+	// Durante el período de gracia se puede iniciar sesión; el cliente muestra la fecha de la baja
+	// y la opción de cancelarla
+	if user.IsDeletionPending() {
+		tokens.DeletionScheduledAt = user.DeletionScheduledAt
+	}
+
+	return tokens, nil
+}
+
+// startSession abre una sesión nueva para el dispositivo del usuario y entrega sus tokens
+func startSession(ctx context.Context, keys *utils.KeySet, users interfaces.User, sessions interfaces.Session, user *entities.User, client entities.ClientInfo) (*entities.AuthTokens, error) {
+	refreshToken, refreshTokenHash, err := utils.GenerateRefreshToken()
+	if err != nil {
+		return nil, errors.NewInternalServerError("Failed to generate refresh token")
+	}
+	expiresAt := time.Now().Add(utils.RefreshTokenTTL)
+
+	session, err := sessions.Create(ctx, user.ID, refreshTokenHash, expiresAt, client)
+	if err != nil {
+		return nil, errors.NewInternalServerError("Failed to create session")
+	}
+
+	return issueTokens(ctx, keys, users, user, session.ID, refreshToken, expiresAt)
+}
@@ -0,0 +1,116 @@
+package login
+
+import (
+	"context"
+	"time"
+
+	"iycds2025_api/src/api/core/entities"
+	"iycds2025_api/src/api/core/errors"
+	"iycds2025_api/src/api/core/interfaces"
+	"iycds2025_api/src/api/core/usecases/twofactor"
+	"iycds2025_api/src/api/services/mail"
+	"iycds2025_api/src/api/utils"
+)
+
+const (
+	// challengeTTL es el tiempo que tiene el usuario para ingresar el código después de la contraseña
+	challengeTTL = 5 * time.Minute
+	// maxChallengeAttempts es la cantidad de códigos incorrectos que anula el desafío
+	maxChallengeAttempts = 5
+)
+
+type VerifyTwoFactorLogin interface {
+	Execute(ctx context.Context, request *entities.TwoFactorLogin, client entities.ClientInfo) (*entities.AuthTokens, error)
+}
+
+type VerifyTwoFactorLoginImpl struct {
+	User         interfaces.User
+	Session      interfaces.Session
+	LoginAttempt interfaces.LoginAttempt
+	TwoFactor    interfaces.TwoFactor
+	Keys         *utils.KeySet
+	EmailService mail.EmailService
+	FrontendURL  string
+}
+
+// Execute completa el login de una cuenta con 2FA: canjea el desafío del primer paso y un código
+// válido (de la app o de recuperación) por los tokens de una sesión nueva
+func (uc *VerifyTwoFactorLoginImpl) Execute(ctx context.Context, request *entities.TwoFactorLogin, client entities.ClientInfo) (*entities.AuthTokens, error) {
+	guard := &loginGuard{users: uc.User, attempts: uc.LoginAttempt, emailService: uc.EmailService, frontendURL: uc.FrontendURL}
+
+	challenge, err := uc.TwoFactor.GetChallenge(ctx, utils.HashToken(request.ChallengeToken))
+	if err != nil {
+		return nil, errors.NewInternalServerError("Failed to get login challenge")
+	}
+	if challenge == nil || challenge.Attempts >= maxChallengeAttempts {
+		return nil, errors.NewUnauthorized("Invalid or expired challenge token")
+	}
+
+	user, err := uc.User.GetByID(ctx, challenge.UserID)
+	if err != nil {
+		return nil, errors.NewInternalServerError("Failed to get user")
+	}
+	if user == nil {
+		return nil, errors.NewUnauthorized("Invalid or expired challenge token")
+	}
+
+	// El bloqueo por cuenta también frena el segundo paso
+	if user.IsLoginLocked(time.Now()) {
+		guard.recordAttempt(ctx, user.ID, user.Email, entities.LoginFailureLocked, client)
+		return nil, errors.NewTooManyRequests("Too many failed login attempts. Try again later")
+	}
+	if user.IsSuspended() {
+		guard.recordAttempt(ctx, user.ID, user.Email, entities.LoginFailureSuspended, client)
+		return nil, errors.NewForbidden("Account is suspended")
+	}
+
+	totp, err := uc.TwoFactor.GetTOTP(ctx, user.ID)
+	if err != nil {
+		return nil, errors.NewInternalServerError("Failed to get two-factor settings")
+	}
+	if !totp.IsEnabled() {
+		return nil, errors.NewUnauthorized("Invalid or expired challenge token")
+	}
+
+	valid, err := twofactor.CheckCode(ctx, uc.TwoFactor, totp, request.Code)
+	if err != nil {
+		return nil, errors.NewInternalServerError("Failed to verify code")
+	}
+	if !valid {
+		guard.recordAttempt(ctx, user.ID, user.Email, entities.LoginFailureInvalidTwoFactor, client)
+		guard.registerFailure(ctx, user, client)
+
+		attempts, err := uc.TwoFactor.RecordChallengeFailure(ctx, challenge.ID)
+		if err != nil {
+			return nil, errors.NewInternalServerError("Failed to record invalid code")
+		}
+		if attempts >= maxChallengeAttempts {
+			return nil, errors.NewUnauthorized("Too many invalid codes. Please log in again")
+		}
+		return nil, errors.NewUnauthorized("Invalid verification code")
+	}
+
+	// El desafío se canjea una sola vez aunque lleguen dos requests con códigos válidos
+	consumed, err := uc.TwoFactor.ConsumeChallenge(ctx, challenge.ID)
+	if err != nil {
+		return nil, errors.NewInternalServerError("Failed to complete login challenge")
+	}
+	if !consumed {
+		return nil, errors.NewUnauthorized("Invalid or expired challenge token")
+	}
+
+	// El login se completó: el conteo de fallos vuelve a empezar
+	if user.FailedLoginCount > 0 || user.LockedUntil != nil {
+		if err := uc.User.ResetLoginFailures(ctx, user.ID); err != nil {
+			return nil, errors.NewInternalServerError("Failed to reset login failures")
+		}
+	}
+
+	tokens, err := startSession(ctx, uc.Keys, uc.User, uc.Session, user, client)
+	if err != nil {
+		return nil, err
+	}
+	guard.recordAttempt(ctx, user.ID, user.Email, "", client)
+
+	return tokens, nil
+}
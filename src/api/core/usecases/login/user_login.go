@@ -2,45 +2,113 @@ package login
 
 import (
 	"context"
+	"time"
 
 	"iycds2025_api/src/api/core/entities"
 	"iycds2025_api/src/api/core/errors"
 	"iycds2025_api/src/api/core/interfaces"
+	"iycds2025_api/src/api/services/mail"
 	"iycds2025_api/src/api/utils"
 )
 
 type UserLogin interface {
-	Execute(ctx context.Context, userRequest *entities.Login) (string, error)
+	Execute(ctx context.Context, userRequest *entities.Login, client entities.ClientInfo) (*entities.LoginResult, error)
 }
 
 type UserLoginImpl struct {
-	User interfaces.User
+	User         interfaces.User
+	Session      interfaces.Session
+	LoginAttempt interfaces.LoginAttempt
+	TwoFactor    interfaces.TwoFactor
+	Keys         *utils.KeySet
+	EmailService mail.EmailService
+	FrontendURL  string
 }
 
-func (uc *UserLoginImpl) Execute(ctx context.Context, userRequest *entities.Login) (string, error) {
+func (uc *UserLoginImpl) guard() *loginGuard {
+	return &loginGuard{users: uc.User, attempts: uc.LoginAttempt, emailService: uc.EmailService, frontendURL: uc.FrontendURL}
+}
+
+// Execute valida email y contraseña. Si la cuenta tiene 2FA no se abre la sesión: se devuelve un
+// desafío que se completa con el código en VerifyTwoFactorLogin.
+func (uc *UserLoginImpl) Execute(ctx context.Context, userRequest *entities.Login, client entities.ClientInfo) (*entities.LoginResult, error) {
+	guard := uc.guard()
+
 	user, err := uc.User.GetByEmail(ctx, userRequest.Email)
-	if err != nil || user == nil {
-		return "", errors.NewUnauthorized("Invalid credentials")
+	if err != nil {
+		return nil, errors.NewUnauthorized("Invalid credentials")
+	}
+	if user == nil {
+		guard.recordAttempt(ctx, 0, userRequest.Email, entities.LoginFailureUnknownEmail, client)
+		return nil, errors.NewUnauthorized("Invalid credentials")
+	}
+
+	// Mientras dure el bloqueo no se verifica la contraseña, ni siquiera si es la correcta:
+	// así no se pueden seguir probando contraseñas desde otras IPs
+	if user.IsLoginLocked(time.Now()) {
+		guard.recordAttempt(ctx, user.ID, userRequest.Email, entities.LoginFailureLocked, client)
+		return nil, errors.NewTooManyRequests("Too many failed login attempts. Try again later")
 	}
 
 	checkPasswordHash := utils.CheckPasswordHash(userRequest.Password, user.Password)
 	if !checkPasswordHash {
-		return "", errors.NewUnauthorized("Invalid credentials")
+		guard.recordAttempt(ctx, user.ID, userRequest.Email, entities.LoginFailureInvalidPassword, client)
+		guard.registerFailure(ctx, user, client)
+		return nil, errors.NewUnauthorized("Invalid credentials")
+	}
+
+	// Una cuenta suspendida por un administrador no puede iniciar sesión
+	if user.IsSuspended() {
+		guard.recordAttempt(ctx, user.ID, userRequest.Email, entities.LoginFailureSuspended, client)
+		return nil, errors.NewForbidden("Account is suspended")
 	}
 
-	permissions, err := uc.User.GetPermissions(ctx, user.ID)
+	totp, err := uc.TwoFactor.GetTOTP(ctx, user.ID)
 	if err != nil {
-		return "", errors.NewInternalServerError("Failed to fetch permissions")
+		return nil, errors.NewInternalServerError("Failed to get two-factor settings")
+	}
+	if totp.IsEnabled() {
+		// El conteo de fallos no se reinicia todavía: si no, con la contraseña se podría volver a
+		// empezar el conteo y probar códigos sin límite
+		challenge, err := createChallenge(ctx, uc.TwoFactor, user.ID, client)
+		if err != nil {
+			return nil, err
+		}
+		guard.recordAttempt(ctx, user.ID, userRequest.Email, entities.LoginFailureTwoFactorPending, client)
+		return &entities.LoginResult{Challenge: challenge}, nil
+	}
+
+	// La contraseña es correcta: el conteo de fallos vuelve a empezar
+	if user.FailedLoginCount > 0 || user.LockedUntil != nil {
+		if err := uc.User.ResetLoginFailures(ctx, user.ID); err != nil {
+			return nil, errors.NewInternalServerError("Failed to reset login failures")
+		}
 	}
 
-	role, err := uc.User.GetRole(ctx, user.ID)
+	// Abrir una sesión nueva para este dispositivo
+	tokens, err := startSession(ctx, uc.Keys, uc.User, uc.Session, user, client)
 	if err != nil {
-		return "", errors.NewInternalServerError("Failed to fetch role")
+		return nil, err
 	}
+	guard.recordAttempt(ctx, user.ID, userRequest.Email, "", client)
+
+	return &entities.LoginResult{Tokens: tokens}, nil
+}
 
-	token, err := utils.GenerateJWT(user.ID, role, permissions, user.FirstLogin)
+// createChallenge guarda un desafío para el segundo paso; el token se entrega una sola vez al cliente
+func createChallenge(ctx context.Context, twoFactor interfaces.TwoFactor, userID int64, client entities.ClientInfo) (*entities.TwoFactorChallenge, error) {
+	token, tokenHash, err := utils.GenerateRefreshToken()
 	if err != nil {
-		return "", errors.NewInternalServerError("Failed to generate JWT token")
+		return nil, errors.NewInternalServerError("Failed to generate challenge token")
+	}
+
+	if err := twoFactor.CreateChallenge(ctx, userID, tokenHash, time.Now().Add(challengeTTL), client); err != nil {
+		return nil, errors.NewInternalServerError("Failed to create login challenge")
 	}
-	return token, nil
+
+	return &entities.TwoFactorChallenge{
+		TwoFactorRequired: true,
+		ChallengeToken:    token,
+		ExpiresIn:         int(challengeTTL.Seconds()),
+	}, nil
 }
@@ -0,0 +1,78 @@
+package password
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"iycds2025_api/src/api/core/entities"
+	"iycds2025_api/src/api/core/errors"
+	"iycds2025_api/src/api/core/interfaces"
+	"iycds2025_api/src/api/services/mail"
+	"iycds2025_api/src/api/utils"
+)
+
+type ChangePassword interface {
+	Execute(ctx context.Context, userID int64, sessionID int64, request *entities.ChangePassword, client entities.ClientInfo) error
+}
+
+type ChangePasswordImpl struct {
+	User         interfaces.User
+	EmailService mail.EmailService
+	FrontendURL  string
+}
+
+// Execute cambia la contraseña de un usuario con sesión iniciada. La sesión actual sigue activa;
+// las demás se cierran porque pudieron abrirse con la contraseña anterior.
+func (uc *ChangePasswordImpl) Execute(ctx context.Context, userID int64, sessionID int64, request *entities.ChangePassword, client entities.ClientInfo) error {
+	user, err := uc.User.GetByID(ctx, userID)
+	if err != nil {
+		return errors.NewInternalServerError("Failed to get user: " + err.Error())
+	}
+	if user == nil {
+		return errors.NewNotFound("User not found")
+	}
+
+	// Las cuentas creadas con un proveedor externo definen su primera contraseña con el restablecimiento
+	if !user.HasPassword() {
+		return errors.NewBadRequest("Account has no password. Use forgot password to set one")
+	}
+
+	// Verificar la contraseña actual
+	if !utils.CheckPasswordHash(request.CurrentPassword, user.Password) {
+		return errors.NewBadRequest("Current password is incorrect")
+	}
+
+	if request.NewPassword == request.CurrentPassword {
+		return errors.NewBadRequest("New password must be different from the current password")
+	}
+
+	// Encriptar la nueva contraseña (aplica la política de contraseñas)
+	hashedPassword, err := utils.HashPassword(request.NewPassword)
+	if err != nil {
+		return errors.NewBadRequest("Invalid password: " + err.Error())
+	}
+
+	revoked, err := uc.User.ChangePassword(ctx, userID, hashedPassword, sessionID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return errors.NewNotFound("User not found")
+		}
+		return errors.NewInternalServerError("Failed to change password: " + err.Error())
+	}
+	fmt.Printf("Password changed for user %d, %d other sessions revoked\n", userID, revoked)
+
+	// Aviso de seguridad: si no fue el usuario, puede restablecer la contraseña desde el enlace
+	to := mail.Recipient{Email: user.Email, Name: user.Name, Locale: user.Locale}
+	data := mail.PasswordChangedData{
+		ChangedAt:         time.Now().UTC().Format("2006-01-02 15:04 UTC"),
+		IPAddress:         client.IPAddress,
+		ForgotPasswordURL: fmt.Sprintf("%s/forgot-password", uc.FrontendURL),
+	}
+	if err := uc.EmailService.SendPasswordChangedEmail(to, data); err != nil {
+		fmt.Printf("Error queueing password changed email to %s: %v\n", user.Email, err)
+	}
+
+	return nil
+}
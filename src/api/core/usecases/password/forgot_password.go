@@ -40,13 +40,14 @@ func (uc *ForgotPasswordImpl) Execute(ctx context.Context, request *entities.For
 	// Construir el enlace de restablecimiento
 	resetLink := fmt.Sprintf("%s/reset-password/%s", uc.FrontendURL, token)
 
-	// Enviar correo electrónico
-	err = uc.EmailService.SendPasswordResetEmail(request.Email, resetLink)
+	// Encolar el correo electrónico; el worker del outbox lo envía y reintenta si falla
+	to := mail.Recipient{Email: user.Email, Name: user.Name, Locale: user.Locale}
+	err = uc.EmailService.SendPasswordResetEmail(to, resetLink)
 	if err != nil {
 		// Registrar el error pero no devolverlo al cliente por seguridad
-		fmt.Printf("Error sending password reset email to %s: %v\n", request.Email, err)
+		fmt.Printf("Error queueing password reset email to %s: %v\n", request.Email, err)
 	} else {
-		fmt.Printf("Password reset email sent successfully to %s. Expires at: %s\n",
+		fmt.Printf("Password reset email queued for %s. Expires at: %s\n",
 			request.Email, expiresAt.Format("2006-01-02 15:04:05"))
 	}
 
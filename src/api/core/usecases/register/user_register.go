@@ -2,10 +2,12 @@ package register
 
 import (
 	"context"
+	"fmt"
 
 	"iycds2025_api/src/api/core/entities"
 	"iycds2025_api/src/api/core/errors"
 	"iycds2025_api/src/api/core/interfaces"
+	"iycds2025_api/src/api/core/usecases/verification"
 	"iycds2025_api/src/api/utils"
 )
 
@@ -14,7 +16,8 @@ type UserRegister interface {
 }
 
 type UserRegisterImpl struct {
-	User interfaces.User
+	User                  interfaces.User
+	SendEmailVerification verification.SendEmailVerification
 }
 
 func (uc *UserRegisterImpl) Execute(ctx context.Context, userRequest *entities.UserRegister) (*entities.User, error) {
@@ -47,5 +50,10 @@ func (uc *UserRegisterImpl) Execute(ctx context.Context, userRequest *entities.U
 		return nil, errors.NewInternalServerError("Failed to create user")
 	}
 
+	// Enviar el enlace de verificación; la cuenta ya existe, así que si falla el usuario puede pedir que se reenvíe
+	if err := uc.SendEmailVerification.Execute(ctx, user, user.Email); err != nil {
+		fmt.Printf("Error sending email verification to user %d: %v\n", user.ID, err)
+	}
+
 	return user, nil
 }
@@ -0,0 +1,47 @@
+package review
+
+import (
+	"context"
+
+	"iycds2025_api/src/api/core/entities"
+	"iycds2025_api/src/api/core/errors"
+	"iycds2025_api/src/api/core/interfaces"
+)
+
+type CreateReview interface {
+	Execute(ctx context.Context, appointmentID int64, reviewReq *entities.ReviewCreate, clientID int64) (*entities.Review, error)
+}
+
+type CreateReviewImpl struct {
+	Appointment interfaces.Appointment
+	Review      interfaces.Review
+}
+
+func (uc *CreateReviewImpl) Execute(ctx context.Context, appointmentID int64, reviewReq *entities.ReviewCreate, clientID int64) (*entities.Review, error) {
+	// Obtener la cita a reseñar
+	appointment, err := uc.Appointment.GetByID(ctx, appointmentID)
+	if err != nil {
+		return nil, errors.NewInternalServerError("Failed to get appointment: " + err.Error())
+	}
+	if appointment == nil {
+		return nil, errors.NewNotFound("Appointment not found")
+	}
+
+	// Solo el cliente de la cita puede reseñarla, y solo una vez completada
+	if appointment.ClientID != clientID {
+		return nil, errors.NewForbidden("Only the client of the appointment can review it")
+	}
+	if appointment.Status != "completed" {
+		return nil, errors.NewBadRequest("Only completed appointments can be reviewed")
+	}
+
+	review, err := uc.Review.Create(ctx, appointment, reviewReq)
+	if err != nil {
+		if err == errors.ErrAlreadyReviewed {
+			return nil, errors.NewConflict("This appointment has already been reviewed")
+		}
+		return nil, errors.NewInternalServerError("Failed to create review: " + err.Error())
+	}
+
+	return review, nil
+}
@@ -0,0 +1,64 @@
+package review
+
+import (
+	"context"
+	"fmt"
+
+	"iycds2025_api/src/api/core/entities"
+	"iycds2025_api/src/api/core/errors"
+	"iycds2025_api/src/api/core/interfaces"
+)
+
+const (
+	// DefaultPageSize es la cantidad de reseñas por página si no se indica otra
+	DefaultPageSize = 10
+	// MaxPageSize es la cantidad máxima de reseñas que se pueden pedir por página
+	MaxPageSize = 50
+)
+
+type ListServiceReviews interface {
+	Execute(ctx context.Context, serviceID int64, page int, pageSize int) (*entities.ReviewListResponse, error)
+}
+
+type ListServiceReviewsImpl struct {
+	Service interfaces.Service
+	Review  interfaces.Review
+}
+
+func (uc *ListServiceReviewsImpl) Execute(ctx context.Context, serviceID int64, page int, pageSize int) (*entities.ReviewListResponse, error) {
+	if page == 0 {
+		page = 1
+	}
+	if page < 1 {
+		return nil, errors.NewBadRequest("Page must be greater than 0")
+	}
+	if pageSize == 0 {
+		pageSize = DefaultPageSize
+	}
+	if pageSize < 1 || pageSize > MaxPageSize {
+		return nil, errors.NewBadRequest(fmt.Sprintf("Page size must be between 1 and %d", MaxPageSize))
+	}
+
+	// Verificar que el servicio existe
+	service, err := uc.Service.GetByID(ctx, serviceID)
+	if err != nil {
+		return nil, errors.NewInternalServerError("Failed to get service: " + err.Error())
+	}
+	if service == nil {
+		return nil, errors.NewNotFound("Service not found")
+	}
+
+	reviews, total, err := uc.Review.GetByServiceID(ctx, serviceID, page, pageSize)
+	if err != nil {
+		return nil, errors.NewInternalServerError("Failed to get reviews: " + err.Error())
+	}
+
+	return &entities.ReviewListResponse{
+		Reviews:       reviews,
+		RatingAverage: service.RatingAverage,
+		ReviewCount:   total,
+		Page:          page,
+		PageSize:      pageSize,
+		TotalPages:    (total + pageSize - 1) / pageSize,
+	}, nil
+}
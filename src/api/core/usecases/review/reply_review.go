@@ -0,0 +1,46 @@
+package review
+
+import (
+	"context"
+	"database/sql"
+
+	"iycds2025_api/src/api/core/entities"
+	"iycds2025_api/src/api/core/errors"
+	"iycds2025_api/src/api/core/interfaces"
+)
+
+type ReplyReview interface {
+	Execute(ctx context.Context, reviewID int64, replyReq *entities.ReviewReply, providerID int64) (*entities.Review, error)
+}
+
+type ReplyReviewImpl struct {
+	Review interfaces.Review
+}
+
+func (uc *ReplyReviewImpl) Execute(ctx context.Context, reviewID int64, replyReq *entities.ReviewReply, providerID int64) (*entities.Review, error) {
+	// Verificar que la reseña existe y es de un servicio del proveedor
+	existing, err := uc.Review.GetByID(ctx, reviewID)
+	if err != nil {
+		return nil, errors.NewInternalServerError("Failed to get review: " + err.Error())
+	}
+	if existing == nil {
+		return nil, errors.NewNotFound("Review not found")
+	}
+	if existing.ProviderID != providerID {
+		return nil, errors.NewForbidden("Only the service provider can reply to this review")
+	}
+	if existing.Reply != nil {
+		return nil, errors.NewConflict("This review already has a reply")
+	}
+
+	review, err := uc.Review.Reply(ctx, reviewID, providerID, replyReq.Reply)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			// Otra petición respondió la reseña mientras tanto
+			return nil, errors.NewConflict("This review already has a reply")
+		}
+		return nil, errors.NewInternalServerError("Failed to reply review: " + err.Error())
+	}
+
+	return review, nil
+}
@@ -2,7 +2,6 @@ package service
 
 import (
 	"context"
-	"encoding/json"
 	"strings"
 
 	"iycds2025_api/src/api/core/entities"
@@ -16,10 +15,24 @@ type CreateService interface {
 }
 
 type CreateServiceImpl struct {
-	Service interfaces.Service
+	Service       interfaces.Service
+	ServiceSearch interfaces.ServiceSearch
+	User          interfaces.User
 }
 
 func (uc *CreateServiceImpl) Execute(ctx context.Context, serviceReq *entities.ServiceCreate, userID int64) (*entities.ServiceResponse, error) {
+	// Solo las cuentas con el email verificado pueden publicar servicios
+	user, err := uc.User.GetByID(ctx, userID)
+	if err != nil {
+		return nil, errors.NewInternalServerError("Failed to get user: " + err.Error())
+	}
+	if user == nil {
+		return nil, errors.NewNotFound("User not found")
+	}
+	if !user.IsEmailVerified() {
+		return nil, errors.NewForbidden("Email address is not verified")
+	}
+
 	// Validar categoría
 	normalizedCategory, isValid := utils.NormalizeCategory(serviceReq.Category)
 	if !isValid {
@@ -27,40 +40,31 @@ func (uc *CreateServiceImpl) Execute(ctx context.Context, serviceReq *entities.S
 	}
 	serviceReq.Category = normalizedCategory
 
+	// Validar la disponibilidad semanal
+	if serviceReq.Availability == nil {
+		return nil, errors.NewBadRequest("Availability is required")
+	}
+	if err := utils.ValidateAvailability(serviceReq.Availability); err != nil {
+		return nil, errors.NewBadRequest("Invalid availability: " + err.Error())
+	}
+
+	// Validar la zona horaria en la que se interpretan los horarios
+	if serviceReq.Timezone == "" {
+		serviceReq.Timezone = utils.DefaultTimezone
+	}
+	if err := utils.ValidateTimezone(serviceReq.Timezone); err != nil {
+		return nil, errors.NewBadRequest("Invalid timezone: " + err.Error())
+	}
+
 	// Crear el servicio
 	service, err := uc.Service.Create(ctx, serviceReq, userID)
 	if err != nil {
 		return nil, errors.NewInternalServerError("Failed to create service: " + err.Error())
 	}
 
-	// Convertir a response
-	return uc.toServiceResponse(service)
-}
+	// Indexar el servicio para la búsqueda de texto libre
+	indexService(ctx, uc.ServiceSearch, service)
 
-func (uc *CreateServiceImpl) toServiceResponse(service *entities.Service) (*entities.ServiceResponse, error) {
-	// Parsear availability JSON
-	var availability map[string]interface{}
-	if err := json.Unmarshal([]byte(service.Availability), &availability); err != nil {
-		return nil, errors.NewInternalServerError("Failed to parse availability")
-	}
-
-	// Parsear zones JSON
-	var zones []entities.Zone
-	if err := json.Unmarshal([]byte(service.Zones), &zones); err != nil {
-		return nil, errors.NewInternalServerError("Failed to parse zones")
-	}
-
-	return &entities.ServiceResponse{
-		ID:           service.ID,
-		Title:        service.Title,
-		Description:  service.Description,
-		Category:     service.Category,
-		Price:        service.Price,
-		Availability: availability,
-		Zones:        zones,
-		Status:       service.Status,
-		ImageURL:     service.ImageURL,
-		CreatedAt:    service.CreatedAt,
-		UpdatedAt:    service.UpdatedAt,
-	}, nil
+	// Convertir a response
+	return toServiceResponse(service)
 }
@@ -3,21 +3,28 @@ package service
 import (
 	"context"
 	"database/sql"
+	"fmt"
 
 	"iycds2025_api/src/api/core/errors"
 	"iycds2025_api/src/api/core/interfaces"
+	"iycds2025_api/src/api/core/policy"
+	"iycds2025_api/src/api/core/usecases/serviceimage"
+	"iycds2025_api/src/api/services/storage"
 )
 
 type DeleteService interface {
-	Execute(ctx context.Context, id int64, userID int64) error
+	Execute(ctx context.Context, id int64, actor policy.Actor) error
 }
 
 type DeleteServiceImpl struct {
-	Service interfaces.Service
+	Service       interfaces.Service
+	ServiceSearch interfaces.ServiceSearch
+	ServiceImage  interfaces.ServiceImage
+	BlobStore     storage.BlobStore
 }
 
-func (uc *DeleteServiceImpl) Execute(ctx context.Context, id int64, userID int64) error {
-	// Verificar que el servicio existe y pertenece al usuario
+func (uc *DeleteServiceImpl) Execute(ctx context.Context, id int64, actor policy.Actor) error {
+	// Verificar que el servicio existe y que el usuario puede eliminarlo (dueño o moderador)
 	existing, err := uc.Service.GetByID(ctx, id)
 	if err != nil {
 		return errors.NewInternalServerError("Failed to get service: " + err.Error())
@@ -25,12 +32,18 @@ func (uc *DeleteServiceImpl) Execute(ctx context.Context, id int64, userID int64
 	if existing == nil {
 		return errors.NewNotFound("Service not found")
 	}
-	if existing.UserID != userID {
-		return errors.NewUnauthorized("You don't have permission to delete this service")
+	if !policy.CanManageService(actor, existing) {
+		return errors.NewForbidden("You don't have permission to delete this service")
 	}
 
-	// Eliminar el servicio de manera definitiva
-	err = uc.Service.Delete(ctx, id, userID)
+	// Las filas de las fotos se borran en cascada con el servicio: leerlas antes para borrar los archivos
+	serviceImages, err := uc.ServiceImage.GetByServiceID(ctx, id)
+	if err != nil {
+		return errors.NewInternalServerError("Failed to get service images: " + err.Error())
+	}
+
+	// Eliminar el servicio de manera definitiva (a nombre del dueño, aunque actúe un moderador)
+	err = uc.Service.Delete(ctx, id, existing.UserID)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return errors.NewNotFound("Service not found or already deleted")
@@ -38,5 +51,12 @@ func (uc *DeleteServiceImpl) Execute(ctx context.Context, id int64, userID int64
 		return errors.NewInternalServerError("Failed to delete service: " + err.Error())
 	}
 
+	// Quitar el servicio del índice de búsqueda
+	if err := uc.ServiceSearch.Remove(ctx, id); err != nil {
+		fmt.Printf("Error removing service %d from search index: %v\n", id, err)
+	}
+
+	serviceimage.DeleteFiles(ctx, uc.BlobStore, serviceImages)
+
 	return nil
 }
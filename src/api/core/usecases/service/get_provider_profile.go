@@ -0,0 +1,97 @@
+package service
+
+import (
+	"context"
+
+	"iycds2025_api/src/api/core/entities"
+	"iycds2025_api/src/api/core/errors"
+	"iycds2025_api/src/api/core/interfaces"
+)
+
+type GetProviderProfile interface {
+	Execute(ctx context.Context, providerID int64, viewerID int64) (*entities.ProviderProfileResponse, error)
+}
+
+type GetProviderProfileImpl struct {
+	User        interfaces.User
+	Service     interfaces.Service
+	Appointment interfaces.Appointment
+	Review      interfaces.Review
+}
+
+// Execute arma el perfil público de un proveedor. viewerID es el usuario que lo consulta, 0 si no
+// inició sesión. El perfil es visible mientras el proveedor tenga servicios activos; el propio
+// proveedor y sus clientes con una reserva aceptada lo ven siempre, junto con el email y el teléfono.
+func (uc *GetProviderProfileImpl) Execute(ctx context.Context, providerID int64, viewerID int64) (*entities.ProviderProfileResponse, error) {
+	provider, err := uc.User.GetByID(ctx, providerID)
+	if err != nil {
+		return nil, errors.NewInternalServerError("Failed to get provider: " + err.Error())
+	}
+	if provider == nil || provider.IsDeleted() || provider.IsSuspended() {
+		return nil, errors.NewNotFound("Provider not found")
+	}
+
+	services, err := uc.Service.GetByUserID(ctx, providerID)
+	if err != nil {
+		return nil, errors.NewInternalServerError("Failed to get services: " + err.Error())
+	}
+
+	activeServices := []*entities.ServiceResponse{}
+	for _, service := range services {
+		if service.Status != "active" {
+			continue
+		}
+		response, err := toServiceResponse(service)
+		if err != nil {
+			return nil, err
+		}
+		activeServices = append(activeServices, response)
+	}
+
+	isSelf := viewerID == providerID
+	hasBooking := false
+	if viewerID != 0 && !isSelf {
+		hasBooking, err = uc.Appointment.HasAcceptedBooking(ctx, viewerID, providerID)
+		if err != nil {
+			return nil, errors.NewInternalServerError("Failed to check bookings: " + err.Error())
+		}
+	}
+
+	// Un usuario sin servicios publicados no es un proveedor: no se expone su perfil
+	if len(activeServices) == 0 && !isSelf && !hasBooking {
+		return nil, errors.NewNotFound("Provider not found")
+	}
+
+	ratingAverage, reviewCount, err := uc.Review.GetProviderRating(ctx, providerID)
+	if err != nil {
+		return nil, errors.NewInternalServerError("Failed to get rating: " + err.Error())
+	}
+
+	completed, err := uc.Appointment.CountCompletedByProvider(ctx, providerID)
+	if err != nil {
+		return nil, errors.NewInternalServerError("Failed to count appointments: " + err.Error())
+	}
+
+	profile := &entities.ProviderProfileResponse{
+		ID:                    provider.ID,
+		DisplayName:           provider.PublicName(),
+		Bio:                   provider.Bio,
+		AvatarURL:             provider.AvatarURL,
+		Locality:              provider.Locality,
+		Province:              provider.Province,
+		MemberSince:           provider.CreatedAt,
+		Services:              activeServices,
+		RatingAverage:         ratingAverage,
+		ReviewCount:           reviewCount,
+		CompletedAppointments: completed,
+	}
+
+	if isSelf || hasBooking {
+		profile.Contact = &entities.ProviderContact{
+			Email: provider.Email,
+			Phone: provider.Phone,
+		}
+	}
+
+	return profile, nil
+}
@@ -2,7 +2,6 @@ package service
 
 import (
 	"context"
-	"encoding/json"
 
 	"iycds2025_api/src/api/core/entities"
 	"iycds2025_api/src/api/core/errors"
@@ -14,7 +13,9 @@ type GetServiceByID interface {
 }
 
 type GetServiceByIDImpl struct {
-	Service interfaces.Service
+	Service      interfaces.Service
+	User         interfaces.User
+	ServiceImage interfaces.ServiceImage
 }
 
 func (uc *GetServiceByIDImpl) Execute(ctx context.Context, id int64) (*entities.ServiceResponse, error) {
@@ -33,33 +34,29 @@ func (uc *GetServiceByIDImpl) Execute(ctx context.Context, id int64) (*entities.
 	}
 
 	// Convertir a response
-	return uc.toServiceResponse(service)
-}
+	response, err := toServiceResponse(service)
+	if err != nil {
+		return nil, err
+	}
 
-func (uc *GetServiceByIDImpl) toServiceResponse(service *entities.Service) (*entities.ServiceResponse, error) {
-	// Parsear availability JSON
-	var availability map[string]interface{}
-	if err := json.Unmarshal([]byte(service.Availability), &availability); err != nil {
-		return nil, errors.NewInternalServerError("Failed to parse availability")
+	// Quién ofrece el servicio, para enlazar a su perfil público
+	provider, err := uc.User.GetByID(ctx, service.UserID)
+	if err != nil {
+		return nil, errors.NewInternalServerError("Failed to get provider: " + err.Error())
+	}
+	if provider != nil && !provider.IsDeleted() {
+		response.Provider = &entities.ProviderSummary{
+			ID:          provider.ID,
+			DisplayName: provider.PublicName(),
+			AvatarURL:   provider.AvatarURL,
+		}
 	}
 
-	// Parsear zones JSON
-	var zones []entities.Zone
-	if err := json.Unmarshal([]byte(service.Zones), &zones); err != nil {
-		return nil, errors.NewInternalServerError("Failed to parse zones")
+	// Fotos en orden, con la URL de la miniatura para la galería
+	response.Images, err = uc.ServiceImage.GetByServiceID(ctx, service.ID)
+	if err != nil {
+		return nil, errors.NewInternalServerError("Failed to get service images: " + err.Error())
 	}
 
-	return &entities.ServiceResponse{
-		ID:           service.ID,
-		Title:        service.Title,
-		Description:  service.Description,
-		Category:     service.Category,
-		Price:        service.Price,
-		Availability: availability,
-		Zones:        zones,
-		Status:       service.Status,
-		ImageURL:     service.ImageURL,
-		CreatedAt:    service.CreatedAt,
-		UpdatedAt:    service.UpdatedAt,
-	}, nil
+	return response, nil
 }
@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"iycds2025_api/src/api/core/entities"
@@ -10,24 +11,40 @@ import (
 	"iycds2025_api/src/api/utils"
 )
 
+const (
+	// DefaultCalendarDays es la cantidad de días que se devuelven si no se indica otra
+	DefaultCalendarDays = 30
+	// MaxCalendarDays es la cantidad máxima de días que se pueden pedir en una consulta
+	MaxCalendarDays = 90
+)
+
 type GetServiceCalendarUseCase struct {
-	serviceRepository    interfaces.Service
-	appointmentRepository interfaces.Appointment
+	serviceRepository          interfaces.Service
+	appointmentRepository      interfaces.Appointment
+	serviceExceptionRepository interfaces.ServiceException
 }
 
-func NewGetServiceCalendarUseCase(serviceRepo interfaces.Service, appointmentRepo interfaces.Appointment) *GetServiceCalendarUseCase {
+func NewGetServiceCalendarUseCase(serviceRepo interfaces.Service, appointmentRepo interfaces.Appointment, exceptionRepo interfaces.ServiceException) *GetServiceCalendarUseCase {
 	return &GetServiceCalendarUseCase{
-		serviceRepository:     serviceRepo,
-		appointmentRepository: appointmentRepo,
+		serviceRepository:          serviceRepo,
+		appointmentRepository:      appointmentRepo,
+		serviceExceptionRepository: exceptionRepo,
 	}
 }
 
-func (uc *GetServiceCalendarUseCase) Execute(serviceID int) (*entities.CalendarResponse, *errors.APIError) {
-	ctx := context.Background()
-	
+// Execute construye el calendario de un servicio desde startDate (YYYY-MM-DD, hoy si está vacío)
+// durante la cantidad de días indicada, contando las citas activas de cada día
+func (uc *GetServiceCalendarUseCase) Execute(ctx context.Context, serviceID int, startDate string, days int) (*entities.CalendarResponse, *errors.APIError) {
+	if days < 1 || days > MaxCalendarDays {
+		return nil, errors.NewBadRequest(fmt.Sprintf("Days must be between 1 and %d", MaxCalendarDays))
+	}
+
 	// Verificar que el servicio existe y está activo
 	service, err := uc.serviceRepository.GetByID(ctx, int64(serviceID))
 	if err != nil {
+		return nil, errors.NewInternalServerError("Failed to get service: " + err.Error())
+	}
+	if service == nil {
 		return nil, errors.NewNotFound("Service not found")
 	}
 
@@ -35,64 +52,83 @@ func (uc *GetServiceCalendarUseCase) Execute(serviceID int) (*entities.CalendarR
 		return nil, errors.NewBadRequest("Service is not active")
 	}
 
-	// Generar los próximos 30 días desde hoy
-	now := time.Now()
-	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
-	
+	// "Hoy" se calcula en la zona horaria del servicio, no en la del servidor
+	loc := utils.LoadLocation(service.Timezone)
+	now := time.Now().In(loc)
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, loc)
+
+	start := today
+	if startDate != "" {
+		start, err = time.ParseInLocation("2006-01-02", startDate, loc)
+		if err != nil {
+			return nil, errors.NewBadRequest("Invalid start date format. Use YYYY-MM-DD")
+		}
+		if start.Before(today) {
+			return nil, errors.NewBadRequest("Start date cannot be in the past")
+		}
+	}
+	end := start.AddDate(0, 0, days-1)
+
+	// Obtener todas las citas activas del rango con una sola consulta
+	appointments, err := uc.appointmentRepository.GetByServiceIDAndDateRange(ctx, int64(serviceID),
+		start.Format("2006-01-02"), end.Format("2006-01-02"))
+	if err != nil {
+		return nil, errors.NewInternalServerError("Failed to get appointments: " + err.Error())
+	}
+
+	// Agrupar los horarios ocupados por fecha
+	occupiedByDate := make(map[string][]string)
+	for _, appointment := range appointments {
+		occupiedByDate[appointment.Date] = append(occupiedByDate[appointment.Date], appointment.TimeSlot)
+	}
+
+	// Obtener las excepciones de agenda del rango con una sola consulta
+	exceptions, err := uc.serviceExceptionRepository.GetByServiceIDAndDateRange(ctx, int64(serviceID),
+		start.Format("2006-01-02"), end.Format("2006-01-02"))
+	if err != nil {
+		return nil, errors.NewInternalServerError("Failed to get service exceptions: " + err.Error())
+	}
+
+	availability, err := utils.ParseAvailability(service.Availability)
+	if err != nil {
+		return nil, errors.NewInternalServerError("Failed to parse service availability: " + err.Error())
+	}
+
 	var calendarDays []entities.CalendarDay
-	
-	for i := 0; i < 30; i++ {
-		currentDate := today.AddDate(0, 0, i)
+
+	for i := 0; i < days; i++ {
+		currentDate := start.AddDate(0, 0, i)
 		dateStr := currentDate.Format("2006-01-02")
 		dayOfWeek := utils.GetDayOfWeekInSpanish(currentDate.Weekday())
 
-		// Obtener disponibilidad del día desde el JSON
-		dayName, dayErr := utils.GetDayOfWeek(dateStr)
-		if dayErr != nil {
-			calendarDays = append(calendarDays, entities.CalendarDay{
-				Date:            dateStr,
-				DayOfWeek:       dayOfWeek,
-				HasAvailability: false,
-				AvailableSlots:  0,
-				TotalSlots:      0,
-			})
-			continue
-		}
-
-		dayAvailability, availErr := utils.GetDayAvailabilityFromJSON(service.Availability, dayName)
-		if availErr != nil || !dayAvailability.Available {
-			calendarDays = append(calendarDays, entities.CalendarDay{
-				Date:            dateStr,
-				DayOfWeek:       dayOfWeek,
-				HasAvailability: false,
-				AvailableSlots:  0,
-				TotalSlots:      0,
-			})
-			continue
+		emptyDay := entities.CalendarDay{
+			Date:            dateStr,
+			DayOfWeek:       dayOfWeek,
+			HasAvailability: false,
+			AvailableSlots:  0,
+			TotalSlots:      0,
 		}
 
-		// Generar time slots para este día
-		timeSlots, slotsErr := utils.GenerateTimeSlots(dayAvailability.Start, dayAvailability.End)
-		if slotsErr != nil {
-			calendarDays = append(calendarDays, entities.CalendarDay{
-				Date:            dateStr,
-				DayOfWeek:       dayOfWeek,
-				HasAvailability: false,
-				AvailableSlots:  0,
-				TotalSlots:      0,
-			})
+		// Generar time slots para este día según la disponibilidad y las excepciones
+		timeSlots, slotsErr := utils.GenerateDateSlots(availability, exceptions, dateStr)
+		if slotsErr != nil || len(timeSlots) == 0 {
+			calendarDays = append(calendarDays, emptyDay)
 			continue
 		}
 
-		// Por ahora asumimos que todos los slots están disponibles
-		// En el futuro podríamos verificar appointments existentes aquí
+		// Descontar los slots que se superponen con una cita activa o que ya comenzaron
 		totalSlots := len(timeSlots)
-		availableSlots := totalSlots
+		availableSlots := 0
+		for _, slot := range timeSlots {
+			if !utils.IsSlotOccupied(slot, occupiedByDate[dateStr]) && !utils.HasSlotStarted(dateStr, slot, loc) {
+				availableSlots++
+			}
+		}
 
 		calendarDays = append(calendarDays, entities.CalendarDay{
 			Date:            dateStr,
 			DayOfWeek:       dayOfWeek,
-			HasAvailability: totalSlots > 0,
+			HasAvailability: availableSlots > 0,
 			AvailableSlots:  availableSlots,
 			TotalSlots:      totalSlots,
 		})
@@ -101,8 +137,9 @@ func (uc *GetServiceCalendarUseCase) Execute(serviceID int) (*entities.CalendarR
 	response := &entities.CalendarResponse{
 		ServiceID:    serviceID,
 		ServiceTitle: service.Title,
-		StartDate:    today.Format("2006-01-02"),
-		EndDate:      today.AddDate(0, 0, 29).Format("2006-01-02"),
+		Timezone:     loc.String(),
+		StartDate:    start.Format("2006-01-02"),
+		EndDate:      end.Format("2006-01-02"),
 		Days:         calendarDays,
 	}
 
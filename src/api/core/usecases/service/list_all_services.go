@@ -2,24 +2,42 @@ package service
 
 import (
 	"context"
-	"encoding/json"
+	"fmt"
+	"strings"
 
 	"iycds2025_api/src/api/core/entities"
 	"iycds2025_api/src/api/core/errors"
 	"iycds2025_api/src/api/core/interfaces"
+	"iycds2025_api/src/api/utils"
 )
 
+const (
+	// DefaultPageSize es la cantidad de servicios por página si no se indica otra
+	DefaultPageSize = 20
+	// MaxPageSize es la cantidad máxima de servicios que se pueden pedir por página
+	MaxPageSize = 100
+	// maxSearchQueryLength limita el largo del texto libre de búsqueda
+	maxSearchQueryLength = 100
+)
+
+// validServiceSorts son los órdenes admitidos en el catálogo
+var validServiceSorts = []string{"newest", "oldest", "price_asc", "price_desc", "title"}
+
 type ListAllServices interface {
-	Execute(ctx context.Context) (*entities.ServiceListResponse, error)
+	Execute(ctx context.Context, filter *entities.ServiceFilter) (*entities.ServiceListResponse, error)
 }
 
 type ListAllServicesImpl struct {
 	Service interfaces.Service
 }
 
-func (uc *ListAllServicesImpl) Execute(ctx context.Context) (*entities.ServiceListResponse, error) {
-	// Obtener todos los servicios activos
-	services, err := uc.Service.GetAllActive(ctx)
+func (uc *ListAllServicesImpl) Execute(ctx context.Context, filter *entities.ServiceFilter) (*entities.ServiceListResponse, error) {
+	if err := normalizeServiceFilter(filter); err != nil {
+		return nil, err
+	}
+
+	// Obtener la página de servicios activos que cumplen los filtros
+	services, total, err := uc.Service.Search(ctx, filter)
 	if err != nil {
 		return nil, errors.NewInternalServerError("Failed to get services: " + err.Error())
 	}
@@ -27,7 +45,7 @@ func (uc *ListAllServicesImpl) Execute(ctx context.Context) (*entities.ServiceLi
 	// Convertir a response
 	serviceResponses := make([]entities.ServiceResponse, len(services))
 	for i, service := range services {
-		response, err := uc.toServiceResponse(service)
+		response, err := toServiceResponse(service)
 		if err != nil {
 			return nil, err
 		}
@@ -35,35 +53,66 @@ func (uc *ListAllServicesImpl) Execute(ctx context.Context) (*entities.ServiceLi
 	}
 
 	return &entities.ServiceListResponse{
-		Services: serviceResponses,
-		Total:    len(serviceResponses),
+		Services:   serviceResponses,
+		Total:      total,
+		Page:       filter.Page,
+		PageSize:   filter.PageSize,
+		TotalPages: (total + filter.PageSize - 1) / filter.PageSize,
 	}, nil
 }
 
-func (uc *ListAllServicesImpl) toServiceResponse(service *entities.Service) (*entities.ServiceResponse, error) {
-	// Parsear availability JSON
-	var availability map[string]interface{}
-	if err := json.Unmarshal([]byte(service.Availability), &availability); err != nil {
-		return nil, errors.NewInternalServerError("Failed to parse availability")
+// normalizeServiceFilter valida el filtro del catálogo y completa los valores por defecto
+func normalizeServiceFilter(filter *entities.ServiceFilter) error {
+	if filter.Category != "" {
+		normalizedCategory, ok := utils.NormalizeCategory(filter.Category)
+		if !ok {
+			return errors.NewBadRequest("Invalid category. Valid categories are: " + strings.Join(utils.GetValidCategories(), ", "))
+		}
+		filter.Category = normalizedCategory
 	}
 
-	// Parsear zones JSON
-	var zones []entities.Zone
-	if err := json.Unmarshal([]byte(service.Zones), &zones); err != nil {
-		return nil, errors.NewInternalServerError("Failed to parse zones")
+	filter.Province = strings.TrimSpace(filter.Province)
+	filter.Locality = strings.TrimSpace(filter.Locality)
+	filter.Query = strings.TrimSpace(filter.Query)
+	if len(filter.Query) > maxSearchQueryLength {
+		return errors.NewBadRequest(fmt.Sprintf("Search query cannot exceed %d characters", maxSearchQueryLength))
 	}
 
-	return &entities.ServiceResponse{
-		ID:           service.ID,
-		Title:        service.Title,
-		Description:  service.Description,
-		Category:     service.Category,
-		Price:        service.Price,
-		Availability: availability,
-		Zones:        zones,
-		Status:       service.Status,
-		ImageURL:     service.ImageURL,
-		CreatedAt:    service.CreatedAt,
-		UpdatedAt:    service.UpdatedAt,
-	}, nil
+	if filter.MinPrice != nil && *filter.MinPrice < 0 {
+		return errors.NewBadRequest("min_price cannot be negative")
+	}
+	if filter.MinPrice != nil && filter.MaxPrice != nil && *filter.MaxPrice < *filter.MinPrice {
+		return errors.NewBadRequest("max_price cannot be lower than min_price")
+	}
+
+	if filter.Sort == "" {
+		filter.Sort = "newest"
+	}
+	if !isValidServiceSort(filter.Sort) {
+		return errors.NewBadRequest("Invalid sort. Valid values are: " + strings.Join(validServiceSorts, ", "))
+	}
+
+	if filter.Page == 0 {
+		filter.Page = 1
+	}
+	if filter.Page < 1 {
+		return errors.NewBadRequest("Page must be greater than 0")
+	}
+	if filter.PageSize == 0 {
+		filter.PageSize = DefaultPageSize
+	}
+	if filter.PageSize < 1 || filter.PageSize > MaxPageSize {
+		return errors.NewBadRequest(fmt.Sprintf("Page size must be between 1 and %d", MaxPageSize))
+	}
+
+	return nil
+}
+
+func isValidServiceSort(sort string) bool {
+	for _, valid := range validServiceSorts {
+		if sort == valid {
+			return true
+		}
+	}
+	return false
 }
@@ -0,0 +1,95 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"iycds2025_api/src/api/core/entities"
+	"iycds2025_api/src/api/core/errors"
+	"iycds2025_api/src/api/core/interfaces"
+	"iycds2025_api/src/api/utils"
+)
+
+const (
+	// maxSearchTerms limita la cantidad de términos que se buscan a la vez
+	maxSearchTerms = 10
+	// snippetLength es el largo aproximado, en caracteres, del fragmento resaltado de la descripción
+	snippetLength = 160
+)
+
+type SearchServices interface {
+	Execute(ctx context.Context, text string, page int, pageSize int) (*entities.ServiceSearchResponse, error)
+}
+
+type SearchServicesImpl struct {
+	ServiceSearch interfaces.ServiceSearch
+}
+
+func (uc *SearchServicesImpl) Execute(ctx context.Context, text string, page int, pageSize int) (*entities.ServiceSearchResponse, error) {
+	if len(text) > maxSearchQueryLength {
+		return nil, errors.NewBadRequest(fmt.Sprintf("Search query cannot exceed %d characters", maxSearchQueryLength))
+	}
+
+	// Tokenizar igual que al indexar: minúsculas, sin acentos ni stopwords
+	terms := utils.UniqueTokens(text)
+	if len(terms) == 0 {
+		return nil, errors.NewBadRequest(fmt.Sprintf("Search query must contain at least one word of %d or more letters", utils.MinTokenLength))
+	}
+	if len(terms) > maxSearchTerms {
+		terms = terms[:maxSearchTerms]
+	}
+
+	if page == 0 {
+		page = 1
+	}
+	if page < 1 {
+		return nil, errors.NewBadRequest("Page must be greater than 0")
+	}
+	if pageSize == 0 {
+		pageSize = DefaultPageSize
+	}
+	if pageSize < 1 || pageSize > MaxPageSize {
+		return nil, errors.NewBadRequest(fmt.Sprintf("Page size must be between 1 and %d", MaxPageSize))
+	}
+
+	hits, total, err := uc.ServiceSearch.Search(ctx, &entities.ServiceSearchQuery{
+		Terms:    terms,
+		Page:     page,
+		PageSize: pageSize,
+	})
+	if err != nil {
+		return nil, errors.NewInternalServerError("Failed to search services: " + err.Error())
+	}
+
+	// Convertir a response resaltando los términos encontrados
+	results := make([]entities.ServiceSearchResult, len(hits))
+	for i, hit := range hits {
+		response, err := toServiceResponse(hit.Service)
+		if err != nil {
+			return nil, err
+		}
+		results[i] = entities.ServiceSearchResult{
+			Service:          *response,
+			Score:            hit.Score,
+			TitleHighlight:   utils.HighlightSnippet(hit.Service.Title, terms, 0),
+			SnippetHighlight: utils.HighlightSnippet(hit.Service.Description, terms, snippetLength),
+		}
+	}
+
+	return &entities.ServiceSearchResponse{
+		Query:      text,
+		Results:    results,
+		Total:      total,
+		Page:       page,
+		PageSize:   pageSize,
+		TotalPages: (total + pageSize - 1) / pageSize,
+	}, nil
+}
+
+// indexService actualiza el índice de búsqueda de un servicio. Un error no invalida la
+// operación principal: el servicio ya se guardó y se vuelve a indexar en el próximo cambio.
+func indexService(ctx context.Context, search interfaces.ServiceSearch, service *entities.Service) {
+	if err := search.Index(ctx, service); err != nil {
+		fmt.Printf("Error indexing service %d for search: %v\n", service.ID, err)
+	}
+}
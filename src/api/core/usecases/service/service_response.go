@@ -0,0 +1,43 @@
+package service
+
+import (
+	"encoding/json"
+
+	"iycds2025_api/src/api/core/entities"
+	"iycds2025_api/src/api/core/errors"
+	"iycds2025_api/src/api/utils"
+)
+
+// toServiceResponse convierte un servicio a su representación de respuesta,
+// parseando la disponibilidad y las zonas guardadas como JSON
+func toServiceResponse(service *entities.Service) (*entities.ServiceResponse, error) {
+	// Parsear availability JSON
+	availability, err := utils.ParseAvailability(service.Availability)
+	if err != nil {
+		return nil, errors.NewInternalServerError("Failed to parse availability")
+	}
+
+	// Parsear zones JSON
+	var zones []entities.Zone
+	if err := json.Unmarshal([]byte(service.Zones), &zones); err != nil {
+		return nil, errors.NewInternalServerError("Failed to parse zones")
+	}
+
+	return &entities.ServiceResponse{
+		ID:            service.ID,
+		Title:         service.Title,
+		Description:   service.Description,
+		Category:      service.Category,
+		Price:         service.Price,
+		Availability:  availability,
+		Timezone:      service.Timezone,
+		Zones:         zones,
+		Status:        service.Status,
+		ImageURL:      service.ImageURL,
+		RatingAverage: service.RatingAverage,
+		ReviewCount:   service.ReviewCount,
+		CreatedAt:     service.CreatedAt,
+		UpdatedAt:     service.UpdatedAt,
+		ProviderID:    service.UserID,
+	}, nil
+}
@@ -2,25 +2,26 @@ package service
 
 import (
 	"context"
-	"encoding/json"
 	"strings"
 
 	"iycds2025_api/src/api/core/entities"
 	"iycds2025_api/src/api/core/errors"
 	"iycds2025_api/src/api/core/interfaces"
+	"iycds2025_api/src/api/core/policy"
 	"iycds2025_api/src/api/utils"
 )
 
 type UpdateService interface {
-	Execute(ctx context.Context, id int64, serviceReq *entities.ServiceUpdate, userID int64) (*entities.ServiceResponse, error)
+	Execute(ctx context.Context, id int64, serviceReq *entities.ServiceUpdate, actor policy.Actor) (*entities.ServiceResponse, error)
 }
 
 type UpdateServiceImpl struct {
-	Service interfaces.Service
+	Service       interfaces.Service
+	ServiceSearch interfaces.ServiceSearch
 }
 
-func (uc *UpdateServiceImpl) Execute(ctx context.Context, id int64, serviceReq *entities.ServiceUpdate, userID int64) (*entities.ServiceResponse, error) {
-	// Verificar que el servicio existe y pertenece al usuario
+func (uc *UpdateServiceImpl) Execute(ctx context.Context, id int64, serviceReq *entities.ServiceUpdate, actor policy.Actor) (*entities.ServiceResponse, error) {
+	// Verificar que el servicio existe y que el usuario puede modificarlo (dueño o moderador)
 	existing, err := uc.Service.GetByID(ctx, id)
 	if err != nil {
 		return nil, errors.NewInternalServerError("Failed to get service: " + err.Error())
@@ -28,8 +29,8 @@ func (uc *UpdateServiceImpl) Execute(ctx context.Context, id int64, serviceReq *
 	if existing == nil {
 		return nil, errors.NewNotFound("Service not found")
 	}
-	if existing.UserID != userID {
-		return nil, errors.NewUnauthorized("You don't have permission to update this service")
+	if !policy.CanManageService(actor, existing) {
+		return nil, errors.NewForbidden("You don't have permission to update this service")
 	}
 
 	// Validar categoría si se está actualizando
@@ -41,40 +42,29 @@ func (uc *UpdateServiceImpl) Execute(ctx context.Context, id int64, serviceReq *
 		serviceReq.Category = normalizedCategory
 	}
 
-	// Actualizar el servicio
-	service, err := uc.Service.Update(ctx, id, serviceReq, userID)
-	if err != nil {
-		return nil, errors.NewInternalServerError("Failed to update service: " + err.Error())
+	// Validar la disponibilidad si se está actualizando
+	if serviceReq.Availability != nil {
+		if err := utils.ValidateAvailability(serviceReq.Availability); err != nil {
+			return nil, errors.NewBadRequest("Invalid availability: " + err.Error())
+		}
 	}
 
-	// Convertir a response
-	return uc.toServiceResponse(service)
-}
-
-func (uc *UpdateServiceImpl) toServiceResponse(service *entities.Service) (*entities.ServiceResponse, error) {
-	// Parsear availability JSON
-	var availability map[string]interface{}
-	if err := json.Unmarshal([]byte(service.Availability), &availability); err != nil {
-		return nil, errors.NewInternalServerError("Failed to parse availability")
+	// Validar la zona horaria si se está actualizando
+	if serviceReq.Timezone != "" {
+		if err := utils.ValidateTimezone(serviceReq.Timezone); err != nil {
+			return nil, errors.NewBadRequest("Invalid timezone: " + err.Error())
+		}
 	}
 
-	// Parsear zones JSON
-	var zones []entities.Zone
-	if err := json.Unmarshal([]byte(service.Zones), &zones); err != nil {
-		return nil, errors.NewInternalServerError("Failed to parse zones")
+	// Actualizar el servicio (a nombre del dueño, aunque actúe un moderador)
+	service, err := uc.Service.Update(ctx, id, serviceReq, existing.UserID)
+	if err != nil {
+		return nil, errors.NewInternalServerError("Failed to update service: " + err.Error())
 	}
 
-	return &entities.ServiceResponse{
-		ID:           service.ID,
-		Title:        service.Title,
-		Description:  service.Description,
-		Category:     service.Category,
-		Price:        service.Price,
-		Availability: availability,
-		Zones:        zones,
-		Status:       service.Status,
-		ImageURL:     service.ImageURL,
-		CreatedAt:    service.CreatedAt,
-		UpdatedAt:    service.UpdatedAt,
-	}, nil
+	// Reindexar el servicio para la búsqueda de texto libre
+	indexService(ctx, uc.ServiceSearch, service)
+
+	// Convertir a response
+	return toServiceResponse(service)
 }
@@ -5,17 +5,19 @@ import (
 
 	"iycds2025_api/src/api/core/errors"
 	"iycds2025_api/src/api/core/interfaces"
+	"iycds2025_api/src/api/core/policy"
 )
 
 type UpdateServiceStatus interface {
-	Execute(ctx context.Context, id int64, status string, userID int64) error
+	Execute(ctx context.Context, id int64, status string, actor policy.Actor) error
 }
 
 type UpdateServiceStatusImpl struct {
-	Service interfaces.Service
+	Service       interfaces.Service
+	ServiceSearch interfaces.ServiceSearch
 }
 
-func (uc *UpdateServiceStatusImpl) Execute(ctx context.Context, id int64, status string, userID int64) error {
+func (uc *UpdateServiceStatusImpl) Execute(ctx context.Context, id int64, status string, actor policy.Actor) error {
 	// Validar estados permitidos
 	validStatuses := map[string]bool{
 		"active":   true,
@@ -25,7 +27,7 @@ func (uc *UpdateServiceStatusImpl) Execute(ctx context.Context, id int64, status
 		return errors.NewBadRequest("Invalid status. Allowed values: active, inactive")
 	}
 
-	// Verificar que el servicio existe y pertenece al usuario
+	// Verificar que el servicio existe y que el usuario puede modificarlo (dueño o moderador)
 	existing, err := uc.Service.GetByID(ctx, id)
 	if err != nil {
 		return errors.NewInternalServerError("Failed to get service: " + err.Error())
@@ -33,8 +35,8 @@ func (uc *UpdateServiceStatusImpl) Execute(ctx context.Context, id int64, status
 	if existing == nil {
 		return errors.NewNotFound("Service not found")
 	}
-	if existing.UserID != userID {
-		return errors.NewUnauthorized("You don't have permission to update this service")
+	if !policy.CanManageService(actor, existing) {
+		return errors.NewForbidden("You don't have permission to update this service")
 	}
 
 	// Verificar si el estado ya es el mismo
@@ -42,11 +44,15 @@ func (uc *UpdateServiceStatusImpl) Execute(ctx context.Context, id int64, status
 		return errors.NewBadRequest("Service is already " + status)
 	}
 
-	// Actualizar el estado del servicio
-	err = uc.Service.UpdateStatus(ctx, id, status, userID)
+	// Actualizar el estado del servicio (a nombre del dueño, aunque actúe un moderador)
+	err = uc.Service.UpdateStatus(ctx, id, status, existing.UserID)
 	if err != nil {
 		return errors.NewInternalServerError("Failed to update service status: " + err.Error())
 	}
 
+	// Reindexar con el nuevo estado: solo los servicios activos aparecen en la búsqueda
+	existing.Status = status
+	indexService(ctx, uc.ServiceSearch, existing)
+
 	return nil
 }
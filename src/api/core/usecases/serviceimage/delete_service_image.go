@@ -0,0 +1,49 @@
+package serviceimage
+
+import (
+	"context"
+	"database/sql"
+
+	"iycds2025_api/src/api/core/entities"
+	"iycds2025_api/src/api/core/errors"
+	"iycds2025_api/src/api/core/interfaces"
+	"iycds2025_api/src/api/core/policy"
+	"iycds2025_api/src/api/services/storage"
+)
+
+type DeleteServiceImage interface {
+	Execute(ctx context.Context, serviceID int64, imageID int64, actor policy.Actor) error
+}
+
+type DeleteServiceImageImpl struct {
+	Service      interfaces.Service
+	ServiceImage interfaces.ServiceImage
+	BlobStore    storage.BlobStore
+}
+
+func (uc *DeleteServiceImageImpl) Execute(ctx context.Context, serviceID int64, imageID int64, actor policy.Actor) error {
+	if _, err := getOwnedService(ctx, uc.Service, serviceID, actor); err != nil {
+		return err
+	}
+
+	// Verificar que la foto pertenece al servicio
+	existing, err := uc.ServiceImage.GetByID(ctx, imageID)
+	if err != nil {
+		return errors.NewInternalServerError("Failed to get service image: " + err.Error())
+	}
+	if existing == nil || existing.ServiceID != serviceID {
+		return errors.NewNotFound("Service image not found")
+	}
+
+	if err := uc.ServiceImage.Delete(ctx, imageID); err != nil {
+		if err == sql.ErrNoRows {
+			return errors.NewNotFound("Service image not found")
+		}
+		return errors.NewInternalServerError("Failed to delete service image: " + err.Error())
+	}
+
+	// Los archivos se borran después de la fila para no dejar una foto listada sin archivo
+	DeleteFiles(ctx, uc.BlobStore, []*entities.ServiceImage{existing})
+
+	return nil
+}
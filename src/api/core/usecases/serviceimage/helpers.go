@@ -0,0 +1,61 @@
+package serviceimage
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"iycds2025_api/src/api/core/entities"
+	"iycds2025_api/src/api/core/errors"
+	"iycds2025_api/src/api/core/interfaces"
+	"iycds2025_api/src/api/core/policy"
+	"iycds2025_api/src/api/services/storage"
+)
+
+// getOwnedService obtiene el servicio y verifica que el usuario puede administrar sus fotos (dueño o moderador)
+func getOwnedService(ctx context.Context, services interfaces.Service, serviceID int64, actor policy.Actor) (*entities.Service, error) {
+	service, err := services.GetByID(ctx, serviceID)
+	if err != nil {
+		return nil, errors.NewInternalServerError("Failed to get service: " + err.Error())
+	}
+	if service == nil {
+		return nil, errors.NewNotFound("Service not found")
+	}
+	if !policy.CanManageService(actor, service) {
+		return nil, errors.NewForbidden("You don't have permission to manage images for this service")
+	}
+
+	return service, nil
+}
+
+// newImageKeys genera las claves de la foto y de su miniatura. El nombre es aleatorio: las URLs no
+// se pueden adivinar y un archivo nunca se reemplaza, así que se puede cachear sin vencimiento.
+func newImageKeys(serviceID int64, extension string) (string, string, error) {
+	raw := make([]byte, 16)
+	if _, err := rand.Read(raw); err != nil {
+		return "", "", err
+	}
+	name := hex.EncodeToString(raw)
+
+	prefix := fmt.Sprintf("services/%d/%s", serviceID, name)
+	return prefix + extension, prefix + "_thumb" + extension, nil
+}
+
+// publicURL arma la URL con la que se accede a la clave desde afuera
+func publicURL(baseURL, key string) string {
+	return strings.TrimRight(baseURL, "/") + "/" + key
+}
+
+// DeleteFiles borra del almacenamiento la foto y la miniatura de cada imagen. Los errores solo se
+// registran: la fila ya no existe y un archivo huérfano no afecta a los usuarios.
+func DeleteFiles(ctx context.Context, blobStore storage.BlobStore, images []*entities.ServiceImage) {
+	for _, image := range images {
+		for _, key := range []string{image.ImageKey, image.ThumbnailKey} {
+			if err := blobStore.Delete(ctx, key); err != nil {
+				fmt.Printf("Error deleting blob %s: %v\n", key, err)
+			}
+		}
+	}
+}
@@ -0,0 +1,41 @@
+package serviceimage
+
+import (
+	"context"
+
+	"iycds2025_api/src/api/core/entities"
+	"iycds2025_api/src/api/core/errors"
+	"iycds2025_api/src/api/core/interfaces"
+	"iycds2025_api/src/api/core/policy"
+)
+
+type ListServiceImages interface {
+	Execute(ctx context.Context, serviceID int64, actor policy.Actor) ([]*entities.ServiceImage, error)
+}
+
+type ListServiceImagesImpl struct {
+	Service      interfaces.Service
+	ServiceImage interfaces.ServiceImage
+}
+
+// Execute lista las fotos en orden. Las de un servicio pausado solo las ve quien puede administrarlo;
+// para el resto el servicio no existe, igual que en el detalle.
+func (uc *ListServiceImagesImpl) Execute(ctx context.Context, serviceID int64, actor policy.Actor) ([]*entities.ServiceImage, error) {
+	service, err := uc.Service.GetByID(ctx, serviceID)
+	if err != nil {
+		return nil, errors.NewInternalServerError("Failed to get service: " + err.Error())
+	}
+	if service == nil || (service.Status != "active" && !policy.CanManageService(actor, service)) {
+		return nil, errors.NewNotFound("Service not found")
+	}
+
+	serviceImages, err := uc.ServiceImage.GetByServiceID(ctx, serviceID)
+	if err != nil {
+		return nil, errors.NewInternalServerError("Failed to get service images: " + err.Error())
+	}
+	if serviceImages == nil {
+		serviceImages = []*entities.ServiceImage{}
+	}
+
+	return serviceImages, nil
+}
@@ -0,0 +1,41 @@
+package serviceimage
+
+import (
+	"context"
+	"database/sql"
+
+	"iycds2025_api/src/api/core/entities"
+	"iycds2025_api/src/api/core/errors"
+	"iycds2025_api/src/api/core/interfaces"
+	"iycds2025_api/src/api/core/policy"
+)
+
+type ReorderServiceImages interface {
+	Execute(ctx context.Context, serviceID int64, request *entities.ServiceImageOrderRequest, actor policy.Actor) ([]*entities.ServiceImage, error)
+}
+
+type ReorderServiceImagesImpl struct {
+	Service      interfaces.Service
+	ServiceImage interfaces.ServiceImage
+}
+
+// Execute cambia el orden de las fotos; la primera de la lista pasa a ser la portada del servicio
+func (uc *ReorderServiceImagesImpl) Execute(ctx context.Context, serviceID int64, request *entities.ServiceImageOrderRequest, actor policy.Actor) ([]*entities.ServiceImage, error) {
+	if _, err := getOwnedService(ctx, uc.Service, serviceID, actor); err != nil {
+		return nil, err
+	}
+
+	if err := uc.ServiceImage.Reorder(ctx, serviceID, request.ImageIDs); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.NewBadRequest("image_ids must list every image of the service exactly once")
+		}
+		return nil, errors.NewInternalServerError("Failed to reorder service images: " + err.Error())
+	}
+
+	serviceImages, err := uc.ServiceImage.GetByServiceID(ctx, serviceID)
+	if err != nil {
+		return nil, errors.NewInternalServerError("Failed to get service images: " + err.Error())
+	}
+
+	return serviceImages, nil
+}
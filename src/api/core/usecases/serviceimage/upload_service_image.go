@@ -0,0 +1,93 @@
+package serviceimage
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+
+	"iycds2025_api/src/api/core/entities"
+	"iycds2025_api/src/api/core/errors"
+	"iycds2025_api/src/api/core/interfaces"
+	"iycds2025_api/src/api/core/policy"
+	"iycds2025_api/src/api/services/images"
+	"iycds2025_api/src/api/services/storage"
+)
+
+type UploadServiceImage interface {
+	Execute(ctx context.Context, serviceID int64, data []byte, actor policy.Actor) (*entities.ServiceImage, error)
+}
+
+type UploadServiceImageImpl struct {
+	Service      interfaces.Service
+	ServiceImage interfaces.ServiceImage
+	BlobStore    storage.BlobStore
+	// PublicURL es la URL base con la que se sirven los archivos del almacenamiento
+	PublicURL string
+}
+
+func (uc *UploadServiceImageImpl) Execute(ctx context.Context, serviceID int64, data []byte, actor policy.Actor) (*entities.ServiceImage, error) {
+	if _, err := getOwnedService(ctx, uc.Service, serviceID, actor); err != nil {
+		return nil, err
+	}
+
+	// Revisar el límite antes de procesar la imagen; el repositorio lo vuelve a verificar al guardarla
+	existing, err := uc.ServiceImage.GetByServiceID(ctx, serviceID)
+	if err != nil {
+		return nil, errors.NewInternalServerError("Failed to get service images: " + err.Error())
+	}
+	if len(existing) >= entities.MaxServiceImages {
+		return nil, errors.NewConflict(fmt.Sprintf("A service can have at most %d images", entities.MaxServiceImages))
+	}
+
+	processed, err := images.Process(data)
+	if err != nil {
+		switch err {
+		case images.ErrUnsupportedType:
+			return nil, errors.NewUnsupportedMediaType("Unsupported image type. Allowed types: JPEG and PNG")
+		case images.ErrInvalidImage:
+			return nil, errors.NewBadRequest("Invalid image file")
+		case images.ErrTooManyPixels:
+			return nil, errors.NewBadRequest(fmt.Sprintf("Image resolution is too large (maximum %d megapixels)", images.MaxPixels/1_000_000))
+		}
+		return nil, errors.NewInternalServerError("Failed to process image: " + err.Error())
+	}
+
+	imageKey, thumbnailKey, err := newImageKeys(serviceID, processed.Extension)
+	if err != nil {
+		return nil, errors.NewInternalServerError("Failed to generate image key")
+	}
+
+	image := &entities.ServiceImage{
+		ServiceID:       serviceID,
+		ImageKey:        imageKey,
+		ThumbnailKey:    thumbnailKey,
+		URL:             publicURL(uc.PublicURL, imageKey),
+		ThumbnailURL:    publicURL(uc.PublicURL, thumbnailKey),
+		ContentType:     processed.ContentType,
+		Width:           processed.Width,
+		Height:          processed.Height,
+		ThumbnailWidth:  processed.ThumbnailWidth,
+		ThumbnailHeight: processed.ThumbnailHeight,
+		SizeBytes:       len(processed.Data),
+	}
+
+	// Guardar los archivos antes que la fila: una foto listada siempre tiene sus archivos
+	if err := uc.BlobStore.Put(ctx, imageKey, processed.Data, processed.ContentType); err != nil {
+		return nil, errors.NewInternalServerError("Failed to store image: " + err.Error())
+	}
+	if err := uc.BlobStore.Put(ctx, thumbnailKey, processed.Thumbnail, processed.ContentType); err != nil {
+		DeleteFiles(ctx, uc.BlobStore, []*entities.ServiceImage{image})
+		return nil, errors.NewInternalServerError("Failed to store thumbnail: " + err.Error())
+	}
+
+	created, err := uc.ServiceImage.Create(ctx, image)
+	if err != nil {
+		DeleteFiles(ctx, uc.BlobStore, []*entities.ServiceImage{image})
+		if stderrors.Is(err, errors.ErrServiceImageLimit) {
+			return nil, errors.NewConflict(fmt.Sprintf("A service can have at most %d images", entities.MaxServiceImages))
+		}
+		return nil, errors.NewInternalServerError("Failed to save image: " + err.Error())
+	}
+
+	return created, nil
+}
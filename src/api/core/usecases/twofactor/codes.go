@@ -0,0 +1,53 @@
+package twofactor
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"iycds2025_api/src/api/core/entities"
+	"iycds2025_api/src/api/core/interfaces"
+	"iycds2025_api/src/api/utils"
+)
+
+// totpIssuer es el nombre con el que la cuenta aparece en la app de autenticación
+const totpIssuer = "IYCDS 2025"
+
+// recoveryCodeCount es la cantidad de códigos de recuperación que se entregan cada vez
+const recoveryCodeCount = 10
+
+// CheckCode verifica un código del segundo factor: un código TOTP de la app o, si no tiene ese
+// formato, uno de los códigos de recuperación. En ambos casos el código queda consumido y no se
+// puede volver a usar.
+func CheckCode(ctx context.Context, repo interfaces.TwoFactor, totp *entities.UserTOTP, code string) (bool, error) {
+	code = strings.TrimSpace(code)
+
+	if utils.IsTOTPCode(code) {
+		step, ok := utils.ValidateTOTP(totp.Secret, code, time.Now())
+		if !ok {
+			return false, nil
+		}
+		return repo.UseTOTPStep(ctx, totp.UserID, step)
+	}
+
+	normalized := utils.NormalizeRecoveryCode(code)
+	if normalized == "" {
+		return false, nil
+	}
+	return repo.UseRecoveryCode(ctx, totp.UserID, utils.HashToken(normalized))
+}
+
+// generateRecoveryCodes crea los códigos de recuperación para mostrar al usuario y sus hashes para guardar
+func generateRecoveryCodes() ([]string, []string, error) {
+	codes := make([]string, recoveryCodeCount)
+	hashes := make([]string, recoveryCodeCount)
+	for i := range codes {
+		code, err := utils.GenerateRecoveryCode()
+		if err != nil {
+			return nil, nil, err
+		}
+		codes[i] = code
+		hashes[i] = utils.HashToken(utils.NormalizeRecoveryCode(code))
+	}
+	return codes, hashes, nil
+}
@@ -0,0 +1,58 @@
+package twofactor
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"iycds2025_api/src/api/core/entities"
+	"iycds2025_api/src/api/core/errors"
+	"iycds2025_api/src/api/core/interfaces"
+	"iycds2025_api/src/api/utils"
+)
+
+type ConfirmTwoFactor interface {
+	Execute(ctx context.Context, userID int64, request *entities.TwoFactorCode) (*entities.RecoveryCodesResponse, error)
+}
+
+type ConfirmTwoFactorImpl struct {
+	TwoFactor interfaces.TwoFactor
+}
+
+// Execute activa el 2FA si el código corresponde al secreto pendiente, lo que demuestra que el
+// usuario lo registró bien en su app. Devuelve los códigos de recuperación, que no se vuelven a mostrar.
+func (uc *ConfirmTwoFactorImpl) Execute(ctx context.Context, userID int64, request *entities.TwoFactorCode) (*entities.RecoveryCodesResponse, error) {
+	totp, err := uc.TwoFactor.GetTOTP(ctx, userID)
+	if err != nil {
+		return nil, errors.NewInternalServerError("Failed to get two-factor settings: " + err.Error())
+	}
+	if totp == nil {
+		return nil, errors.NewBadRequest("Two-factor setup has not been started")
+	}
+	if totp.IsEnabled() {
+		return nil, errors.NewConflict("Two-factor authentication is already enabled")
+	}
+
+	// Para confirmar solo sirve un código de la app: todavía no hay códigos de recuperación
+	step, ok := utils.ValidateTOTP(totp.Secret, strings.TrimSpace(request.Code), time.Now())
+	if !ok {
+		return nil, errors.NewBadRequest("Invalid verification code")
+	}
+
+	codes, hashes, err := generateRecoveryCodes()
+	if err != nil {
+		return nil, errors.NewInternalServerError("Failed to generate recovery codes")
+	}
+
+	if err := uc.TwoFactor.EnableTOTP(ctx, userID, step, hashes); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.NewConflict("Two-factor authentication is already enabled")
+		}
+		return nil, errors.NewInternalServerError("Failed to enable two-factor authentication: " + err.Error())
+	}
+	fmt.Printf("Two-factor authentication enabled for user %d\n", userID)
+
+	return &entities.RecoveryCodesResponse{RecoveryCodes: codes}, nil
+}
@@ -0,0 +1,60 @@
+package twofactor
+
+import (
+	"context"
+	"fmt"
+
+	"iycds2025_api/src/api/core/entities"
+	"iycds2025_api/src/api/core/errors"
+	"iycds2025_api/src/api/core/interfaces"
+	"iycds2025_api/src/api/utils"
+)
+
+type DisableTwoFactor interface {
+	Execute(ctx context.Context, userID int64, request *entities.TwoFactorDisable) error
+}
+
+type DisableTwoFactorImpl struct {
+	User      interfaces.User
+	TwoFactor interfaces.TwoFactor
+}
+
+// Execute desactiva el 2FA. Se pide la contraseña (si la cuenta tiene) y un código (de la app o de recuperación) para que
+// un token de acceso robado no alcance para quitar el segundo factor.
+func (uc *DisableTwoFactorImpl) Execute(ctx context.Context, userID int64, request *entities.TwoFactorDisable) error {
+	user, err := uc.User.GetByID(ctx, userID)
+	if err != nil {
+		return errors.NewInternalServerError("Failed to get user: " + err.Error())
+	}
+	if user == nil {
+		return errors.NewNotFound("User not found")
+	}
+
+	// Las cuentas sin contraseña (creadas con un proveedor externo) solo confirman con el código
+	if user.HasPassword() && !utils.CheckPasswordHash(request.Password, user.Password) {
+		return errors.NewBadRequest("Password is incorrect")
+	}
+
+	totp, err := uc.TwoFactor.GetTOTP(ctx, userID)
+	if err != nil {
+		return errors.NewInternalServerError("Failed to get two-factor settings: " + err.Error())
+	}
+	if !totp.IsEnabled() {
+		return errors.NewBadRequest("Two-factor authentication is not enabled")
+	}
+
+	valid, err := CheckCode(ctx, uc.TwoFactor, totp, request.Code)
+	if err != nil {
+		return errors.NewInternalServerError("Failed to verify code: " + err.Error())
+	}
+	if !valid {
+		return errors.NewBadRequest("Invalid verification code")
+	}
+
+	if err := uc.TwoFactor.DisableTOTP(ctx, userID); err != nil {
+		return errors.NewInternalServerError("Failed to disable two-factor authentication: " + err.Error())
+	}
+	fmt.Printf("Two-factor authentication disabled for user %d\n", userID)
+
+	return nil
+}
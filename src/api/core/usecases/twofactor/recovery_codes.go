@@ -0,0 +1,46 @@
+package twofactor
+
+import (
+	"context"
+
+	"iycds2025_api/src/api/core/entities"
+	"iycds2025_api/src/api/core/errors"
+	"iycds2025_api/src/api/core/interfaces"
+)
+
+type RegenerateRecoveryCodes interface {
+	Execute(ctx context.Context, userID int64, request *entities.TwoFactorCode) (*entities.RecoveryCodesResponse, error)
+}
+
+type RegenerateRecoveryCodesImpl struct {
+	TwoFactor interfaces.TwoFactor
+}
+
+// Execute reemplaza los códigos de recuperación por una tanda nueva; los anteriores dejan de valer
+func (uc *RegenerateRecoveryCodesImpl) Execute(ctx context.Context, userID int64, request *entities.TwoFactorCode) (*entities.RecoveryCodesResponse, error) {
+	totp, err := uc.TwoFactor.GetTOTP(ctx, userID)
+	if err != nil {
+		return nil, errors.NewInternalServerError("Failed to get two-factor settings: " + err.Error())
+	}
+	if !totp.IsEnabled() {
+		return nil, errors.NewBadRequest("Two-factor authentication is not enabled")
+	}
+
+	valid, err := CheckCode(ctx, uc.TwoFactor, totp, request.Code)
+	if err != nil {
+		return nil, errors.NewInternalServerError("Failed to verify code: " + err.Error())
+	}
+	if !valid {
+		return nil, errors.NewBadRequest("Invalid verification code")
+	}
+
+	codes, hashes, err := generateRecoveryCodes()
+	if err != nil {
+		return nil, errors.NewInternalServerError("Failed to generate recovery codes")
+	}
+	if err := uc.TwoFactor.ReplaceRecoveryCodes(ctx, userID, hashes); err != nil {
+		return nil, errors.NewInternalServerError("Failed to save recovery codes: " + err.Error())
+	}
+
+	return &entities.RecoveryCodesResponse{RecoveryCodes: codes}, nil
+}
@@ -0,0 +1,52 @@
+package twofactor
+
+import (
+	"context"
+
+	"iycds2025_api/src/api/core/entities"
+	"iycds2025_api/src/api/core/errors"
+	"iycds2025_api/src/api/core/interfaces"
+	"iycds2025_api/src/api/utils"
+)
+
+type SetupTwoFactor interface {
+	Execute(ctx context.Context, userID int64) (*entities.TwoFactorSetupResponse, error)
+}
+
+type SetupTwoFactorImpl struct {
+	User      interfaces.User
+	TwoFactor interfaces.TwoFactor
+}
+
+// Execute genera un secreto nuevo para registrar en la app de autenticación. El 2FA no se activa
+// hasta confirmarlo con un código; pedir otro secreto antes de confirmar reemplaza el anterior.
+func (uc *SetupTwoFactorImpl) Execute(ctx context.Context, userID int64) (*entities.TwoFactorSetupResponse, error) {
+	user, err := uc.User.GetByID(ctx, userID)
+	if err != nil {
+		return nil, errors.NewInternalServerError("Failed to get user: " + err.Error())
+	}
+	if user == nil {
+		return nil, errors.NewNotFound("User not found")
+	}
+
+	current, err := uc.TwoFactor.GetTOTP(ctx, userID)
+	if err != nil {
+		return nil, errors.NewInternalServerError("Failed to get two-factor settings: " + err.Error())
+	}
+	if current.IsEnabled() {
+		return nil, errors.NewConflict("Two-factor authentication is already enabled")
+	}
+
+	secret, err := utils.GenerateTOTPSecret()
+	if err != nil {
+		return nil, errors.NewInternalServerError("Failed to generate two-factor secret")
+	}
+	if err := uc.TwoFactor.SaveTOTPSecret(ctx, userID, secret); err != nil {
+		return nil, errors.NewInternalServerError("Failed to save two-factor secret: " + err.Error())
+	}
+
+	return &entities.TwoFactorSetupResponse{
+		Secret:     secret,
+		OTPAuthURI: utils.TOTPURI(totpIssuer, user.Email, secret),
+	}, nil
+}
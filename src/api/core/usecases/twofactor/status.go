@@ -0,0 +1,38 @@
+package twofactor
+
+import (
+	"context"
+
+	"iycds2025_api/src/api/core/entities"
+	"iycds2025_api/src/api/core/errors"
+	"iycds2025_api/src/api/core/interfaces"
+)
+
+type TwoFactorStatus interface {
+	Execute(ctx context.Context, userID int64) (*entities.TwoFactorStatusResponse, error)
+}
+
+type TwoFactorStatusImpl struct {
+	TwoFactor interfaces.TwoFactor
+}
+
+func (uc *TwoFactorStatusImpl) Execute(ctx context.Context, userID int64) (*entities.TwoFactorStatusResponse, error) {
+	totp, err := uc.TwoFactor.GetTOTP(ctx, userID)
+	if err != nil {
+		return nil, errors.NewInternalServerError("Failed to get two-factor settings: " + err.Error())
+	}
+	if !totp.IsEnabled() {
+		return &entities.TwoFactorStatusResponse{Enabled: false}, nil
+	}
+
+	remaining, err := uc.TwoFactor.CountRecoveryCodes(ctx, userID)
+	if err != nil {
+		return nil, errors.NewInternalServerError("Failed to count recovery codes: " + err.Error())
+	}
+
+	return &entities.TwoFactorStatusResponse{
+		Enabled:                true,
+		EnabledAt:              totp.ConfirmedAt,
+		RecoveryCodesRemaining: remaining,
+	}, nil
+}
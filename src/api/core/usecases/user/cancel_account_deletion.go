@@ -0,0 +1,31 @@
+package user
+
+import (
+	"context"
+	"fmt"
+
+	"iycds2025_api/src/api/core/errors"
+	"iycds2025_api/src/api/core/interfaces"
+)
+
+type CancelAccountDeletion interface {
+	Execute(ctx context.Context, userID int64) error
+}
+
+type CancelAccountDeletionImpl struct {
+	User interfaces.User
+}
+
+// Execute cancela la baja programada mientras dure el período de gracia
+func (uc *CancelAccountDeletionImpl) Execute(ctx context.Context, userID int64) error {
+	cancelled, err := uc.User.CancelDeletion(ctx, userID)
+	if err != nil {
+		return errors.NewInternalServerError("Failed to cancel account deletion: " + err.Error())
+	}
+	if !cancelled {
+		return errors.NewNotFound("No account deletion is pending")
+	}
+	fmt.Printf("Account deletion cancelled for user %d\n", userID)
+
+	return nil
+}
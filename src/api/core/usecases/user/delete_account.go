@@ -0,0 +1,86 @@
+package user
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"iycds2025_api/src/api/core/entities"
+	"iycds2025_api/src/api/core/errors"
+	"iycds2025_api/src/api/core/interfaces"
+	"iycds2025_api/src/api/services/mail"
+	"iycds2025_api/src/api/utils"
+)
+
+// accountDeletionGracePeriod es el tiempo durante el cual el usuario puede arrepentirse de la baja
+const accountDeletionGracePeriod = 30 * 24 * time.Hour
+
+type RequestAccountDeletion interface {
+	Execute(ctx context.Context, userID int64, request *entities.AccountDeletionRequest, client entities.ClientInfo) (*entities.AccountDeletionResponse, error)
+}
+
+type RequestAccountDeletionImpl struct {
+	User         interfaces.User
+	Appointment  interfaces.Appointment
+	EmailService mail.EmailService
+	FrontendURL  string
+}
+
+// Execute programa la baja de la cuenta. Hasta que vence el período de gracia el usuario puede
+// iniciar sesión y cancelarla; después un worker anonimiza sus datos personales.
+func (uc *RequestAccountDeletionImpl) Execute(ctx context.Context, userID int64, request *entities.AccountDeletionRequest, client entities.ClientInfo) (*entities.AccountDeletionResponse, error) {
+	user, err := uc.User.GetByID(ctx, userID)
+	if err != nil {
+		return nil, errors.NewInternalServerError("Failed to get user: " + err.Error())
+	}
+	if user == nil || user.IsDeleted() {
+		return nil, errors.NewNotFound("User not found")
+	}
+
+	// Con un access token robado no alcanza: hay que conocer la contraseña. Las cuentas sin
+	// contraseña (creadas con un proveedor externo) dependen del aviso por email y del período de gracia.
+	if user.HasPassword() && !utils.CheckPasswordHash(request.Password, user.Password) {
+		return nil, errors.NewBadRequest("Password is incorrect")
+	}
+
+	// La otra parte de una cita activa espera que se atienda o se cancele con aviso: el usuario
+	// tiene que resolverlas antes de irse
+	fromDate := time.Now().AddDate(0, 0, -1).Format("2006-01-02")
+	upcoming, err := uc.Appointment.CountUpcomingByUser(ctx, userID, fromDate)
+	if err != nil {
+		return nil, errors.NewInternalServerError("Failed to check appointments: " + err.Error())
+	}
+	if upcoming > 0 {
+		return nil, errors.NewConflict(fmt.Sprintf("You have %d upcoming appointments. Cancel or complete them before deleting your account", upcoming))
+	}
+
+	scheduledAt := time.Now().Add(accountDeletionGracePeriod)
+	if user.IsDeletionPending() {
+		scheduledAt = *user.DeletionScheduledAt
+	}
+
+	if err := uc.User.ScheduleDeletion(ctx, userID, scheduledAt); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.NewNotFound("User not found")
+		}
+		return nil, errors.NewInternalServerError("Failed to schedule account deletion: " + err.Error())
+	}
+	fmt.Printf("Account deletion scheduled for user %d at %s (ip %s)\n", userID, scheduledAt.UTC().Format(time.RFC3339), client.IPAddress)
+
+	to := mail.Recipient{Email: user.Email, Name: user.Name, Locale: user.Locale}
+	data := mail.AccountDeletionScheduledData{
+		ScheduledFor: scheduledAt.UTC().Format("2006-01-02 15:04 UTC"),
+		IPAddress:    client.IPAddress,
+		LoginURL:     fmt.Sprintf("%s/login", uc.FrontendURL),
+	}
+	if err := uc.EmailService.SendAccountDeletionScheduledEmail(to, data); err != nil {
+		fmt.Printf("Error queueing account deletion email to %s: %v\n", user.Email, err)
+	}
+
+	return &entities.AccountDeletionResponse{
+		Message:             "Account deletion scheduled. Log in before the scheduled date to cancel it",
+		DeletionScheduledAt: scheduledAt,
+		GracePeriodDays:     int(accountDeletionGracePeriod.Hours() / 24),
+	}, nil
+}
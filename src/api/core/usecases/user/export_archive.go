@@ -0,0 +1,63 @@
+package user
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"iycds2025_api/src/api/core/entities"
+)
+
+// BuildExportArchive arma la exportación en un ZIP con un archivo JSON por sección, más cómodo de
+// revisar que un único documento cuando la cuenta tiene mucha actividad
+func BuildExportArchive(export *entities.PersonalDataExport) ([]byte, error) {
+	files := []struct {
+		name    string
+		content interface{}
+	}{
+		{"account.json", map[string]interface{}{
+			"exported_at":        export.ExportedAt,
+			"profile":            export.Profile,
+			"identities":         export.Identities,
+			"two_factor_enabled": export.TwoFactorEnabled,
+		}},
+		{"services.json", export.Services},
+		{"appointments_as_client.json", export.AppointmentsAsClient},
+		{"appointments_as_provider.json", export.AppointmentsAsProvider},
+		{"reviews_written.json", export.ReviewsWritten},
+		{"reviews_received.json", export.ReviewsReceived},
+	}
+
+	var buf bytes.Buffer
+	archive := zip.NewWriter(&buf)
+	for _, file := range files {
+		content, err := json.MarshalIndent(file.content, "", "  ")
+		if err != nil {
+			return nil, err
+		}
+
+		writer, err := archive.CreateHeader(&zip.FileHeader{
+			Name:     file.name,
+			Method:   zip.Deflate,
+			Modified: export.ExportedAt,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if _, err := writer.Write(content); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := archive.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// ExportFileName es el nombre con el que se descarga la exportación
+func ExportFileName(userID int64, exportedAt time.Time, format string) string {
+	return fmt.Sprintf("iycds2025-user-%d-%s.%s", userID, exportedAt.Format("20060102"), format)
+}
@@ -0,0 +1,115 @@
+package user
+
+import (
+	"context"
+	"time"
+
+	"iycds2025_api/src/api/core/entities"
+	"iycds2025_api/src/api/core/errors"
+	"iycds2025_api/src/api/core/interfaces"
+)
+
+type ExportPersonalData interface {
+	Execute(ctx context.Context, userID int64) (*entities.PersonalDataExport, error)
+}
+
+type ExportPersonalDataImpl struct {
+	User        interfaces.User
+	Identity    interfaces.Identity
+	TwoFactor   interfaces.TwoFactor
+	Service     interfaces.Service
+	Appointment interfaces.Appointment
+	Review      interfaces.Review
+}
+
+// Execute reúne los datos personales del usuario: perfil, identidades vinculadas, servicios, citas
+// como cliente y como proveedor y reseñas escritas y recibidas. No incluye secretos (contraseña,
+// TOTP, tokens) ni datos de otros usuarios más allá de sus IDs.
+func (uc *ExportPersonalDataImpl) Execute(ctx context.Context, userID int64) (*entities.PersonalDataExport, error) {
+	user, err := uc.User.GetByID(ctx, userID)
+	if err != nil {
+		return nil, errors.NewInternalServerError("Failed to get user: " + err.Error())
+	}
+	if user == nil || user.IsDeleted() {
+		return nil, errors.NewNotFound("User not found")
+	}
+
+	role, err := uc.User.GetRole(ctx, userID)
+	if err != nil {
+		return nil, errors.NewInternalServerError("Failed to get role: " + err.Error())
+	}
+
+	identities, err := uc.Identity.ListByUser(ctx, userID)
+	if err != nil {
+		return nil, errors.NewInternalServerError("Failed to get identities: " + err.Error())
+	}
+
+	totp, err := uc.TwoFactor.GetTOTP(ctx, userID)
+	if err != nil {
+		return nil, errors.NewInternalServerError("Failed to get two-factor settings: " + err.Error())
+	}
+
+	services, err := uc.Service.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, errors.NewInternalServerError("Failed to get services: " + err.Error())
+	}
+
+	asClient, err := uc.Appointment.GetByClientID(ctx, userID)
+	if err != nil {
+		return nil, errors.NewInternalServerError("Failed to get appointments: " + err.Error())
+	}
+
+	asProvider, err := uc.Appointment.GetByProviderID(ctx, userID)
+	if err != nil {
+		return nil, errors.NewInternalServerError("Failed to get appointments: " + err.Error())
+	}
+
+	written, err := uc.Review.GetByClientID(ctx, userID)
+	if err != nil {
+		return nil, errors.NewInternalServerError("Failed to get reviews: " + err.Error())
+	}
+
+	received, err := uc.Review.GetByProviderID(ctx, userID)
+	if err != nil {
+		return nil, errors.NewInternalServerError("Failed to get reviews: " + err.Error())
+	}
+
+	// Las listas vacías se exportan como [] y no como null
+	if services == nil {
+		services = []*entities.Service{}
+	}
+	if asClient == nil {
+		asClient = []*entities.Appointment{}
+	}
+	if asProvider == nil {
+		asProvider = []*entities.Appointment{}
+	}
+
+	return &entities.PersonalDataExport{
+		ExportedAt: time.Now().UTC(),
+		Profile: entities.ExportProfile{
+			ID:                  user.ID,
+			Name:                user.Name,
+			DisplayName:         user.DisplayName,
+			Bio:                 user.Bio,
+			AvatarURL:           user.AvatarURL,
+			Email:               user.Email,
+			EmailVerifiedAt:     user.EmailVerifiedAt,
+			Locality:            user.Locality,
+			Province:            user.Province,
+			Phone:               user.Phone,
+			Locale:              user.Locale,
+			Role:                role,
+			DeletionScheduledAt: user.DeletionScheduledAt,
+			CreatedAt:           user.CreatedAt,
+			UpdatedAt:           user.UpdatedAt,
+		},
+		Identities:             identities,
+		Services:               services,
+		AppointmentsAsClient:   asClient,
+		AppointmentsAsProvider: asProvider,
+		ReviewsWritten:         written,
+		ReviewsReceived:        received,
+		TwoFactorEnabled:       totp.IsEnabled(),
+	}, nil
+}
@@ -0,0 +1,26 @@
+package user
+
+import (
+	"context"
+
+	"iycds2025_api/src/api/core/entities"
+	"iycds2025_api/src/api/core/errors"
+	"iycds2025_api/src/api/core/interfaces"
+)
+
+type ListIdentities interface {
+	Execute(ctx context.Context, userID int64) ([]*entities.UserIdentity, error)
+}
+
+type ListIdentitiesImpl struct {
+	Identity interfaces.Identity
+}
+
+// Execute devuelve los proveedores externos vinculados a la cuenta
+func (uc *ListIdentitiesImpl) Execute(ctx context.Context, userID int64) ([]*entities.UserIdentity, error) {
+	identities, err := uc.Identity.ListByUser(ctx, userID)
+	if err != nil {
+		return nil, errors.NewInternalServerError("Failed to get identities: " + err.Error())
+	}
+	return identities, nil
+}
@@ -0,0 +1,51 @@
+package user
+
+import (
+	"context"
+	"fmt"
+
+	"iycds2025_api/src/api/core/errors"
+	"iycds2025_api/src/api/core/interfaces"
+)
+
+type UnlinkIdentity interface {
+	Execute(ctx context.Context, userID int64, provider string) error
+}
+
+type UnlinkIdentityImpl struct {
+	User     interfaces.User
+	Identity interfaces.Identity
+}
+
+// Execute desvincula un proveedor externo de la cuenta. No se permite quitar la única forma de
+// iniciar sesión de una cuenta sin contraseña.
+func (uc *UnlinkIdentityImpl) Execute(ctx context.Context, userID int64, provider string) error {
+	user, err := uc.User.GetByID(ctx, userID)
+	if err != nil {
+		return errors.NewInternalServerError("Failed to get user: " + err.Error())
+	}
+	if user == nil {
+		return errors.NewNotFound("User not found")
+	}
+
+	if !user.HasPassword() {
+		identities, err := uc.Identity.ListByUser(ctx, userID)
+		if err != nil {
+			return errors.NewInternalServerError("Failed to get identities: " + err.Error())
+		}
+		if len(identities) <= 1 {
+			return errors.NewBadRequest("Set a password before unlinking your only sign-in method")
+		}
+	}
+
+	unlinked, err := uc.Identity.Unlink(ctx, userID, provider)
+	if err != nil {
+		return errors.NewInternalServerError("Failed to unlink identity: " + err.Error())
+	}
+	if !unlinked {
+		return errors.NewNotFound("Identity not linked to this account")
+	}
+	fmt.Printf("Unlinked %s identity from user %d\n", provider, userID)
+
+	return nil
+}
@@ -6,6 +6,8 @@ import (
 	"iycds2025_api/src/api/core/entities"
 	"iycds2025_api/src/api/core/errors"
 	"iycds2025_api/src/api/core/interfaces"
+	"iycds2025_api/src/api/core/usecases/verification"
+	"iycds2025_api/src/api/utils"
 )
 
 type UpdateUser interface {
@@ -13,7 +15,8 @@ type UpdateUser interface {
 }
 
 type UpdateUserImpl struct {
-	User interfaces.User
+	User                  interfaces.User
+	SendEmailVerification verification.SendEmailVerification
 }
 
 func (uc *UpdateUserImpl) Execute(ctx context.Context, userID int64, userUpdate *entities.UserUpdate) (*entities.UserResponse, error) {
@@ -26,36 +29,66 @@ func (uc *UpdateUserImpl) Execute(ctx context.Context, userID int64, userUpdate
 		return nil, errors.NewNotFound("User not found")
 	}
 
+	if userUpdate.AvatarURL != nil && *userUpdate.AvatarURL != "" && !utils.IsHTTPURL(*userUpdate.AvatarURL) {
+		return nil, errors.NewBadRequest("Avatar URL must be an http or https URL")
+	}
+
 	// Si se está actualizando el email, verificar que no esté en uso
+	pendingEmail := ""
 	if userUpdate.Email != "" && userUpdate.Email != existing.Email {
 		existingUser, err := uc.User.GetByEmail(ctx, userUpdate.Email)
 		if err != nil {
 			return nil, errors.NewInternalServerError("Failed to check email availability: " + err.Error())
 		}
 		if existingUser != nil {
-			return nil, errors.NewBadRequest("Email is already in use")
+			return nil, errors.NewConflict("Email is already in use")
 		}
+		pendingEmail = userUpdate.Email
 	}
 
+	// El nuevo email no se guarda todavía: se aplica cuando el usuario confirma el enlace que
+	// se le envía a esa dirección
+	userUpdate.Email = ""
+
 	// Actualizar el usuario
 	updatedUser, err := uc.User.Update(ctx, userID, userUpdate)
 	if err != nil {
 		return nil, errors.NewInternalServerError("Failed to update user: " + err.Error())
 	}
 
+	if pendingEmail != "" {
+		if err := uc.SendEmailVerification.Execute(ctx, updatedUser, pendingEmail); err != nil {
+			return nil, err
+		}
+	}
+
 	// Convertir a response (sin password)
-	return uc.toUserResponse(updatedUser), nil
+	response := uc.toUserResponse(updatedUser)
+	response.PendingEmail = pendingEmail
+	return response, nil
 }
 
 func (uc *UpdateUserImpl) toUserResponse(user *entities.User) *entities.UserResponse {
-	return &entities.UserResponse{
+	response := &entities.UserResponse{
 		ID:        user.ID,
 		Name:      user.Name,
 		Email:     user.Email,
 		Locality:  user.Locality,
 		Province:  user.Province,
 		Phone:     user.Phone,
+		Locale:    user.Locale,
 		CreatedAt: user.CreatedAt.Format("2006-01-02T15:04:05Z"),
 		UpdatedAt: user.UpdatedAt.Format("2006-01-02T15:04:05Z"),
+
+		EmailVerified: user.IsEmailVerified(),
+		HasPassword:   user.HasPassword(),
+
+		DisplayName: user.DisplayName,
+		Bio:         user.Bio,
+		AvatarURL:   user.AvatarURL,
+	}
+	if user.IsDeletionPending() {
+		response.DeletionScheduledAt = user.DeletionScheduledAt
 	}
+	return response
 }
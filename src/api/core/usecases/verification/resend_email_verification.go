@@ -0,0 +1,41 @@
+package verification
+
+import (
+	"context"
+
+	"iycds2025_api/src/api/core/errors"
+	"iycds2025_api/src/api/core/interfaces"
+)
+
+type ResendEmailVerification interface {
+	Execute(ctx context.Context, userID int64) error
+}
+
+type ResendEmailVerificationImpl struct {
+	User                  interfaces.User
+	SendEmailVerification SendEmailVerification
+}
+
+func (uc *ResendEmailVerificationImpl) Execute(ctx context.Context, userID int64) error {
+	user, err := uc.User.GetByID(ctx, userID)
+	if err != nil {
+		return errors.NewInternalServerError("Failed to get user: " + err.Error())
+	}
+	if user == nil {
+		return errors.NewNotFound("User not found")
+	}
+
+	// Un cambio de email pendiente tiene prioridad: el enlace va a la nueva dirección
+	email, err := uc.User.GetPendingVerificationEmail(ctx, userID)
+	if err != nil {
+		return errors.NewInternalServerError("Failed to get pending verification: " + err.Error())
+	}
+	if email == "" || email == user.Email {
+		if user.IsEmailVerified() {
+			return errors.NewBadRequest("Email is already verified")
+		}
+		email = user.Email
+	}
+
+	return uc.SendEmailVerification.Execute(ctx, user, email)
+}
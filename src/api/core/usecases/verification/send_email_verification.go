@@ -0,0 +1,46 @@
+package verification
+
+import (
+	"context"
+	"fmt"
+
+	"iycds2025_api/src/api/core/entities"
+	"iycds2025_api/src/api/core/errors"
+	"iycds2025_api/src/api/core/interfaces"
+	"iycds2025_api/src/api/services/mail"
+)
+
+// SendEmailVerification envía el enlace para confirmar un email del usuario: el de la cuenta
+// (registro) o el nuevo email de un cambio, que se aplica recién al verificarlo
+type SendEmailVerification interface {
+	Execute(ctx context.Context, user *entities.User, email string) error
+}
+
+type SendEmailVerificationImpl struct {
+	User         interfaces.User
+	EmailService mail.EmailService
+	FrontendURL  string
+}
+
+func (uc *SendEmailVerificationImpl) Execute(ctx context.Context, user *entities.User, email string) error {
+	token, expiresAt, err := uc.User.CreateEmailVerificationToken(ctx, user.ID, email)
+	if err != nil {
+		return errors.NewInternalServerError("Failed to create verification token")
+	}
+
+	// Construir el enlace de verificación
+	verifyLink := fmt.Sprintf("%s/verify-email/%s", uc.FrontendURL, token)
+
+	// El correo va a la dirección que se confirma; el token ya quedó creado aunque falle el envío
+	// y el usuario puede pedir que se reenvíe
+	to := mail.Recipient{Email: email, Name: user.Name, Locale: user.Locale}
+	data := mail.EmailVerificationData{VerifyLink: verifyLink, EmailChange: email != user.Email}
+	if err := uc.EmailService.SendEmailVerificationEmail(to, data); err != nil {
+		fmt.Printf("Error queueing email verification to %s: %v\n", email, err)
+	} else {
+		fmt.Printf("Email verification queued for %s. Expires at: %s\n",
+			email, expiresAt.Format("2006-01-02 15:04:05"))
+	}
+
+	return nil
+}
@@ -0,0 +1,26 @@
+package verification
+
+import (
+	"context"
+
+	"iycds2025_api/src/api/core/entities"
+	"iycds2025_api/src/api/core/interfaces"
+)
+
+type VerifyEmail interface {
+	Execute(ctx context.Context, request *entities.VerifyEmail) error
+}
+
+type VerifyEmailImpl struct {
+	User interfaces.User
+}
+
+func (uc *VerifyEmailImpl) Execute(ctx context.Context, request *entities.VerifyEmail) error {
+	// Confirmar el email del token; si es un cambio de email, la cuenta pasa a usar el nuevo
+	_, err := uc.User.VerifyEmail(ctx, request.Token)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -1,33 +1,67 @@
 package dependencies
 
 import (
+	"fmt"
+	"log"
 	"os"
 
 	"iycds2025_api/configs"
+	"iycds2025_api/src/api/core/usecases/admin"
 	"iycds2025_api/src/api/core/usecases/appointment"
+	"iycds2025_api/src/api/core/usecases/exception"
 	"iycds2025_api/src/api/core/usecases/login"
 	"iycds2025_api/src/api/core/usecases/password"
 	"iycds2025_api/src/api/core/usecases/register"
+	"iycds2025_api/src/api/core/usecases/review"
 	"iycds2025_api/src/api/core/usecases/service"
+	"iycds2025_api/src/api/core/usecases/serviceimage"
 	"iycds2025_api/src/api/core/usecases/user"
+	"iycds2025_api/src/api/core/usecases/twofactor"
+	"iycds2025_api/src/api/core/usecases/verification"
 	"iycds2025_api/src/api/infrastructure/entrypoints/api"
 	apiHandlers "iycds2025_api/src/api/infrastructure/entrypoints/api/handlers"
+	"iycds2025_api/src/api/infrastructure/workers"
 	"iycds2025_api/src/api/repositories/database"
+	"iycds2025_api/src/api/middleware"
+	"iycds2025_api/src/api/services/mail"
+
+	"github.com/gin-gonic/gin"
 )
 
 type HandlerContainer struct {
 	Ping                        api.Handler
 	UserLogin                   api.Handler
+	UserLoginTwoFactor          api.Handler
+	ExternalLoginProviders      api.Handler
+	ExternalLoginStart          api.Handler
+	ExternalLoginCallback       api.Handler
+	UserRefresh                 api.Handler
+	UserLogout                  api.Handler
+	UserLogoutAll               api.Handler
 	UserRegister                api.Handler
 	UserUpdate                  api.Handler
 	PasswordForgot              api.Handler
 	PasswordReset               api.Handler
+	PasswordChange              api.Handler
+	EmailVerify                 api.Handler
+	EmailVerificationResend     api.Handler
+	TwoFactorStatus             api.Handler
+	TwoFactorSetup              api.Handler
+	TwoFactorConfirm            api.Handler
+	TwoFactorDisable            api.Handler
+	TwoFactorRecoveryCodes      api.Handler
+	UserIdentities              api.Handler
+	UserIdentityUnlink          api.Handler
+	UserExport                  api.Handler
+	UserDelete                  api.Handler
+	UserDeletionCancel          api.Handler
 	ServiceCreate               api.Handler
 	ServiceUpdate               api.Handler
 	ServiceDelete               api.Handler
 	ServiceUpdateStatus         api.Handler
 	ServiceList                 api.Handler
 	ServiceListAll              api.Handler
+	ServiceSearch               api.Handler
 	ServiceGetByID              api.Handler
 	ServiceAvailability         api.Handler
 	ServiceCalendar             api.Handler
@@ -35,10 +69,55 @@ type HandlerContainer struct {
 	AppointmentCreate           api.Handler
 	AppointmentList             api.Handler
 	AppointmentUpdateStatus     api.Handler
+	ServiceExceptionList        api.Handler
+	ServiceExceptionCreate      api.Handler
+	ServiceExceptionUpdate      api.Handler
+	ServiceExceptionDelete      api.Handler
+	ServiceImageList            api.Handler
+	ServiceImageUpload          api.Handler
+	ServiceImageDelete          api.Handler
+	ServiceImageReorder         api.Handler
+	Media                       api.Handler
+	ReviewCreate                api.Handler
+	ReviewReply                 api.Handler
+	ServiceReviews              api.Handler
+	ProviderProfile             api.Handler
 	Categories                  api.Handler
+	JWKS                        api.Handler
+
+	// Back-office (rutas /api/admin: usuarios y auditoría solo para administradores; servicios y citas
+	// también para moderadores con el permiso correspondiente)
+	AdminUserList            api.Handler
+	AdminUserSuspend         api.Handler
+	AdminUserReactivate      api.Handler
+	AdminServiceList         api.Handler
+	AdminServiceDeactivate   api.Handler
+	AdminServiceDelete       api.Handler
+	AdminAppointmentList     api.Handler
+	AdminAppointmentCancel   api.Handler
+	AdminAppointmentReassign api.Handler
+	AdminAuditLog            api.Handler
+
+	// Bandeja de correos capturados; solo en desarrollo con EMAIL_SERVICE_TYPE=file (nil en otro caso)
+	DevMailboxList    api.Handler
+	DevMailboxMessage api.Handler
+
+	// Middleware de autenticación: valida el JWT y que su sesión siga activa
+	Auth gin.HandlerFunc
+	// Igual que Auth pero sin exigir el token: las rutas públicas lo usan para conocer al usuario si lo hay
+	OptionalAuth gin.HandlerFunc
+
+	// Procesos en segundo plano que se inician junto con la API
+	Workers []workers.Worker
 }
 
 func Start() *HandlerContainer {
+	// Claves de firma de los access tokens: sin clave la API no arranca
+	jwtKeys, err := configs.LoadJWTKeySet()
+	if err != nil {
+		log.Fatalf("Failed to load JWT signing keys: %v", err)
+	}
+
 	// Database
 	db := configs.ConnectDatabase()
 
@@ -49,9 +128,29 @@ func Start() *HandlerContainer {
 
 	serviceRepo := database.NewServiceRepository(db)
 	appointmentRepo := database.NewAppointmentRepository(db)
+	serviceExceptionRepo := database.NewServiceExceptionRepository(db)
+	serviceSearchRepo := database.NewServiceSearchRepository(db)
+	reviewRepo := database.NewReviewRepository(db)
+	emailOutboxRepo := database.NewEmailOutboxRepository(db)
+	sessionRepo := database.NewSessionRepository(db)
+	auditLogRepo := database.NewAuditLogRepository(db)
+	loginAttemptRepo := database.NewLoginAttemptRepository(db)
+	twoFactorRepo := database.NewTwoFactorRepository(db)
+	identityRepo := database.NewIdentityRepository(db)
+	serviceImageRepo := database.NewServiceImageRepository(db)
 
 	// Services
-	emailService := configs.NewEmailService()
+	// Los use cases encolan los correos en el outbox; el worker los envía con el servicio configurado
+	emailService := mail.NewOutboxEmailService(emailOutboxRepo)
+	deliveryService := configs.NewEmailService()
+	emailOutboxWorker := workers.NewEmailOutboxWorker(emailOutboxRepo, deliveryService)
+
+	// Almacenamiento de las fotos de los servicios: disco local por defecto, S3 o compatible en producción
+	storageConfig := configs.GetStorageConfig()
+	blobStore, err := configs.NewBlobStore(storageConfig)
+	if err != nil {
+		log.Fatalf("Failed to configure file storage: %v", err)
+	}
 
 	// URL del frontend para los enlaces
 	frontendURL := os.Getenv("FRONTEND_URL")
@@ -59,9 +158,62 @@ func Start() *HandlerContainer {
 		frontendURL = "http://localhost:3000" // URL por defecto
 	}
 
+	// Proveedores de identidad externos (OIDC) para el login sin contraseña
+	identityProviders, err := configs.LoadIdentityProviders(frontendURL)
+	if err != nil {
+		log.Fatalf("Failed to load identity providers: %v", err)
+	}
+
 	// Use cases
 	userLoginUseCase := &login.UserLoginImpl{
-		User: userRepo,
+		User:         userRepo,
+		Session:      sessionRepo,
+		LoginAttempt: loginAttemptRepo,
+		TwoFactor:    twoFactorRepo,
+		Keys:         jwtKeys,
+		EmailService: emailService,
+		FrontendURL:  frontendURL,
+	}
+
+	// Segundo paso del login para las cuentas con 2FA
+	verifyTwoFactorLoginUseCase := &login.VerifyTwoFactorLoginImpl{
+		User:         userRepo,
+		Session:      sessionRepo,
+		LoginAttempt: loginAttemptRepo,
+		TwoFactor:    twoFactorRepo,
+		Keys:         jwtKeys,
+		EmailService: emailService,
+		FrontendURL:  frontendURL,
+	}
+
+	// Login con proveedores externos (authorization code + PKCE)
+	startExternalLoginUseCase := &login.StartExternalLoginImpl{
+		Identity:  identityRepo,
+		Providers: identityProviders,
+	}
+
+	externalLoginUseCase := &login.ExternalLoginImpl{
+		User:         userRepo,
+		Session:      sessionRepo,
+		LoginAttempt: loginAttemptRepo,
+		TwoFactor:    twoFactorRepo,
+		Identity:     identityRepo,
+		Providers:    identityProviders,
+		Keys:         jwtKeys,
+	}
+
+	refreshTokenUseCase := &login.RefreshTokenImpl{
+		User:    userRepo,
+		Session: sessionRepo,
+		Keys:    jwtKeys,
+	}
+
+	logoutUseCase := &login.LogoutImpl{
+		Session: sessionRepo,
+	}
+
+	logoutAllUseCase := &login.LogoutAllImpl{
+		Session: sessionRepo,
 	}
 
 	forgotPasswordUseCase := &password.ForgotPasswordImpl{
@@ -74,25 +226,105 @@ func Start() *HandlerContainer {
 		User: userRepo,
 	}
 
-	userRegisterUseCase := &register.UserRegisterImpl{
+	changePasswordUseCase := &password.ChangePasswordImpl{
+		User:         userRepo,
+		EmailService: emailService,
+		FrontendURL:  frontendURL,
+	}
+
+	// Autenticación en dos pasos (TOTP)
+	twoFactorStatusUseCase := &twofactor.TwoFactorStatusImpl{
+		TwoFactor: twoFactorRepo,
+	}
+
+	setupTwoFactorUseCase := &twofactor.SetupTwoFactorImpl{
+		User:      userRepo,
+		TwoFactor: twoFactorRepo,
+	}
+
+	confirmTwoFactorUseCase := &twofactor.ConfirmTwoFactorImpl{
+		TwoFactor: twoFactorRepo,
+	}
+
+	disableTwoFactorUseCase := &twofactor.DisableTwoFactorImpl{
+		User:      userRepo,
+		TwoFactor: twoFactorRepo,
+	}
+
+	regenerateRecoveryCodesUseCase := &twofactor.RegenerateRecoveryCodesImpl{
+		TwoFactor: twoFactorRepo,
+	}
+
+	// Verificación de email: se envía al registrarse y al cambiar de email
+	sendEmailVerificationUseCase := &verification.SendEmailVerificationImpl{
+		User:         userRepo,
+		EmailService: emailService,
+		FrontendURL:  frontendURL,
+	}
+
+	verifyEmailUseCase := &verification.VerifyEmailImpl{
 		User: userRepo,
 	}
 
+	resendEmailVerificationUseCase := &verification.ResendEmailVerificationImpl{
+		User:                  userRepo,
+		SendEmailVerification: sendEmailVerificationUseCase,
+	}
+
+	userRegisterUseCase := &register.UserRegisterImpl{
+		User:                  userRepo,
+		SendEmailVerification: sendEmailVerificationUseCase,
+	}
+
 	userUpdateUseCase := &user.UpdateUserImpl{
+		User:                  userRepo,
+		SendEmailVerification: sendEmailVerificationUseCase,
+	}
+
+	listIdentitiesUseCase := &user.ListIdentitiesImpl{
+		Identity: identityRepo,
+	}
+
+	unlinkIdentityUseCase := &user.UnlinkIdentityImpl{
+		User:     userRepo,
+		Identity: identityRepo,
+	}
+
+	exportPersonalDataUseCase := &user.ExportPersonalDataImpl{
+		User:        userRepo,
+		Identity:    identityRepo,
+		TwoFactor:   twoFactorRepo,
+		Service:     serviceRepo,
+		Appointment: appointmentRepo,
+		Review:      reviewRepo,
+	}
+
+	requestAccountDeletionUseCase := &user.RequestAccountDeletionImpl{
+		User:         userRepo,
+		Appointment:  appointmentRepo,
+		EmailService: emailService,
+		FrontendURL:  frontendURL,
+	}
+
+	cancelAccountDeletionUseCase := &user.CancelAccountDeletionImpl{
 		User: userRepo,
 	}
 
 	// Service use cases
 	createServiceUseCase := &service.CreateServiceImpl{
-		Service: serviceRepo,
+		Service:       serviceRepo,
+		ServiceSearch: serviceSearchRepo,
+		User:          userRepo,
 	}
 
 	updateServiceUseCase := &service.UpdateServiceImpl{
-		Service: serviceRepo,
+		Service:       serviceRepo,
+		ServiceSearch: serviceSearchRepo,
 	}
 
 	updateStatusUseCase := &service.UpdateServiceStatusImpl{
-		Service: serviceRepo,
+		Service:       serviceRepo,
+		ServiceSearch: serviceSearchRepo,
 	}
 
 	listMyServicesUseCase := &service.ListMyServicesImpl{
@@ -104,24 +336,45 @@ func Start() *HandlerContainer {
 	}
 
 	getServiceByIDUseCase := &service.GetServiceByIDImpl{
-		Service: serviceRepo,
+		Service:      serviceRepo,
+		User:         userRepo,
+		ServiceImage: serviceImageRepo,
+	}
+
+	getProviderProfileUseCase := &service.GetProviderProfileImpl{
+		User:        userRepo,
+		Service:     serviceRepo,
+		Appointment: appointmentRepo,
+		Review:      reviewRepo,
 	}
 
 	deleteServiceUseCase := &service.DeleteServiceImpl{
-		Service: serviceRepo,
+		Service:       serviceRepo,
+		ServiceSearch: serviceSearchRepo,
+		ServiceImage:  serviceImageRepo,
+		BlobStore:     blobStore,
+	}
+
+	searchServicesUseCase := &service.SearchServicesImpl{
+		ServiceSearch: serviceSearchRepo,
 	}
 
-	getServiceCalendarUseCase := service.NewGetServiceCalendarUseCase(serviceRepo, appointmentRepo)
+	getServiceCalendarUseCase := service.NewGetServiceCalendarUseCase(serviceRepo, appointmentRepo, serviceExceptionRepo)
 
 	// Appointment use cases
 	getServiceAvailabilityUseCase := &appointment.GetServiceAvailabilityImpl{
-		Service:     serviceRepo,
-		Appointment: appointmentRepo,
+		Service:          serviceRepo,
+		Appointment:      appointmentRepo,
+		ServiceException: serviceExceptionRepo,
 	}
 
 	createAppointmentUseCase := &appointment.CreateAppointmentImpl{
-		Service:     serviceRepo,
-		Appointment: appointmentRepo,
+		Service:          serviceRepo,
+		Appointment:      appointmentRepo,
+		ServiceException: serviceExceptionRepo,
+		User:             userRepo,
+		EmailService:     emailService,
+		FrontendURL:      frontendURL,
 	}
 
 	listMyAppointmentsUseCase := &appointment.ListMyAppointmentsImpl{
@@ -135,9 +388,133 @@ func Start() *HandlerContainer {
 	}
 
 	updateAppointmentStatusUseCase := &appointment.UpdateAppointmentStatusImpl{
+		Appointment:  appointmentRepo,
+		Service:      serviceRepo,
+		User:         userRepo,
+		EmailService: emailService,
+		FrontendURL:  frontendURL,
+	}
+
+	reassignAppointmentUseCase := &appointment.ReassignAppointmentImpl{
+		Appointment:      appointmentRepo,
+		Service:          serviceRepo,
+		ServiceException: serviceExceptionRepo,
+		User:             userRepo,
+		EmailService:     emailService,
+		FrontendURL:      frontendURL,
+	}
+
+	// Service exception use cases
+	listServiceExceptionsUseCase := &exception.ListServiceExceptionsImpl{
+		Service:          serviceRepo,
+		ServiceException: serviceExceptionRepo,
+	}
+
+	createServiceExceptionUseCase := &exception.CreateServiceExceptionImpl{
+		Service:          serviceRepo,
+		ServiceException: serviceExceptionRepo,
+	}
+
+	updateServiceExceptionUseCase := &exception.UpdateServiceExceptionImpl{
+		Service:          serviceRepo,
+		ServiceException: serviceExceptionRepo,
+	}
+
+	deleteServiceExceptionUseCase := &exception.DeleteServiceExceptionImpl{
+		Service:          serviceRepo,
+		ServiceException: serviceExceptionRepo,
+	}
+
+	// Service image use cases
+	listServiceImagesUseCase := &serviceimage.ListServiceImagesImpl{
+		Service:      serviceRepo,
+		ServiceImage: serviceImageRepo,
+	}
+
+	uploadServiceImageUseCase := &serviceimage.UploadServiceImageImpl{
+		Service:      serviceRepo,
+		ServiceImage: serviceImageRepo,
+		BlobStore:    blobStore,
+		PublicURL:    storageConfig.PublicURL,
+	}
+
+	deleteServiceImageUseCase := &serviceimage.DeleteServiceImageImpl{
+		Service:      serviceRepo,
+		ServiceImage: serviceImageRepo,
+		BlobStore:    blobStore,
+	}
+
+	reorderServiceImagesUseCase := &serviceimage.ReorderServiceImagesImpl{
+		Service:      serviceRepo,
+		ServiceImage: serviceImageRepo,
+	}
+
+	// Review use cases
+	createReviewUseCase := &review.CreateReviewImpl{
+		Appointment: appointmentRepo,
+		Review:      reviewRepo,
+	}
+
+	replyReviewUseCase := &review.ReplyReviewImpl{
+		Review: reviewRepo,
+	}
+
+	listServiceReviewsUseCase := &review.ListServiceReviewsImpl{
+		Service: serviceRepo,
+		Review:  reviewRepo,
+	}
+
+	// Admin use cases: las acciones reutilizan los casos de uso de cada recurso y quedan en la auditoría
+	adminListUsersUseCase := &admin.ListUsersImpl{
+		User: userRepo,
+	}
+
+	adminSuspendUserUseCase := &admin.SuspendUserImpl{
+		User:     userRepo,
+		AuditLog: auditLogRepo,
+	}
+
+	adminReactivateUserUseCase := &admin.ReactivateUserImpl{
+		User:     userRepo,
+		AuditLog: auditLogRepo,
+	}
+
+	adminListServicesUseCase := &admin.ListServicesImpl{
+		Service: serviceRepo,
+	}
+
+	adminDeactivateServiceUseCase := &admin.DeactivateServiceImpl{
+		Service:             serviceRepo,
+		UpdateServiceStatus: updateStatusUseCase,
+		AuditLog:            auditLogRepo,
+	}
+
+	adminDeleteServiceUseCase := &admin.DeleteServiceImpl{
+		Service:       serviceRepo,
+		DeleteService: deleteServiceUseCase,
+		AuditLog:      auditLogRepo,
+	}
+
+	adminListAppointmentsUseCase := &admin.ListAppointmentsImpl{
 		Appointment: appointmentRepo,
 	}
 
+	adminCancelAppointmentUseCase := &admin.CancelAppointmentImpl{
+		Appointment:             appointmentRepo,
+		UpdateAppointmentStatus: updateAppointmentStatusUseCase,
+		AuditLog:                auditLogRepo,
+	}
+
+	adminReassignAppointmentUseCase := &admin.ReassignAppointmentImpl{
+		Appointment:         appointmentRepo,
+		ReassignAppointment: reassignAppointmentUseCase,
+		AuditLog:            auditLogRepo,
+	}
+
+	adminListAuditLogUseCase := &admin.ListAuditLogImpl{
+		AuditLog: auditLogRepo,
+	}
+
 	// Handlers
 	handlers := HandlerContainer{}
 
@@ -145,6 +522,27 @@ func Start() *HandlerContainer {
 	handlers.UserLogin = &apiHandlers.UserLogin{
 		UseCase: userLoginUseCase,
 	}
+	handlers.UserLoginTwoFactor = &apiHandlers.UserLoginTwoFactor{
+		UseCase: verifyTwoFactorLoginUseCase,
+	}
+	handlers.ExternalLoginProviders = &apiHandlers.ExternalLoginProviders{
+		Providers: identityProviders,
+	}
+	handlers.ExternalLoginStart = &apiHandlers.ExternalLoginStart{
+		UseCase: startExternalLoginUseCase,
+	}
+	handlers.ExternalLoginCallback = &apiHandlers.ExternalLoginCallback{
+		UseCase: externalLoginUseCase,
+	}
+	handlers.UserRefresh = &apiHandlers.UserRefresh{
+		UseCase: refreshTokenUseCase,
+	}
+	handlers.UserLogout = &apiHandlers.UserLogout{
+		UseCase: logoutUseCase,
+	}
+	handlers.UserLogoutAll = &apiHandlers.UserLogoutAll{
+		UseCase: logoutAllUseCase,
+	}
 	handlers.UserRegister = &apiHandlers.UserRegister{
 		UseCase: userRegisterUseCase,
 	}
@@ -157,6 +555,45 @@ func Start() *HandlerContainer {
 	handlers.PasswordReset = &apiHandlers.PasswordReset{
 		UseCase: resetPasswordUseCase,
 	}
+	handlers.PasswordChange = &apiHandlers.PasswordChange{
+		UseCase: changePasswordUseCase,
+	}
+	handlers.EmailVerify = &apiHandlers.EmailVerify{
+		UseCase: verifyEmailUseCase,
+	}
+	handlers.EmailVerificationResend = &apiHandlers.EmailVerificationResend{
+		UseCase: resendEmailVerificationUseCase,
+	}
+	handlers.TwoFactorStatus = &apiHandlers.TwoFactorStatus{
+		UseCase: twoFactorStatusUseCase,
+	}
+	handlers.TwoFactorSetup = &apiHandlers.TwoFactorSetup{
+		UseCase: setupTwoFactorUseCase,
+	}
+	handlers.TwoFactorConfirm = &apiHandlers.TwoFactorConfirm{
+		UseCase: confirmTwoFactorUseCase,
+	}
+	handlers.TwoFactorDisable = &apiHandlers.TwoFactorDisable{
+		UseCase: disableTwoFactorUseCase,
+	}
+	handlers.TwoFactorRecoveryCodes = &apiHandlers.TwoFactorRecoveryCodes{
+		UseCase: regenerateRecoveryCodesUseCase,
+	}
+	handlers.UserIdentities = &apiHandlers.UserIdentities{
+		UseCase: listIdentitiesUseCase,
+	}
+	handlers.UserIdentityUnlink = &apiHandlers.UserIdentityUnlink{
+		UseCase: unlinkIdentityUseCase,
+	}
+	handlers.UserExport = &apiHandlers.UserExport{
+		UseCase: exportPersonalDataUseCase,
+	}
+	handlers.UserDelete = &apiHandlers.UserDelete{
+		UseCase: requestAccountDeletionUseCase,
+	}
+	handlers.UserDeletionCancel = &apiHandlers.UserDeletionCancel{
+		UseCase: cancelAccountDeletionUseCase,
+	}
 	handlers.ServiceCreate = &apiHandlers.ServiceCreateHandler{
 		CreateService: createServiceUseCase,
 	}
@@ -175,9 +612,15 @@ func Start() *HandlerContainer {
 	handlers.ServiceListAll = &apiHandlers.ServiceListAllHandler{
 		ListAllServices: listAllServicesUseCase,
 	}
+	handlers.ServiceSearch = &apiHandlers.ServiceSearchHandler{
+		SearchServices: searchServicesUseCase,
+	}
 	handlers.ServiceGetByID = &apiHandlers.ServiceGetByIDHandler{
 		GetServiceByID: getServiceByIDUseCase,
 	}
+	handlers.ProviderProfile = &apiHandlers.ProviderProfile{
+		UseCase: getProviderProfileUseCase,
+	}
 	handlers.ServiceAvailability = &apiHandlers.ServiceAvailabilityHandler{
 		GetServiceAvailability: getServiceAvailabilityUseCase,
 	}
@@ -196,7 +639,98 @@ func Start() *HandlerContainer {
 	handlers.AppointmentUpdateStatus = &apiHandlers.AppointmentUpdateStatusHandler{
 		UpdateAppointmentStatus: updateAppointmentStatusUseCase,
 	}
+	handlers.ServiceExceptionList = &apiHandlers.ServiceExceptionListHandler{
+		ListServiceExceptions: listServiceExceptionsUseCase,
+	}
+	handlers.ServiceExceptionCreate = &apiHandlers.ServiceExceptionCreateHandler{
+		CreateServiceException: createServiceExceptionUseCase,
+	}
+	handlers.ServiceExceptionUpdate = &apiHandlers.ServiceExceptionUpdateHandler{
+		UpdateServiceException: updateServiceExceptionUseCase,
+	}
+	handlers.ServiceExceptionDelete = &apiHandlers.ServiceExceptionDeleteHandler{
+		DeleteServiceException: deleteServiceExceptionUseCase,
+	}
+	handlers.ServiceImageList = &apiHandlers.ServiceImageListHandler{
+		ListServiceImages: listServiceImagesUseCase,
+	}
+	handlers.ServiceImageUpload = &apiHandlers.ServiceImageUploadHandler{
+		UploadServiceImage: uploadServiceImageUseCase,
+	}
+	handlers.ServiceImageDelete = &apiHandlers.ServiceImageDeleteHandler{
+		DeleteServiceImage: deleteServiceImageUseCase,
+	}
+	handlers.ServiceImageReorder = &apiHandlers.ServiceImageReorderHandler{
+		ReorderServiceImages: reorderServiceImagesUseCase,
+	}
+	handlers.Media = &apiHandlers.MediaHandler{
+		BlobStore: blobStore,
+	}
+	handlers.ReviewCreate = &apiHandlers.ReviewCreateHandler{
+		CreateReview: createReviewUseCase,
+	}
+	handlers.ReviewReply = &apiHandlers.ReviewReplyHandler{
+		ReplyReview: replyReviewUseCase,
+	}
+	handlers.ServiceReviews = &apiHandlers.ServiceReviewsHandler{
+		ListServiceReviews: listServiceReviewsUseCase,
+	}
 	handlers.Categories = &apiHandlers.CategoriesHandler{}
+	handlers.JWKS = &apiHandlers.JWKSHandler{
+		Keys: jwtKeys,
+	}
+	handlers.AdminUserList = &apiHandlers.AdminUserListHandler{
+		ListUsers: adminListUsersUseCase,
+	}
+	handlers.AdminUserSuspend = &apiHandlers.AdminUserSuspendHandler{
+		SuspendUser: adminSuspendUserUseCase,
+	}
+	handlers.AdminUserReactivate = &apiHandlers.AdminUserReactivateHandler{
+		ReactivateUser: adminReactivateUserUseCase,
+	}
+	handlers.AdminServiceList = &apiHandlers.AdminServiceListHandler{
+		ListServices: adminListServicesUseCase,
+	}
+	handlers.AdminServiceDeactivate = &apiHandlers.AdminServiceDeactivateHandler{
+		DeactivateService: adminDeactivateServiceUseCase,
+	}
+	handlers.AdminServiceDelete = &apiHandlers.AdminServiceDeleteHandler{
+		DeleteService: adminDeleteServiceUseCase,
+	}
+	handlers.AdminAppointmentList = &apiHandlers.AdminAppointmentListHandler{
+		ListAppointments: adminListAppointmentsUseCase,
+	}
+	handlers.AdminAppointmentCancel = &apiHandlers.AdminAppointmentCancelHandler{
+		CancelAppointment: adminCancelAppointmentUseCase,
+	}
+	handlers.AdminAppointmentReassign = &apiHandlers.AdminAppointmentReassignHandler{
+		ReassignAppointment: adminReassignAppointmentUseCase,
+	}
+	handlers.AdminAuditLog = &apiHandlers.AdminAuditLogHandler{
+		ListAuditLog: adminListAuditLogUseCase,
+	}
+
+	// La bandeja expone los correos (con enlaces de restablecimiento): solo con APP_ENV=development
+	// configurado explícitamente; el valor por defecto de APP_ENV no se escribe en el entorno
+	if mailbox, ok := deliveryService.(*mail.FileEmailService); ok && os.Getenv("APP_ENV") == "development" {
+		handlers.DevMailboxList = &apiHandlers.DevMailboxListHandler{
+			Mailbox: mailbox,
+		}
+		handlers.DevMailboxMessage = &apiHandlers.DevMailboxMessageHandler{
+			Mailbox: mailbox,
+		}
+	}
+
+	handlers.Auth = middleware.AuthMiddleware(jwtKeys, sessionRepo)
+	handlers.OptionalAuth = middleware.OptionalAuthMiddleware(jwtKeys, sessionRepo)
+
+	// Workers
+	// Sin base de datos no hay cola ni bajas que procesar: los workers solo fallarían en cada vuelta
+	if db != nil {
+		handlers.Workers = []workers.Worker{emailOutboxWorker, workers.NewAccountDeletionWorker(userRepo)}
+	} else {
+		fmt.Println("WARNING: database unavailable, background workers not started")
+	}
 
 	return &handlers
 }
@@ -0,0 +1,60 @@
+package handlers
+
+import (
+	"net/http"
+
+	"iycds2025_api/src/api/core/entities"
+	"iycds2025_api/src/api/core/errors"
+	"iycds2025_api/src/api/core/policy"
+	"iycds2025_api/src/api/core/usecases/admin"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AdminAppointmentListHandler struct {
+	ListAppointments admin.ListAppointments
+}
+
+func (h *AdminAppointmentListHandler) Handle(c *gin.Context) {
+	actor, exists := c.Get("actor")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "User not authenticated",
+		})
+		return
+	}
+
+	// Filtros opcionales: ?status=...&service_id=N&client_id=N&provider_id=N&from=YYYY-MM-DD&to=YYYY-MM-DD
+	filter := &entities.AppointmentFilter{
+		Status:   c.Query("status"),
+		DateFrom: c.Query("from"),
+		DateTo:   c.Query("to"),
+	}
+	if !bindOptionalID(c, "service_id", &filter.ServiceID) ||
+		!bindOptionalID(c, "client_id", &filter.ClientID) ||
+		!bindOptionalID(c, "provider_id", &filter.ProviderID) {
+		return
+	}
+	if !bindPagination(c, &filter.Page, &filter.PageSize) {
+		return
+	}
+
+	response, err := h.ListAppointments.Execute(c.Request.Context(), filter, actor.(policy.Actor))
+	if err != nil {
+		if apiErr, ok := err.(*errors.APIError); ok {
+			c.JSON(apiErr.Code, gin.H{
+				"error": apiErr.Message,
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Appointments retrieved successfully",
+		"data":    response,
+	})
+}
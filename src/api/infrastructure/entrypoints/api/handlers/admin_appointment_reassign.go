@@ -0,0 +1,72 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"iycds2025_api/src/api/core/entities"
+	"iycds2025_api/src/api/core/errors"
+	"iycds2025_api/src/api/core/policy"
+	"iycds2025_api/src/api/core/usecases/admin"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+type AdminAppointmentReassignHandler struct {
+	ReassignAppointment admin.ReassignAppointment
+}
+
+func (h *AdminAppointmentReassignHandler) Handle(c *gin.Context) {
+	actor, exists := c.Get("actor")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "User not authenticated",
+		})
+		return
+	}
+
+	// Obtener ID de la cita desde URL
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid appointment ID",
+		})
+		return
+	}
+
+	// Nuevo servicio, fecha u horario (los campos vacíos no cambian) y el motivo, que queda en la auditoría
+	var req entities.AppointmentReassign
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid request body: " + err.Error(),
+		})
+		return
+	}
+	validate := validator.New()
+	if err := validate.Struct(req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid input data: " + err.Error(),
+		})
+		return
+	}
+
+	response, err := h.ReassignAppointment.Execute(c.Request.Context(), id, &req, actor.(policy.Actor), clientInfo(c))
+	if err != nil {
+		if apiErr, ok := err.(*errors.APIError); ok {
+			c.JSON(apiErr.Code, gin.H{
+				"error": apiErr.Message,
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Appointment reassigned successfully",
+		"data":    response,
+	})
+}
@@ -0,0 +1,57 @@
+package handlers
+
+import (
+	"net/http"
+
+	"iycds2025_api/src/api/core/entities"
+	"iycds2025_api/src/api/core/errors"
+	"iycds2025_api/src/api/core/policy"
+	"iycds2025_api/src/api/core/usecases/admin"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AdminAuditLogHandler struct {
+	ListAuditLog admin.ListAuditLog
+}
+
+func (h *AdminAuditLogHandler) Handle(c *gin.Context) {
+	actor, exists := c.Get("actor")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "User not authenticated",
+		})
+		return
+	}
+
+	// Filtros opcionales: ?actor_id=N&action=user.suspend&target_type=user&target_id=N
+	filter := &entities.AuditFilter{
+		Action:     c.Query("action"),
+		TargetType: c.Query("target_type"),
+	}
+	if !bindOptionalID(c, "actor_id", &filter.ActorID) || !bindOptionalID(c, "target_id", &filter.TargetID) {
+		return
+	}
+	if !bindPagination(c, &filter.Page, &filter.PageSize) {
+		return
+	}
+
+	response, err := h.ListAuditLog.Execute(c.Request.Context(), filter, actor.(policy.Actor))
+	if err != nil {
+		if apiErr, ok := err.(*errors.APIError); ok {
+			c.JSON(apiErr.Code, gin.H{
+				"error": apiErr.Message,
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Audit log retrieved successfully",
+		"data":    response,
+	})
+}
@@ -0,0 +1,58 @@
+package handlers
+
+import (
+	"net/http"
+
+	"iycds2025_api/src/api/core/entities"
+	"iycds2025_api/src/api/core/errors"
+	"iycds2025_api/src/api/core/policy"
+	"iycds2025_api/src/api/core/usecases/admin"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AdminServiceListHandler struct {
+	ListServices admin.ListServices
+}
+
+func (h *AdminServiceListHandler) Handle(c *gin.Context) {
+	actor, exists := c.Get("actor")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "User not authenticated",
+		})
+		return
+	}
+
+	// Filtros opcionales: ?q=texto&status=active|inactive&category=...&user_id=N&page=N&page_size=N
+	filter := &entities.AdminServiceFilter{
+		Query:    c.Query("q"),
+		Status:   c.Query("status"),
+		Category: c.Query("category"),
+	}
+	if !bindOptionalID(c, "user_id", &filter.UserID) {
+		return
+	}
+	if !bindPagination(c, &filter.Page, &filter.PageSize) {
+		return
+	}
+
+	response, err := h.ListServices.Execute(c.Request.Context(), filter, actor.(policy.Actor))
+	if err != nil {
+		if apiErr, ok := err.(*errors.APIError); ok {
+			c.JSON(apiErr.Code, gin.H{
+				"error": apiErr.Message,
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Services retrieved successfully",
+		"data":    response,
+	})
+}
@@ -0,0 +1,54 @@
+package handlers
+
+import (
+	"net/http"
+
+	"iycds2025_api/src/api/core/entities"
+	"iycds2025_api/src/api/core/errors"
+	"iycds2025_api/src/api/core/policy"
+	"iycds2025_api/src/api/core/usecases/admin"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AdminUserListHandler struct {
+	ListUsers admin.ListUsers
+}
+
+func (h *AdminUserListHandler) Handle(c *gin.Context) {
+	actor, exists := c.Get("actor")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "User not authenticated",
+		})
+		return
+	}
+
+	// Filtros opcionales: ?q=texto&status=active|suspended&page=N&page_size=N
+	filter := &entities.UserFilter{
+		Query:  c.Query("q"),
+		Status: c.Query("status"),
+	}
+	if !bindPagination(c, &filter.Page, &filter.PageSize) {
+		return
+	}
+
+	response, err := h.ListUsers.Execute(c.Request.Context(), filter, actor.(policy.Actor))
+	if err != nil {
+		if apiErr, ok := err.(*errors.APIError); ok {
+			c.JSON(apiErr.Code, gin.H{
+				"error": apiErr.Message,
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Users retrieved successfully",
+		"data":    response,
+	})
+}
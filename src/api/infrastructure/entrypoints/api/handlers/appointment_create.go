@@ -4,6 +4,7 @@ import (
 	"net/http"
 
 	"iycds2025_api/src/api/core/entities"
+	"iycds2025_api/src/api/core/errors"
 	"iycds2025_api/src/api/core/usecases/appointment"
 
 	"github.com/gin-gonic/gin"
@@ -45,25 +46,15 @@ func (h *AppointmentCreateHandler) Handle(c *gin.Context) {
 	// Ejecutar use case
 	response, err := h.CreateAppointment.Execute(c.Request.Context(), &appointmentReq, userID.(int64))
 	if err != nil {
-		// Manejar diferentes tipos de errores
-		switch {
-		case err.Error() == "Service not found":
-			c.JSON(http.StatusNotFound, gin.H{
-				"error": err.Error(),
-			})
-		case err.Error() == "Time slot is already occupied or service not found":
-			c.JSON(http.StatusConflict, gin.H{
-				"error": "Time slot is already occupied",
-			})
-		case err.Error() == "Cannot create appointment for your own service":
-			c.JSON(http.StatusBadRequest, gin.H{
-				"error": err.Error(),
-			})
-		default:
-			c.JSON(http.StatusInternalServerError, gin.H{
-				"error": err.Error(),
+		if apiErr, ok := err.(*errors.APIError); ok {
+			c.JSON(apiErr.Code, gin.H{
+				"error": apiErr.Message,
 			})
+			return
 		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
 		return
 	}
 
@@ -5,6 +5,8 @@ import (
 	"strconv"
 
 	"iycds2025_api/src/api/core/entities"
+	"iycds2025_api/src/api/core/errors"
+	"iycds2025_api/src/api/core/policy"
 	"iycds2025_api/src/api/core/usecases/appointment"
 
 	"github.com/gin-gonic/gin"
@@ -16,8 +18,8 @@ type AppointmentUpdateStatusHandler struct {
 }
 
 func (h *AppointmentUpdateStatusHandler) Handle(c *gin.Context) {
-	// Obtener userID del contexto
-	userID, exists := c.Get("userID")
+	// Obtener el usuario autenticado (id, rol y permisos) del contexto
+	actor, exists := c.Get("actor")
 	if !exists {
 		c.JSON(http.StatusUnauthorized, gin.H{
 			"error": "User not authenticated",
@@ -54,25 +56,17 @@ func (h *AppointmentUpdateStatusHandler) Handle(c *gin.Context) {
 	}
 
 	// Ejecutar use case
-	err = h.UpdateAppointmentStatus.Execute(c.Request.Context(), appointmentID, req.Status, userID.(int64))
+	err = h.UpdateAppointmentStatus.Execute(c.Request.Context(), appointmentID, req.Status, actor.(policy.Actor))
 	if err != nil {
-		// Manejar diferentes tipos de errores
-		switch {
-		case err.Error() == "Appointment not found":
-			c.JSON(http.StatusNotFound, gin.H{
-				"error": err.Error(),
-			})
-		case err.Error() == "You don't have permission to cancel this appointment" ||
-			 err.Error() == "Only the service provider can accept or reject appointments" ||
-			 err.Error() == "Only the service provider can mark appointments as completed":
-			c.JSON(http.StatusForbidden, gin.H{
-				"error": err.Error(),
-			})
-		default:
-			c.JSON(http.StatusInternalServerError, gin.H{
-				"error": err.Error(),
+		if apiErr, ok := err.(*errors.APIError); ok {
+			c.JSON(apiErr.Code, gin.H{
+				"error": apiErr.Message,
 			})
+			return
 		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
 		return
 	}
 
@@ -0,0 +1,91 @@
+package handlers
+
+import (
+	"net/http"
+
+	"iycds2025_api/src/api/services/mail"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DevMailboxListHandler lista los correos guardados por el backend de correo "file" (solo desarrollo)
+type DevMailboxListHandler struct {
+	Mailbox *mail.FileEmailService
+}
+
+func (h *DevMailboxListHandler) Handle(c *gin.Context) {
+	emails, err := h.Mailbox.List()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	// El listado no incluye los cuerpos para que sea liviano
+	summaries := make([]gin.H, 0, len(emails))
+	for _, email := range emails {
+		summaries = append(summaries, gin.H{
+			"id":         email.ID,
+			"to":         email.To,
+			"subject":    email.Subject,
+			"locale":     email.Locale,
+			"created_at": email.CreatedAt,
+		})
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Captured emails retrieved successfully",
+		"data":    summaries,
+	})
+}
+
+// DevMailboxMessageHandler muestra un correo guardado (solo desarrollo).
+// Por defecto devuelve el HTML tal como lo vería el destinatario; ?format=text|json|eml devuelve
+// el texto plano, el JSON con todos los datos o el mensaje MIME completo.
+type DevMailboxMessageHandler struct {
+	Mailbox *mail.FileEmailService
+}
+
+func (h *DevMailboxMessageHandler) Handle(c *gin.Context) {
+	id := c.Param("id")
+
+	email, err := h.Mailbox.Get(id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+	if email == nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Email not found",
+		})
+		return
+	}
+
+	switch c.DefaultQuery("format", "html") {
+	case "html":
+		c.Data(http.StatusOK, "text/html; charset=utf-8", []byte(email.HTML))
+	case "text":
+		c.Data(http.StatusOK, "text/plain; charset=utf-8", []byte(email.Text))
+	case "json":
+		c.JSON(http.StatusOK, gin.H{
+			"message": "Captured email retrieved successfully",
+			"data":    email,
+		})
+	case "eml":
+		eml, err := h.Mailbox.EML(id)
+		if err != nil || eml == nil {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": "Email not found",
+			})
+			return
+		}
+		c.Data(http.StatusOK, "message/rfc822", eml)
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid format. Use html, text, json or eml",
+		})
+	}
+}
@@ -0,0 +1,44 @@
+package handlers
+
+import (
+	"net/http"
+
+	"iycds2025_api/src/api/core/entities"
+	"iycds2025_api/src/api/core/errors"
+	"iycds2025_api/src/api/core/usecases/verification"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+type EmailVerify struct {
+	UseCase verification.VerifyEmail
+}
+
+func (handler *EmailVerify) Handle(c *gin.Context) {
+	validate := validator.New()
+
+	var verifyRequest entities.VerifyEmail
+	if err := c.ShouldBindJSON(&verifyRequest); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body", "details": err.Error()})
+		return
+	}
+
+	if err := validate.Struct(verifyRequest); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid input data", "details": err.Error()})
+		return
+	}
+
+	// Ejecutar el caso de uso
+	err := handler.UseCase.Execute(c.Request.Context(), &verifyRequest)
+	if err != nil {
+		if apiErr, ok := err.(*errors.APIError); ok {
+			c.JSON(apiErr.Code, gin.H{"error": apiErr.Message})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Email verified successfully"})
+}
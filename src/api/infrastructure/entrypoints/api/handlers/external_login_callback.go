@@ -0,0 +1,49 @@
+package handlers
+
+import (
+	"net/http"
+
+	"iycds2025_api/src/api/core/entities"
+	"iycds2025_api/src/api/core/errors"
+	"iycds2025_api/src/api/core/usecases/login"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+type ExternalLoginCallback struct {
+	UseCase login.ExternalLogin
+}
+
+func (handler *ExternalLoginCallback) Handle(c *gin.Context) {
+	validate := validator.New()
+
+	var callbackRequest entities.ExternalLoginCallback
+	if err := c.ShouldBindJSON(&callbackRequest); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body", "details": err.Error()})
+		return
+	}
+
+	if err := validate.Struct(callbackRequest); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid input data", "details": err.Error()})
+		return
+	}
+
+	result, err := handler.UseCase.Execute(c.Request.Context(), c.Param("provider"), &callbackRequest, clientInfo(c))
+	if err != nil {
+		if apiErr, ok := err.(*errors.APIError); ok {
+			c.JSON(apiErr.Code, gin.H{"error": apiErr.Message})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
+		return
+	}
+
+	// Igual que el login con contraseña: con 2FA activa se devuelve el desafío del segundo paso
+	if result.Challenge != nil {
+		c.JSON(http.StatusOK, result.Challenge)
+		return
+	}
+
+	c.JSON(http.StatusOK, result.Tokens)
+}
@@ -0,0 +1,26 @@
+package handlers
+
+import (
+	"net/http"
+
+	"iycds2025_api/src/api/core/entities"
+	"iycds2025_api/src/api/services/identity"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ExternalLoginProviders lista los proveedores de identidad configurados, para los botones de login
+type ExternalLoginProviders struct {
+	Providers identity.Providers
+}
+
+func (handler *ExternalLoginProviders) Handle(c *gin.Context) {
+	names := handler.Providers.Names()
+
+	providers := make([]entities.IdentityProviderInfo, len(names))
+	for i, name := range names {
+		providers[i] = entities.IdentityProviderInfo{Name: name}
+	}
+
+	c.JSON(http.StatusOK, gin.H{"providers": providers})
+}
@@ -0,0 +1,28 @@
+package handlers
+
+import (
+	"net/http"
+
+	"iycds2025_api/src/api/core/errors"
+	"iycds2025_api/src/api/core/usecases/login"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ExternalLoginStart struct {
+	UseCase login.StartExternalLogin
+}
+
+func (handler *ExternalLoginStart) Handle(c *gin.Context) {
+	response, err := handler.UseCase.Execute(c.Request.Context(), c.Param("provider"))
+	if err != nil {
+		if apiErr, ok := err.(*errors.APIError); ok {
+			c.JSON(apiErr.Code, gin.H{"error": apiErr.Message})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,20 @@
+package handlers
+
+import (
+	"net/http"
+
+	"iycds2025_api/src/api/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// JWKSHandler publica las claves públicas con las que otros servicios pueden verificar los access tokens
+type JWKSHandler struct {
+	Keys *utils.KeySet
+}
+
+func (h *JWKSHandler) Handle(c *gin.Context) {
+	// Cacheable por poco tiempo: al rotar claves, la nueva se publica antes de empezar a firmar con ella
+	c.Header("Cache-Control", "public, max-age=300")
+	c.JSON(http.StatusOK, h.Keys.JWKS())
+}
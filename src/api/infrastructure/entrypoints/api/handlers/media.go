@@ -0,0 +1,51 @@
+package handlers
+
+import (
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"iycds2025_api/src/api/services/storage"
+
+	"github.com/gin-gonic/gin"
+)
+
+// MediaHandler sirve los archivos del almacenamiento (fotos de los servicios y sus miniaturas)
+// cuando no hay una URL pública propia del bucket o de un CDN
+type MediaHandler struct {
+	BlobStore storage.BlobStore
+}
+
+func (h *MediaHandler) Handle(c *gin.Context) {
+	key := strings.TrimPrefix(c.Param("key"), "/")
+	if !storage.ValidKey(key) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "File not found"})
+		return
+	}
+
+	object, err := h.BlobStore.Get(c.Request.Context(), key)
+	if err != nil {
+		if err == storage.ErrNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "File not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
+		return
+	}
+	defer object.Body.Close()
+
+	// Cada clave es aleatoria y nunca se reescribe: el navegador puede cachearla sin volver a pedirla
+	c.Header("Content-Type", object.ContentType)
+	c.Header("Cache-Control", "public, max-age=31536000, immutable")
+	c.Header("X-Content-Type-Options", "nosniff")
+	if object.Size >= 0 {
+		c.Header("Content-Length", strconv.FormatInt(object.Size, 10))
+	}
+	c.Status(http.StatusOK)
+
+	if c.Request.Method == http.MethodHead {
+		return
+	}
+	io.Copy(c.Writer, object.Body)
+}
@@ -0,0 +1,51 @@
+package handlers
+
+import (
+	"net/http"
+
+	"iycds2025_api/src/api/core/entities"
+	"iycds2025_api/src/api/core/errors"
+	"iycds2025_api/src/api/core/usecases/password"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+type PasswordChange struct {
+	UseCase password.ChangePassword
+}
+
+func (handler *PasswordChange) Handle(c *gin.Context) {
+	// Obtener el usuario y la sesión del token
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+	sessionID, _ := c.Get("sessionID")
+
+	validate := validator.New()
+
+	var changeRequest entities.ChangePassword
+	if err := c.ShouldBindJSON(&changeRequest); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body", "details": err.Error()})
+		return
+	}
+
+	if err := validate.Struct(changeRequest); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid input data", "details": err.Error()})
+		return
+	}
+
+	err := handler.UseCase.Execute(c.Request.Context(), userID.(int64), sessionID.(int64), &changeRequest, clientInfo(c))
+	if err != nil {
+		if apiErr, ok := err.(*errors.APIError); ok {
+			c.JSON(apiErr.Code, gin.H{"error": apiErr.Message})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Password changed successfully"})
+}
@@ -0,0 +1,44 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"iycds2025_api/src/api/core/errors"
+	"iycds2025_api/src/api/core/usecases/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ProviderProfile struct {
+	UseCase service.GetProviderProfile
+}
+
+func (handler *ProviderProfile) Handle(c *gin.Context) {
+	providerID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || providerID <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid provider ID"})
+		return
+	}
+
+	// La ruta es pública: userID solo está presente si OptionalAuthMiddleware validó un token
+	var viewerID int64
+	if value, exists := c.Get("userID"); exists {
+		viewerID = value.(int64)
+	}
+
+	profile, err := handler.UseCase.Execute(c.Request.Context(), providerID, viewerID)
+	if err != nil {
+		if apiErr, ok := err.(*errors.APIError); ok {
+			c.JSON(apiErr.Code, gin.H{"error": apiErr.Message})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Provider retrieved successfully",
+		"data":    profile,
+	})
+}
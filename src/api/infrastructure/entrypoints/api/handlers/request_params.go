@@ -0,0 +1,55 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"iycds2025_api/src/api/core/entities"
+
+	"github.com/gin-gonic/gin"
+)
+
+// bindPagination lee los parámetros opcionales page y page_size; si alguno es inválido
+// responde 400 y devuelve false
+func bindPagination(c *gin.Context, page *int, pageSize *int) bool {
+	var err error
+	if pageParam := c.Query("page"); pageParam != "" {
+		if *page, err = strconv.Atoi(pageParam); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "Invalid page parameter",
+			})
+			return false
+		}
+	}
+	if pageSizeParam := c.Query("page_size"); pageSizeParam != "" {
+		if *pageSize, err = strconv.Atoi(pageSizeParam); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "Invalid page_size parameter",
+			})
+			return false
+		}
+	}
+	return true
+}
+
+// bindOptionalID lee un parámetro de query con un ID opcional; si es inválido responde 400 y devuelve false
+func bindOptionalID(c *gin.Context, name string, id *int64) bool {
+	value := c.Query(name)
+	if value == "" {
+		return true
+	}
+	parsed, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || parsed < 1 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid " + name + " parameter",
+		})
+		return false
+	}
+	*id = parsed
+	return true
+}
+
+// clientInfo identifica el dispositivo del request, para las sesiones y la auditoría
+func clientInfo(c *gin.Context) entities.ClientInfo {
+	return entities.ClientInfo{UserAgent: c.Request.UserAgent(), IPAddress: c.ClientIP()}
+}
@@ -0,0 +1,75 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"iycds2025_api/src/api/core/entities"
+	"iycds2025_api/src/api/core/errors"
+	"iycds2025_api/src/api/core/usecases/review"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+type ReviewCreateHandler struct {
+	CreateReview review.CreateReview
+}
+
+func (h *ReviewCreateHandler) Handle(c *gin.Context) {
+	// Obtener userID del contexto (cliente que deja la reseña)
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "User not authenticated",
+		})
+		return
+	}
+
+	// Obtener ID de la cita desde URL
+	appointmentID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid appointment ID",
+		})
+		return
+	}
+
+	// Parsear request body
+	var reviewReq entities.ReviewCreate
+	if err := c.ShouldBindJSON(&reviewReq); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid request body: " + err.Error(),
+		})
+		return
+	}
+
+	// Validar datos
+	validate := validator.New()
+	if err := validate.Struct(reviewReq); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid input data: " + err.Error(),
+		})
+		return
+	}
+
+	// Ejecutar use case
+	response, err := h.CreateReview.Execute(c.Request.Context(), appointmentID, &reviewReq, userID.(int64))
+	if err != nil {
+		if apiErr, ok := err.(*errors.APIError); ok {
+			c.JSON(apiErr.Code, gin.H{
+				"error": apiErr.Message,
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Review created successfully",
+		"data":    response,
+	})
+}
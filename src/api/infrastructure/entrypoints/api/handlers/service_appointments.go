@@ -4,6 +4,7 @@ import (
 	"net/http"
 	"strconv"
 
+	"iycds2025_api/src/api/core/policy"
 	"iycds2025_api/src/api/core/usecases/appointment"
 
 	"github.com/gin-gonic/gin"
@@ -14,8 +15,8 @@ type ServiceAppointmentsHandler struct {
 }
 
 func (h *ServiceAppointmentsHandler) Handle(c *gin.Context) {
-	// Obtener userID del contexto
-	userID, exists := c.Get("userID")
+	// Obtener el usuario autenticado (id, rol y permisos) del contexto
+	actor, exists := c.Get("actor")
 	if !exists {
 		c.JSON(http.StatusUnauthorized, gin.H{
 			"error": "User not authenticated",
@@ -34,7 +35,7 @@ func (h *ServiceAppointmentsHandler) Handle(c *gin.Context) {
 	}
 
 	// Ejecutar use case
-	response, err := h.ListServiceAppointments.Execute(c.Request.Context(), serviceID, userID.(int64))
+	response, err := h.ListServiceAppointments.Execute(c.Request.Context(), serviceID, actor.(policy.Actor))
 	if err != nil {
 		// Manejar diferentes tipos de errores
 		switch {
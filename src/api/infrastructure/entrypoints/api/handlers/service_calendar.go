@@ -24,8 +24,22 @@ func (h *ServiceCalendarHandler) Handle(c *gin.Context) {
 		return
 	}
 
+	// Parámetros opcionales: fecha de inicio y cantidad de días
+	startDate := c.Query("start")
+
+	days := service.DefaultCalendarDays
+	if daysParam := c.Query("days"); daysParam != "" {
+		days, err = strconv.Atoi(daysParam)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "Invalid days parameter",
+			})
+			return
+		}
+	}
+
 	// Ejecutar use case
-	result, apiErr := h.GetServiceCalendar.Execute(serviceID)
+	result, apiErr := h.GetServiceCalendar.Execute(c.Request.Context(), serviceID, startDate, days)
 
 	if apiErr != nil {
 		c.JSON(apiErr.Code, gin.H{
@@ -4,6 +4,7 @@ import (
 	"net/http"
 
 	"iycds2025_api/src/api/core/entities"
+	"iycds2025_api/src/api/core/errors"
 	"iycds2025_api/src/api/core/usecases/service"
 
 	"github.com/gin-gonic/gin"
@@ -35,6 +36,12 @@ func (h *ServiceCreateHandler) Handle(c *gin.Context) {
 	// Ejecutar use case
 	response, err := h.CreateService.Execute(c.Request.Context(), &serviceReq, userID.(int64))
 	if err != nil {
+		if apiErr, ok := err.(*errors.APIError); ok {
+			c.JSON(apiErr.Code, gin.H{
+				"error": apiErr.Message,
+			})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error": err.Error(),
 		})
@@ -4,6 +4,7 @@ import (
 	"net/http"
 	"strconv"
 
+	"iycds2025_api/src/api/core/policy"
 	"iycds2025_api/src/api/core/usecases/service"
 
 	"github.com/gin-gonic/gin"
@@ -14,8 +15,8 @@ type ServiceDeleteHandler struct {
 }
 
 func (h *ServiceDeleteHandler) Handle(c *gin.Context) {
-	// Obtener userID del contexto
-	userID, exists := c.Get("userID")
+	// Obtener el usuario autenticado (id, rol y permisos) del contexto
+	actor, exists := c.Get("actor")
 	if !exists {
 		c.JSON(http.StatusUnauthorized, gin.H{
 			"error": "User not authenticated",
@@ -34,7 +35,7 @@ func (h *ServiceDeleteHandler) Handle(c *gin.Context) {
 	}
 
 	// Ejecutar use case
-	err = h.DeleteService.Execute(c.Request.Context(), id, userID.(int64))
+	err = h.DeleteService.Execute(c.Request.Context(), id, actor.(policy.Actor))
 	if err != nil {
 		// Manejar diferentes tipos de errores
 		switch {
@@ -0,0 +1,77 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"iycds2025_api/src/api/core/entities"
+	"iycds2025_api/src/api/core/errors"
+	"iycds2025_api/src/api/core/policy"
+	"iycds2025_api/src/api/core/usecases/exception"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+type ServiceExceptionCreateHandler struct {
+	CreateServiceException exception.CreateServiceException
+}
+
+func (h *ServiceExceptionCreateHandler) Handle(c *gin.Context) {
+	// Obtener el usuario autenticado (id, rol y permisos) del contexto
+	actor, exists := c.Get("actor")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "User not authenticated",
+		})
+		return
+	}
+
+	// Obtener ID del servicio desde URL
+	idStr := c.Param("id")
+	serviceID, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid service ID",
+		})
+		return
+	}
+
+	// Parsear request body
+	var exceptionReq entities.ServiceExceptionRequest
+	if err := c.ShouldBindJSON(&exceptionReq); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid request body: " + err.Error(),
+		})
+		return
+	}
+
+	// Validar datos
+	validate := validator.New()
+	if err := validate.Struct(exceptionReq); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid input data: " + err.Error(),
+		})
+		return
+	}
+
+	// Ejecutar use case
+	response, err := h.CreateServiceException.Execute(c.Request.Context(), serviceID, &exceptionReq, actor.(policy.Actor))
+	if err != nil {
+		if apiErr, ok := err.(*errors.APIError); ok {
+			c.JSON(apiErr.Code, gin.H{
+				"error": apiErr.Message,
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Service exception created successfully",
+		"data":    response,
+	})
+}
@@ -0,0 +1,62 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"iycds2025_api/src/api/core/errors"
+	"iycds2025_api/src/api/core/policy"
+	"iycds2025_api/src/api/core/usecases/exception"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ServiceExceptionDeleteHandler struct {
+	DeleteServiceException exception.DeleteServiceException
+}
+
+func (h *ServiceExceptionDeleteHandler) Handle(c *gin.Context) {
+	// Obtener el usuario autenticado (id, rol y permisos) del contexto
+	actor, exists := c.Get("actor")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "User not authenticated",
+		})
+		return
+	}
+
+	// Obtener IDs del servicio y de la excepción desde URL
+	serviceID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid service ID",
+		})
+		return
+	}
+	exceptionID, err := strconv.ParseInt(c.Param("exceptionId"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid exception ID",
+		})
+		return
+	}
+
+	// Ejecutar use case
+	err = h.DeleteServiceException.Execute(c.Request.Context(), serviceID, exceptionID, actor.(policy.Actor))
+	if err != nil {
+		if apiErr, ok := err.(*errors.APIError); ok {
+			c.JSON(apiErr.Code, gin.H{
+				"error": apiErr.Message,
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Service exception deleted successfully",
+	})
+}
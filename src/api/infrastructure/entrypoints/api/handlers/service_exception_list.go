@@ -0,0 +1,47 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"iycds2025_api/src/api/core/errors"
+	"iycds2025_api/src/api/core/usecases/exception"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ServiceExceptionListHandler struct {
+	ListServiceExceptions exception.ListServiceExceptions
+}
+
+func (h *ServiceExceptionListHandler) Handle(c *gin.Context) {
+	// Obtener ID del servicio desde URL
+	idStr := c.Param("id")
+	serviceID, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid service ID",
+		})
+		return
+	}
+
+	// Ejecutar use case
+	response, err := h.ListServiceExceptions.Execute(c.Request.Context(), serviceID)
+	if err != nil {
+		if apiErr, ok := err.(*errors.APIError); ok {
+			c.JSON(apiErr.Code, gin.H{
+				"error": apiErr.Message,
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Service exceptions retrieved successfully",
+		"data":    response,
+	})
+}
@@ -0,0 +1,53 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"iycds2025_api/src/api/core/errors"
+	"iycds2025_api/src/api/core/policy"
+	"iycds2025_api/src/api/core/usecases/serviceimage"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ServiceImageListHandler struct {
+	ListServiceImages serviceimage.ListServiceImages
+}
+
+func (h *ServiceImageListHandler) Handle(c *gin.Context) {
+	// Obtener ID del servicio desde URL
+	serviceID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid service ID",
+		})
+		return
+	}
+
+	// La ruta es pública: actor solo está presente si OptionalAuthMiddleware validó un token
+	var actor policy.Actor
+	if value, exists := c.Get("actor"); exists {
+		actor = value.(policy.Actor)
+	}
+
+	// Ejecutar use case
+	response, err := h.ListServiceImages.Execute(c.Request.Context(), serviceID, actor)
+	if err != nil {
+		if apiErr, ok := err.(*errors.APIError); ok {
+			c.JSON(apiErr.Code, gin.H{
+				"error": apiErr.Message,
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Service images retrieved successfully",
+		"data":    response,
+	})
+}
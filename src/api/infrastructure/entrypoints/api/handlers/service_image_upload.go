@@ -0,0 +1,112 @@
+package handlers
+
+import (
+	stderrors "errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"iycds2025_api/src/api/core/errors"
+	"iycds2025_api/src/api/core/policy"
+	"iycds2025_api/src/api/core/usecases/serviceimage"
+	"iycds2025_api/src/api/services/images"
+
+	"github.com/gin-gonic/gin"
+)
+
+// multipartOverhead es el margen para los encabezados y separadores del formulario multipart
+const multipartOverhead = 64 << 10
+
+type ServiceImageUploadHandler struct {
+	UploadServiceImage serviceimage.UploadServiceImage
+}
+
+func (h *ServiceImageUploadHandler) Handle(c *gin.Context) {
+	// Obtener el usuario autenticado (id, rol y permisos) del contexto
+	actor, exists := c.Get("actor")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "User not authenticated",
+		})
+		return
+	}
+
+	// Obtener ID del servicio desde URL
+	serviceID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid service ID",
+		})
+		return
+	}
+
+	// Cortar la lectura del cuerpo apenas supere el límite, sin esperar a recibirlo completo
+	tooLarge := fmt.Sprintf("Image exceeds the maximum size of %d MB", images.MaxUploadBytes>>20)
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, images.MaxUploadBytes+multipartOverhead)
+
+	// Leer el archivo del campo "image" del formulario multipart
+	fileHeader, err := c.FormFile("image")
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if stderrors.As(err, &maxBytesErr) {
+			c.JSON(http.StatusRequestEntityTooLarge, gin.H{
+				"error": tooLarge,
+			})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Image file is required (multipart/form-data field \"image\")",
+		})
+		return
+	}
+	if fileHeader.Size > images.MaxUploadBytes {
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{
+			"error": tooLarge,
+		})
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Failed to read image file",
+		})
+		return
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(io.LimitReader(file, images.MaxUploadBytes+1))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Failed to read image file",
+		})
+		return
+	}
+	if len(data) > images.MaxUploadBytes {
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{
+			"error": tooLarge,
+		})
+		return
+	}
+
+	// Ejecutar use case
+	response, err := h.UploadServiceImage.Execute(c.Request.Context(), serviceID, data, actor.(policy.Actor))
+	if err != nil {
+		if apiErr, ok := err.(*errors.APIError); ok {
+			c.JSON(apiErr.Code, gin.H{
+				"error": apiErr.Message,
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Service image uploaded successfully",
+		"data":    response,
+	})
+}
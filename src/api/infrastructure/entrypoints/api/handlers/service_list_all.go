@@ -1,8 +1,12 @@
 package handlers
 
 import (
+	"math"
 	"net/http"
+	"strconv"
 
+	"iycds2025_api/src/api/core/entities"
+	"iycds2025_api/src/api/core/errors"
 	"iycds2025_api/src/api/core/usecases/service"
 
 	"github.com/gin-gonic/gin"
@@ -13,9 +17,54 @@ type ServiceListAllHandler struct {
 }
 
 func (h *ServiceListAllHandler) Handle(c *gin.Context) {
+	// Filtros opcionales del catálogo
+	filter := &entities.ServiceFilter{
+		Category: c.Query("category"),
+		Province: c.Query("province"),
+		Locality: c.Query("locality"),
+		Query:    c.Query("q"),
+		Sort:     c.Query("sort"),
+	}
+
+	var err error
+	if filter.MinPrice, err = parseOptionalFloat(c.Query("min_price")); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid min_price parameter",
+		})
+		return
+	}
+	if filter.MaxPrice, err = parseOptionalFloat(c.Query("max_price")); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid max_price parameter",
+		})
+		return
+	}
+	if pageParam := c.Query("page"); pageParam != "" {
+		if filter.Page, err = strconv.Atoi(pageParam); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "Invalid page parameter",
+			})
+			return
+		}
+	}
+	if pageSizeParam := c.Query("page_size"); pageSizeParam != "" {
+		if filter.PageSize, err = strconv.Atoi(pageSizeParam); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "Invalid page_size parameter",
+			})
+			return
+		}
+	}
+
 	// Ejecutar use case
-	response, err := h.ListAllServices.Execute(c.Request.Context())
+	response, err := h.ListAllServices.Execute(c.Request.Context(), filter)
 	if err != nil {
+		if apiErr, ok := err.(*errors.APIError); ok {
+			c.JSON(apiErr.Code, gin.H{
+				"error": apiErr.Message,
+			})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error": err.Error(),
 		})
@@ -27,3 +76,18 @@ func (h *ServiceListAllHandler) Handle(c *gin.Context) {
 		"data":    response,
 	})
 }
+
+// parseOptionalFloat convierte un parámetro numérico opcional; devuelve nil si está vacío
+func parseOptionalFloat(value string) (*float64, error) {
+	if value == "" {
+		return nil, nil
+	}
+	number, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return nil, err
+	}
+	if math.IsNaN(number) || math.IsInf(number, 0) {
+		return nil, strconv.ErrSyntax
+	}
+	return &number, nil
+}
@@ -0,0 +1,65 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"iycds2025_api/src/api/core/errors"
+	"iycds2025_api/src/api/core/usecases/review"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ServiceReviewsHandler struct {
+	ListServiceReviews review.ListServiceReviews
+}
+
+func (h *ServiceReviewsHandler) Handle(c *gin.Context) {
+	// Obtener ID del servicio desde URL
+	serviceID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid service ID",
+		})
+		return
+	}
+
+	// Parámetros opcionales de paginación
+	var page, pageSize int
+	if pageParam := c.Query("page"); pageParam != "" {
+		if page, err = strconv.Atoi(pageParam); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "Invalid page parameter",
+			})
+			return
+		}
+	}
+	if pageSizeParam := c.Query("page_size"); pageSizeParam != "" {
+		if pageSize, err = strconv.Atoi(pageSizeParam); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "Invalid page_size parameter",
+			})
+			return
+		}
+	}
+
+	// Ejecutar use case
+	response, err := h.ListServiceReviews.Execute(c.Request.Context(), serviceID, page, pageSize)
+	if err != nil {
+		if apiErr, ok := err.(*errors.APIError); ok {
+			c.JSON(apiErr.Code, gin.H{
+				"error": apiErr.Message,
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Service reviews retrieved successfully",
+		"data":    response,
+	})
+}
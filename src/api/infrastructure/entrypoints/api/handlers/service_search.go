@@ -0,0 +1,65 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"iycds2025_api/src/api/core/errors"
+	"iycds2025_api/src/api/core/usecases/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ServiceSearchHandler struct {
+	SearchServices service.SearchServices
+}
+
+func (h *ServiceSearchHandler) Handle(c *gin.Context) {
+	query := c.Query("q")
+	if query == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Query parameter q is required",
+		})
+		return
+	}
+
+	// Parámetros opcionales de paginación
+	var page, pageSize int
+	var err error
+	if pageParam := c.Query("page"); pageParam != "" {
+		if page, err = strconv.Atoi(pageParam); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "Invalid page parameter",
+			})
+			return
+		}
+	}
+	if pageSizeParam := c.Query("page_size"); pageSizeParam != "" {
+		if pageSize, err = strconv.Atoi(pageSizeParam); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "Invalid page_size parameter",
+			})
+			return
+		}
+	}
+
+	// Ejecutar use case
+	response, err := h.SearchServices.Execute(c.Request.Context(), query, page, pageSize)
+	if err != nil {
+		if apiErr, ok := err.(*errors.APIError); ok {
+			c.JSON(apiErr.Code, gin.H{
+				"error": apiErr.Message,
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Services found successfully",
+		"data":    response,
+	})
+}
@@ -5,6 +5,8 @@ import (
 	"strconv"
 
 	"iycds2025_api/src/api/core/entities"
+	"iycds2025_api/src/api/core/errors"
+	"iycds2025_api/src/api/core/policy"
 	"iycds2025_api/src/api/core/usecases/service"
 
 	"github.com/gin-gonic/gin"
@@ -15,8 +17,8 @@ type ServiceUpdateHandler struct {
 }
 
 func (h *ServiceUpdateHandler) Handle(c *gin.Context) {
-	// Obtener userID del contexto
-	userID, exists := c.Get("userID")
+	// Obtener el usuario autenticado (id, rol y permisos) del contexto
+	actor, exists := c.Get("actor")
 	if !exists {
 		c.JSON(http.StatusUnauthorized, gin.H{
 			"error": "User not authenticated",
@@ -44,8 +46,14 @@ func (h *ServiceUpdateHandler) Handle(c *gin.Context) {
 	}
 
 	// Ejecutar use case
-	response, err := h.UpdateService.Execute(c.Request.Context(), id, &serviceReq, userID.(int64))
+	response, err := h.UpdateService.Execute(c.Request.Context(), id, &serviceReq, actor.(policy.Actor))
 	if err != nil {
+		if apiErr, ok := err.(*errors.APIError); ok {
+			c.JSON(apiErr.Code, gin.H{
+				"error": apiErr.Message,
+			})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error": err.Error(),
 		})
@@ -5,6 +5,8 @@ import (
 	"strconv"
 
 	"iycds2025_api/src/api/core/entities"
+	"iycds2025_api/src/api/core/errors"
+	"iycds2025_api/src/api/core/policy"
 	"iycds2025_api/src/api/core/usecases/service"
 
 	"github.com/gin-gonic/gin"
@@ -15,8 +17,8 @@ type ServiceUpdateStatusHandler struct {
 }
 
 func (h *ServiceUpdateStatusHandler) Handle(c *gin.Context) {
-	// Obtener userID del contexto
-	userID, exists := c.Get("userID")
+	// Obtener el usuario autenticado (id, rol y permisos) del contexto
+	actor, exists := c.Get("actor")
 	if !exists {
 		c.JSON(http.StatusUnauthorized, gin.H{
 			"error": "User not authenticated",
@@ -44,9 +46,15 @@ func (h *ServiceUpdateStatusHandler) Handle(c *gin.Context) {
 	}
 
 	// Ejecutar use case
-	err = h.UpdateStatusService.Execute(c.Request.Context(), id, req.Status, userID.(int64))
+	err = h.UpdateStatusService.Execute(c.Request.Context(), id, req.Status, actor.(policy.Actor))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
+		if apiErr, ok := err.(*errors.APIError); ok {
+			c.JSON(apiErr.Code, gin.H{
+				"error": apiErr.Message,
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
 			"error": err.Error(),
 		})
 		return
@@ -0,0 +1,50 @@
+package handlers
+
+import (
+	"net/http"
+
+	"iycds2025_api/src/api/core/entities"
+	"iycds2025_api/src/api/core/errors"
+	"iycds2025_api/src/api/core/usecases/twofactor"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+type TwoFactorConfirm struct {
+	UseCase twofactor.ConfirmTwoFactor
+}
+
+func (handler *TwoFactorConfirm) Handle(c *gin.Context) {
+	// Obtener userID del contexto (establecido por AuthMiddleware)
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	validate := validator.New()
+
+	var codeRequest entities.TwoFactorCode
+	if err := c.ShouldBindJSON(&codeRequest); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body", "details": err.Error()})
+		return
+	}
+
+	if err := validate.Struct(codeRequest); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid input data", "details": err.Error()})
+		return
+	}
+
+	response, err := handler.UseCase.Execute(c.Request.Context(), userID.(int64), &codeRequest)
+	if err != nil {
+		if apiErr, ok := err.(*errors.APIError); ok {
+			c.JSON(apiErr.Code, gin.H{"error": apiErr.Message})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
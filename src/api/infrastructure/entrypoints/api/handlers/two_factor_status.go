@@ -0,0 +1,35 @@
+package handlers
+
+import (
+	"net/http"
+
+	"iycds2025_api/src/api/core/errors"
+	"iycds2025_api/src/api/core/usecases/twofactor"
+
+	"github.com/gin-gonic/gin"
+)
+
+type TwoFactorStatus struct {
+	UseCase twofactor.TwoFactorStatus
+}
+
+func (handler *TwoFactorStatus) Handle(c *gin.Context) {
+	// Obtener userID del contexto (establecido por AuthMiddleware)
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	response, err := handler.UseCase.Execute(c.Request.Context(), userID.(int64))
+	if err != nil {
+		if apiErr, ok := err.(*errors.APIError); ok {
+			c.JSON(apiErr.Code, gin.H{"error": apiErr.Message})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,57 @@
+package handlers
+
+import (
+	"net/http"
+
+	"iycds2025_api/src/api/core/entities"
+	"iycds2025_api/src/api/core/errors"
+	"iycds2025_api/src/api/core/usecases/user"
+
+	"github.com/gin-gonic/gin"
+)
+
+type UserExport struct {
+	UseCase user.ExportPersonalData
+}
+
+func (handler *UserExport) Handle(c *gin.Context) {
+	// Obtener userID del contexto (establecido por AuthMiddleware)
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	format := c.DefaultQuery("format", entities.ExportFormatJSON)
+	if format != entities.ExportFormatJSON && format != entities.ExportFormatZIP {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid format. Allowed values: json, zip"})
+		return
+	}
+
+	export, err := handler.UseCase.Execute(c.Request.Context(), userID.(int64))
+	if err != nil {
+		if apiErr, ok := err.(*errors.APIError); ok {
+			c.JSON(apiErr.Code, gin.H{"error": apiErr.Message})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
+		return
+	}
+
+	// Son datos personales: ningún proxy ni el navegador debe guardar una copia
+	fileName := user.ExportFileName(export.Profile.ID, export.ExportedAt, format)
+	c.Header("Cache-Control", "no-store")
+	c.Header("Content-Disposition", `attachment; filename="`+fileName+`"`)
+
+	if format == entities.ExportFormatZIP {
+		archive, err := user.BuildExportArchive(export)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to build export archive"})
+			return
+		}
+		c.Data(http.StatusOK, "application/zip", archive)
+		return
+	}
+
+	c.IndentedJSON(http.StatusOK, export)
+}
@@ -29,7 +29,8 @@ func (handler *UserLogin) Handle(c *gin.Context) {
 		return
 	}
 
-	response, err := handler.UseCase.Execute(c.Request.Context(), &loginRequest)
+	client := clientInfo(c)
+	result, err := handler.UseCase.Execute(c.Request.Context(), &loginRequest, client)
 	if err != nil {
 		if apiErr, ok := err.(*errors.APIError); ok {
 			c.JSON(apiErr.Code, gin.H{"error": apiErr.Message})
@@ -39,5 +40,11 @@ func (handler *UserLogin) Handle(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"token": response})
+	// Con 2FA activa la respuesta es el desafío del segundo paso en lugar de los tokens
+	if result.Challenge != nil {
+		c.JSON(http.StatusOK, result.Challenge)
+		return
+	}
+
+	c.JSON(http.StatusOK, result.Tokens)
 }
@@ -0,0 +1,36 @@
+package handlers
+
+import (
+	"net/http"
+
+	"iycds2025_api/src/api/core/errors"
+	"iycds2025_api/src/api/core/usecases/login"
+
+	"github.com/gin-gonic/gin"
+)
+
+type UserLogout struct {
+	UseCase login.Logout
+}
+
+func (handler *UserLogout) Handle(c *gin.Context) {
+	// Obtener el usuario y la sesión del token
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+	sessionID, _ := c.Get("sessionID")
+
+	err := handler.UseCase.Execute(c.Request.Context(), userID.(int64), sessionID.(int64))
+	if err != nil {
+		if apiErr, ok := err.(*errors.APIError); ok {
+			c.JSON(apiErr.Code, gin.H{"error": apiErr.Message})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Logged out successfully"})
+}
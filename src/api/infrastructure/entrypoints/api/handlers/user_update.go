@@ -4,6 +4,7 @@ import (
 	"net/http"
 
 	"iycds2025_api/src/api/core/entities"
+	"iycds2025_api/src/api/core/errors"
 	"iycds2025_api/src/api/core/usecases/user"
 
 	"github.com/gin-gonic/gin"
@@ -45,26 +46,26 @@ func (h *UserUpdateHandler) Handle(c *gin.Context) {
 	// Ejecutar use case
 	response, err := h.UpdateUser.Execute(c.Request.Context(), userID.(int64), &userUpdate)
 	if err != nil {
-		// Manejar diferentes tipos de errores
-		switch {
-		case err.Error() == "User not found":
-			c.JSON(http.StatusNotFound, gin.H{
-				"error": err.Error(),
-			})
-		case err.Error() == "Email is already in use":
-			c.JSON(http.StatusConflict, gin.H{
-				"error": err.Error(),
-			})
-		default:
-			c.JSON(http.StatusInternalServerError, gin.H{
-				"error": err.Error(),
+		if apiErr, ok := err.(*errors.APIError); ok {
+			c.JSON(apiErr.Code, gin.H{
+				"error": apiErr.Message,
 			})
+			return
 		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
 		return
 	}
 
+	// Un cambio de email queda pendiente hasta que se confirma desde la nueva dirección
+	message := "User updated successfully"
+	if response.PendingEmail != "" {
+		message = "User updated successfully. Check your new email address to confirm the change"
+	}
+
 	c.JSON(http.StatusOK, gin.H{
-		"message": "User updated successfully",
+		"message": message,
 		"data":    response,
 	})
 }
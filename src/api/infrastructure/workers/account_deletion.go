@@ -0,0 +1,88 @@
+package workers
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"iycds2025_api/src/api/core/interfaces"
+)
+
+const (
+	// DefaultAccountDeletionPollInterval es cada cuánto se buscan cuentas con la baja vencida
+	DefaultAccountDeletionPollInterval = 10 * time.Minute
+	// DefaultAccountDeletionBatchSize es la cantidad máxima de cuentas que se anonimizan por vuelta
+	DefaultAccountDeletionBatchSize = 50
+)
+
+// AccountDeletionWorker anonimiza las cuentas cuyo período de gracia de baja ya venció
+type AccountDeletionWorker struct {
+	User         interfaces.User
+	PollInterval time.Duration
+	BatchSize    int
+}
+
+// NewAccountDeletionWorker crea el worker con los valores por defecto
+func NewAccountDeletionWorker(user interfaces.User) *AccountDeletionWorker {
+	return &AccountDeletionWorker{
+		User:         user,
+		PollInterval: DefaultAccountDeletionPollInterval,
+		BatchSize:    DefaultAccountDeletionBatchSize,
+	}
+}
+
+// Run procesa las bajas vencidas hasta que se cancela el contexto
+func (w *AccountDeletionWorker) Run(ctx context.Context) {
+	fmt.Printf("Account deletion worker started (poll every %s)\n", w.PollInterval)
+
+	ticker := time.NewTicker(w.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		// Si el lote vino lleno puede haber más cuentas pendientes: seguir sin esperar al tick
+		if w.processBatch(ctx) && ctx.Err() == nil {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			fmt.Println("Account deletion worker stopped")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// processBatch anonimiza un lote de cuentas vencidas. Devuelve true si el lote vino lleno y no hubo
+// errores; si alguna cuenta falla se espera al próximo tick para no reintentarla en un bucle.
+func (w *AccountDeletionWorker) processBatch(ctx context.Context) bool {
+	ids, err := w.User.ListDueDeletions(ctx, w.BatchSize)
+	if err != nil {
+		if ctx.Err() == nil {
+			fmt.Printf("Error listing due account deletions: %v\n", err)
+		}
+		return false
+	}
+
+	failed := false
+	for _, id := range ids {
+		if ctx.Err() != nil {
+			return false
+		}
+
+		err := w.User.Anonymize(ctx, id)
+		if err == sql.ErrNoRows {
+			// El usuario canceló la baja entre el listado y la anonimización
+			continue
+		}
+		if err != nil {
+			fmt.Printf("Error anonymizing user %d: %v\n", id, err)
+			failed = true
+			continue
+		}
+		fmt.Printf("User %d deleted and anonymized\n", id)
+	}
+
+	return len(ids) == w.BatchSize && !failed
+}
@@ -0,0 +1,148 @@
+package workers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"time"
+
+	"iycds2025_api/src/api/core/entities"
+	"iycds2025_api/src/api/core/interfaces"
+	"iycds2025_api/src/api/services/mail"
+)
+
+const (
+	// DefaultOutboxPollInterval es cada cuánto se buscan correos pendientes
+	DefaultOutboxPollInterval = 5 * time.Second
+	// DefaultOutboxBatchSize es la cantidad máxima de correos que se toman por vuelta
+	DefaultOutboxBatchSize = 20
+	// DefaultOutboxMaxAttempts es la cantidad de intentos antes de descartar un correo
+	DefaultOutboxMaxAttempts = 8
+	// DefaultOutboxBaseBackoff es la espera antes del segundo intento; se duplica en cada fallo
+	DefaultOutboxBaseBackoff = 30 * time.Second
+	// DefaultOutboxMaxBackoff es la espera máxima entre intentos
+	DefaultOutboxMaxBackoff = time.Hour
+	// DefaultOutboxLease es cuánto tiempo queda reservado un correo tomado por un worker;
+	// si el proceso muere a mitad del envío, otro worker lo retoma al vencer la reserva
+	DefaultOutboxLease = 2 * time.Minute
+)
+
+// maxLastErrorLength limita el error que se guarda para no llenar la tabla con respuestas enormes
+const maxLastErrorLength = 1000
+
+// EmailOutboxWorker entrega los correos de la tabla email_outbox con reintentos y backoff exponencial
+type EmailOutboxWorker struct {
+	Outbox       interfaces.EmailOutbox
+	EmailService mail.EmailService // servicio que envía de verdad (SendGrid, SMTP o mock)
+	PollInterval time.Duration
+	BatchSize    int
+	MaxAttempts  int
+	BaseBackoff  time.Duration
+	MaxBackoff   time.Duration
+	Lease        time.Duration
+}
+
+// NewEmailOutboxWorker crea el worker con los valores por defecto
+func NewEmailOutboxWorker(outbox interfaces.EmailOutbox, emailService mail.EmailService) *EmailOutboxWorker {
+	return &EmailOutboxWorker{
+		Outbox:       outbox,
+		EmailService: emailService,
+		PollInterval: DefaultOutboxPollInterval,
+		BatchSize:    DefaultOutboxBatchSize,
+		MaxAttempts:  DefaultOutboxMaxAttempts,
+		BaseBackoff:  DefaultOutboxBaseBackoff,
+		MaxBackoff:   DefaultOutboxMaxBackoff,
+		Lease:        DefaultOutboxLease,
+	}
+}
+
+// Run procesa la cola hasta que se cancela el contexto
+func (w *EmailOutboxWorker) Run(ctx context.Context) {
+	fmt.Printf("Email outbox worker started (poll every %s)\n", w.PollInterval)
+
+	ticker := time.NewTicker(w.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		// Si el lote vino lleno puede haber más correos acumulados: seguir sin esperar al tick
+		if w.processBatch(ctx) == w.BatchSize && ctx.Err() == nil {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			fmt.Println("Email outbox worker stopped")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// processBatch toma un lote de correos vencidos, intenta enviarlos y devuelve cuántos tomó
+func (w *EmailOutboxWorker) processBatch(ctx context.Context) int {
+	messages, err := w.Outbox.ClaimDue(ctx, w.BatchSize, w.Lease)
+	if err != nil {
+		if ctx.Err() == nil {
+			fmt.Printf("Error claiming outbox emails: %v\n", err)
+		}
+		return 0
+	}
+
+	for _, message := range messages {
+		w.deliver(ctx, message)
+	}
+
+	return len(messages)
+}
+
+func (w *EmailOutboxWorker) deliver(ctx context.Context, message *entities.OutboxEmail) {
+	sendErr := mail.Deliver(w.EmailService, message)
+
+	// El resultado se registra aunque el worker se esté apagando
+	ctx = context.WithoutCancel(ctx)
+
+	if sendErr == nil {
+		if err := w.Outbox.MarkSent(ctx, message.ID); err != nil {
+			fmt.Printf("Error marking outbox email %d as sent: %v\n", message.ID, err)
+		}
+		return
+	}
+
+	lastError := sendErr.Error()
+	if len(lastError) > maxLastErrorLength {
+		lastError = lastError[:maxLastErrorLength]
+	}
+
+	// Los correos que no se pueden armar o que agotaron los intentos pasan a "dead"
+	if errors.Is(sendErr, mail.ErrInvalidOutboxEmail) || message.Attempts >= w.MaxAttempts {
+		fmt.Printf("Outbox email %d (%s to %s) dead after %d attempts: %v\n",
+			message.ID, message.Kind, message.Recipient, message.Attempts, sendErr)
+		if err := w.Outbox.MarkDead(ctx, message.ID, lastError); err != nil {
+			fmt.Printf("Error marking outbox email %d as dead: %v\n", message.ID, err)
+		}
+		return
+	}
+
+	retryIn := w.backoff(message.Attempts)
+	fmt.Printf("Outbox email %d (%s to %s) failed on attempt %d, retrying in %s: %v\n",
+		message.ID, message.Kind, message.Recipient, message.Attempts, retryIn, sendErr)
+	if err := w.Outbox.MarkFailed(ctx, message.ID, lastError, retryIn); err != nil {
+		fmt.Printf("Error rescheduling outbox email %d: %v\n", message.ID, err)
+	}
+}
+
+// backoff calcula la espera antes del próximo intento: BaseBackoff * 2^(intentos-1), con tope
+// en MaxBackoff y hasta un 20% de variación para que los reintentos no se sincronicen
+func (w *EmailOutboxWorker) backoff(attempts int) time.Duration {
+	wait := w.BaseBackoff
+	for i := 1; i < attempts && wait < w.MaxBackoff; i++ {
+		wait *= 2
+	}
+	if wait > w.MaxBackoff {
+		wait = w.MaxBackoff
+	}
+
+	jitter := time.Duration(rand.Int63n(int64(wait)/5 + 1))
+	return wait + jitter
+}
@@ -0,0 +1,8 @@
+package workers
+
+import "context"
+
+// Worker es un proceso en segundo plano que corre junto a la API hasta que se cancela el contexto
+type Worker interface {
+	Run(ctx context.Context)
+}
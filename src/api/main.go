@@ -1,7 +1,31 @@
 package main
 
-import "iycds2025_api/src/api/app"
+import (
+	"os"
+	// Base de zonas horarias IANA embebida: la imagen de producción puede no traer /usr/share/zoneinfo
+	_ "time/tzdata"
+
+	"iycds2025_api/src/api/app"
+)
 
 func main() {
+	// Subcomando para gestionar el esquema: migrate up|down|status
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		app.Migrate(os.Args[2:])
+		return
+	}
+
+	// Subcomando para consultar la cola de correos: outbox status|show|retry
+	if len(os.Args) > 1 && os.Args[1] == "outbox" {
+		app.Outbox(os.Args[2:])
+		return
+	}
+
+	// Subcomando para gestionar las claves de firma de los JWT: jwt-keys generate|list
+	if len(os.Args) > 1 && os.Args[1] == "jwt-keys" {
+		app.JWTKeys(os.Args[2:])
+		return
+	}
+
 	app.Start()
 }
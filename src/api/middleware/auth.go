@@ -1,17 +1,20 @@
 package middleware
 
 import (
+	"fmt"
 	"net/http"
 	"strings"
+	"time"
 
+	"iycds2025_api/src/api/core/interfaces"
+	"iycds2025_api/src/api/core/policy"
 	"iycds2025_api/src/api/utils"
 
 	"github.com/gin-gonic/gin"
-	"github.com/golang-jwt/jwt/v5"
 )
 
-// AuthMiddleware verifica el token JWT y extrae la información del usuario
-func AuthMiddleware() gin.HandlerFunc {
+// AuthMiddleware verifica el token JWT y que su sesión siga activa, y extrae la información del usuario
+func AuthMiddleware(keys *utils.KeySet, sessions interfaces.Session) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		authHeader := c.GetHeader("Authorization")
 		if authHeader == "" {
@@ -22,47 +25,75 @@ func AuthMiddleware() gin.HandlerFunc {
 			return
 		}
 
-		// Verificar que el header tenga el formato "Bearer token"
-		parts := strings.Split(authHeader, " ")
-		if len(parts) != 2 || parts[0] != "Bearer" {
-			c.JSON(http.StatusUnauthorized, gin.H{
-				"error": "Invalid authorization header format",
+		claims, status, message := authenticate(c, keys, sessions, authHeader)
+		if claims == nil {
+			c.JSON(status, gin.H{
+				"error": message,
 			})
 			c.Abort()
 			return
 		}
 
-		tokenString := parts[1]
-
-		// Parsear y validar el token
-		token, err := jwt.ParseWithClaims(tokenString, &utils.Claims{}, func(token *jwt.Token) (interface{}, error) {
-			return utils.JwtSecret, nil
-		})
+		setUserContext(c, claims)
+		c.Next()
+	}
+}
 
-		if err != nil || !token.Valid {
-			c.JSON(http.StatusUnauthorized, gin.H{
-				"error": "Invalid or expired token",
-			})
-			c.Abort()
-			return
+// OptionalAuthMiddleware es para endpoints públicos cuya respuesta cambia si el usuario inició
+// sesión. Sin token, o con uno inválido o de una sesión cerrada, el request sigue como anónimo.
+func OptionalAuthMiddleware(keys *utils.KeySet, sessions interfaces.Session) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		authHeader := c.GetHeader("Authorization")
+		if authHeader != "" {
+			if claims, _, _ := authenticate(c, keys, sessions, authHeader); claims != nil {
+				setUserContext(c, claims)
+			}
 		}
 
-		// Extraer claims del token
-		claims, ok := token.Claims.(*utils.Claims)
-		if !ok {
-			c.JSON(http.StatusUnauthorized, gin.H{
-				"error": "Invalid token claims",
-			})
-			c.Abort()
-			return
-		}
+		c.Next()
+	}
+}
 
-		// Almacenar información del usuario en el contexto
-		c.Set("userID", claims.ID)
-		c.Set("userRole", claims.Role)
-		c.Set("userPermissions", claims.Permissions)
-		c.Set("firstLogin", claims.FirstLogin)
+// authenticate valida el header Authorization. Si no es válido devuelve claims nil junto con el
+// código HTTP y el mensaje de error.
+func authenticate(c *gin.Context, keys *utils.KeySet, sessions interfaces.Session, authHeader string) (*utils.Claims, int, string) {
+	// Verificar que el header tenga el formato "Bearer token"
+	parts := strings.Split(authHeader, " ")
+	if len(parts) != 2 || parts[0] != "Bearer" {
+		return nil, http.StatusUnauthorized, "Invalid authorization header format"
+	}
 
-		c.Next()
+	tokenString := parts[1]
+
+	// Validar firma (según el kid del token), emisor y vencimiento
+	claims, err := keys.ParseJWT(tokenString)
+	if err != nil {
+		return nil, http.StatusUnauthorized, "Invalid or expired token"
+	}
+
+	// El token solo vale mientras su sesión no haya sido cerrada (logout, cambio de contraseña, etc.)
+	session, err := sessions.GetByID(c.Request.Context(), claims.SessionID)
+	if err != nil {
+		fmt.Printf("Error verifying session %d: %v\n", claims.SessionID, err)
+		return nil, http.StatusInternalServerError, "Failed to verify session"
+	}
+	if session == nil || session.UserID != claims.ID || !session.IsActive(time.Now()) {
+		return nil, http.StatusUnauthorized, "Session has been revoked"
 	}
+
+	return claims, 0, ""
+}
+
+// setUserContext almacena la información del usuario en el contexto
+func setUserContext(c *gin.Context, claims *utils.Claims) {
+	c.Set("userID", claims.ID)
+	c.Set("sessionID", claims.SessionID)
+	c.Set("userRole", claims.Role)
+	c.Set("userPermissions", claims.Permissions)
+	c.Set("firstLogin", claims.FirstLogin)
+	c.Set("actor", policy.Actor{
+		UserID:      claims.ID,
+		Role:        claims.Role,
+		Permissions: claims.Permissions,
+	})
 }
@@ -0,0 +1,53 @@
+package middleware
+
+import (
+	"net/http"
+
+	"iycds2025_api/src/api/core/policy"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RequireRole permite el acceso solo a usuarios con alguno de los roles indicados.
+// Debe usarse después de AuthMiddleware.
+func RequireRole(roles ...string) gin.HandlerFunc {
+	return authorize(func(actor policy.Actor) bool {
+		return actor.HasRole(roles...)
+	})
+}
+
+// RequirePermission permite el acceso solo a usuarios con todos los permisos indicados
+// (el rol admin los tiene todos). Debe usarse después de AuthMiddleware.
+func RequirePermission(permissions ...string) gin.HandlerFunc {
+	return authorize(func(actor policy.Actor) bool {
+		for _, permission := range permissions {
+			if !actor.Can(permission) {
+				return false
+			}
+		}
+		return true
+	})
+}
+
+func authorize(allowed func(policy.Actor) bool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		actor, exists := c.Get("actor")
+		if !exists {
+			c.JSON(http.StatusUnauthorized, gin.H{
+				"error": "User not authenticated",
+			})
+			c.Abort()
+			return
+		}
+
+		if !allowed(actor.(policy.Actor)) {
+			c.JSON(http.StatusForbidden, gin.H{
+				"error": "You don't have permission to perform this action",
+			})
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -0,0 +1,173 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"iycds2025_api/src/api/core/entities"
+)
+
+// ScheduleDeletion programa la baja de la cuenta. Los servicios se desactivan en el momento para que
+// nadie reserve un turno que no se va a atender, y se cierran todas las sesiones.
+func (r *UserRepository) ScheduleDeletion(ctx context.Context, userID int64, scheduledAt time.Time) error {
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// Si la baja ya estaba programada se conserva la fecha del primer pedido
+	query := `
+		UPDATE users
+		SET deletion_requested_at = COALESCE(deletion_requested_at, NOW()),
+		    deletion_scheduled_at = COALESCE(deletion_scheduled_at, ?),
+		    updated_at = NOW()
+		WHERE id = ? AND deleted_at IS NULL
+	`
+	result, err := tx.ExecContext(ctx, query, scheduledAt, userID)
+	if err != nil {
+		return err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+
+	if _, err := tx.ExecContext(ctx, `UPDATE services SET status = 'inactive', updated_at = NOW() WHERE user_id = ? AND status = 'active'`, userID); err != nil {
+		return err
+	}
+
+	if _, err := revokeUserSessions(ctx, tx, userID, 0); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// CancelDeletion cancela la baja mientras no haya vencido el período de gracia. Los servicios
+// desactivados al programarla no se reactivan solos: el usuario decide cuáles vuelve a publicar.
+func (r *UserRepository) CancelDeletion(ctx context.Context, userID int64) (bool, error) {
+	query := `
+		UPDATE users
+		SET deletion_requested_at = NULL, deletion_scheduled_at = NULL, updated_at = NOW()
+		WHERE id = ? AND deletion_scheduled_at IS NOT NULL AND deleted_at IS NULL
+	`
+	result, err := r.DB.ExecContext(ctx, query, userID)
+	if err != nil {
+		return false, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return rowsAffected > 0, nil
+}
+
+func (r *UserRepository) ListDueDeletions(ctx context.Context, limit int) ([]int64, error) {
+	query := `
+		SELECT id FROM users
+		WHERE deletion_scheduled_at <= NOW() AND deleted_at IS NULL
+		ORDER BY deletion_scheduled_at
+		LIMIT ?
+	`
+	rows, err := r.DB.QueryContext(ctx, query, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+
+	return ids, rows.Err()
+}
+
+// Anonymize reemplaza los datos personales de la cuenta y borra todo lo que permite identificarla o
+// iniciar sesión con ella. La fila de users no se borra: las citas y reseñas dependen de ella (ON DELETE
+// CASCADE) y la otra parte debe seguir viendo su historial, ahora con el nombre genérico.
+func (r *UserRepository) Anonymize(ctx context.Context, userID int64) error {
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// El bloqueo de la fila evita que una cancelación de último momento se cruce con la anonimización
+	var email string
+	query := `SELECT email FROM users WHERE id = ? AND deletion_scheduled_at <= NOW() AND deleted_at IS NULL FOR UPDATE`
+	if err := tx.QueryRowContext(ctx, query, userID).Scan(&email); err != nil {
+		return err
+	}
+
+	// El email tiene un índice único: se usa una dirección reservada (.invalid, RFC 2606) por cuenta
+	anonymizeQuery := `
+		UPDATE users
+		SET name = ?, display_name = '', bio = '', avatar_url = '',
+		    email = CONCAT('deleted-', id, '@', ?), email_verified_at = NULL, password = '',
+		    locality = '', province = '', phone = '', first_login = false, suspension_reason = '',
+		    failed_login_count = 0, last_failed_login_at = NULL, locked_until = NULL,
+		    deleted_at = NOW(), updated_at = NOW()
+		WHERE id = ?
+	`
+	if _, err := tx.ExecContext(ctx, anonymizeQuery, entities.DeletedUserName, entities.DeletedUserEmailDomain, userID); err != nil {
+		return err
+	}
+
+	// Credenciales, identidades externas y tokens pendientes. Borrar las sesiones invalida también
+	// los access tokens que todavía no vencieron.
+	deletes := []string{
+		`DELETE FROM user_sessions WHERE user_id = ?`,
+		`DELETE FROM user_identities WHERE user_id = ?`,
+		`DELETE FROM user_totp WHERE user_id = ?`,
+		`DELETE FROM user_recovery_codes WHERE user_id = ?`,
+		`DELETE FROM login_challenges WHERE user_id = ?`,
+		`DELETE FROM email_verification_tokens WHERE user_id = ?`,
+		`DELETE FROM password_reset_tokens WHERE user_id = ?`,
+	}
+	for _, statement := range deletes {
+		if _, err := tx.ExecContext(ctx, statement, userID); err != nil {
+			return err
+		}
+	}
+
+	// Los intentos de login se conservan para las estadísticas de seguridad, sin email, IP ni navegador
+	attemptsQuery := `UPDATE login_attempts SET email = '', ip_address = '', user_agent = '' WHERE user_id = ? OR email = ?`
+	if _, err := tx.ExecContext(ctx, attemptsQuery, userID, email); err != nil {
+		return err
+	}
+
+	// Los correos guardados en el outbox incluyen el email y el nombre en el payload
+	if _, err := tx.ExecContext(ctx, `DELETE FROM email_outbox WHERE recipient = ?`, email); err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, `UPDATE services SET status = 'inactive', updated_at = NOW() WHERE user_id = ?`, userID); err != nil {
+		return err
+	}
+
+	// Las citas que quedaron activas no se van a atender: se cancelan para liberar el horario. Se
+	// toma desde el día anterior porque las fechas están en la zona horaria de cada servicio; las
+	// citas pasadas se conservan tal como están.
+	appointmentsQuery := `
+		UPDATE appointments
+		SET status = 'cancelled', updated_at = NOW()
+		WHERE (client_id = ? OR provider_id = ?) AND status IN ('pending', 'accepted') AND date >= ?
+	`
+	fromDate := time.Now().AddDate(0, 0, -1).Format("2006-01-02")
+	if _, err := tx.ExecContext(ctx, appointmentsQuery, userID, userID, fromDate); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
@@ -3,8 +3,11 @@ package database
 import (
 	"context"
 	"database/sql"
+	"strings"
 
 	"iycds2025_api/src/api/core/entities"
+	"iycds2025_api/src/api/core/errors"
+	"iycds2025_api/src/api/utils"
 )
 
 type AppointmentRepository struct {
@@ -16,10 +19,18 @@ func NewAppointmentRepository(db *sql.DB) *AppointmentRepository {
 }
 
 func (r *AppointmentRepository) Create(ctx context.Context, appointmentReq *entities.AppointmentCreate, clientID int64) (*entities.Appointment, error) {
-	// Primero obtener el provider_id del servicio
+	// La verificación de conflicto y el INSERT se ejecutan en una única transacción
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	// Obtener el provider_id del servicio bloqueando su fila: las reservas
+	// concurrentes sobre el mismo servicio quedan serializadas hasta el commit
 	var providerID int64
-	serviceQuery := `SELECT user_id FROM services WHERE id = ? AND status = 'active'`
-	err := r.db.QueryRowContext(ctx, serviceQuery, appointmentReq.ServiceID).Scan(&providerID)
+	serviceQuery := `SELECT user_id FROM services WHERE id = ? AND status = 'active' FOR UPDATE`
+	err = tx.QueryRowContext(ctx, serviceQuery, appointmentReq.ServiceID).Scan(&providerID)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, sql.ErrNoRows // Servicio no encontrado o inactivo
@@ -27,33 +38,49 @@ func (r *AppointmentRepository) Create(ctx context.Context, appointmentReq *enti
 		return nil, err
 	}
 
-	// Verificar que no existe una cita en el mismo horario y fecha
+	// Verificar que ninguna cita activa del día se superpone con el horario pedido.
+	// Se comparan rangos y no strings porque la duración de los turnos puede cambiar.
 	conflictQuery := `
-		SELECT COUNT(*) FROM appointments 
-		WHERE service_id = ? AND date = ? AND time_slot = ? 
+		SELECT time_slot FROM appointments 
+		WHERE service_id = ? AND date = ? 
 		AND status IN ('pending', 'accepted')
 	`
-	var count int
-	err = r.db.QueryRowContext(ctx, conflictQuery, 
-		appointmentReq.ServiceID, appointmentReq.Date, appointmentReq.TimeSlot).Scan(&count)
+	rows, err := tx.QueryContext(ctx, conflictQuery, appointmentReq.ServiceID, appointmentReq.Date)
 	if err != nil {
 		return nil, err
 	}
-	if count > 0 {
-		return nil, sql.ErrNoRows // Usar como indicador de conflicto
+	var occupiedSlots []string
+	for rows.Next() {
+		var timeSlot string
+		if err := rows.Scan(&timeSlot); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		occupiedSlots = append(occupiedSlots, timeSlot)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if utils.IsSlotOccupied(appointmentReq.TimeSlot, occupiedSlots) {
+		return nil, errors.ErrSlotTaken
 	}
 
-	// Crear la cita
+	// Crear la cita. El índice único sobre los horarios activos es la última
+	// garantía contra reservas duplicadas
 	query := `
 		INSERT INTO appointments (service_id, client_id, provider_id, date, time_slot, status, notes, created_at, updated_at)
 		VALUES (?, ?, ?, ?, ?, 'pending', ?, NOW(), NOW())
 	`
 
-	result, err := r.db.ExecContext(ctx, query,
+	result, err := tx.ExecContext(ctx, query,
 		appointmentReq.ServiceID, clientID, providerID,
 		appointmentReq.Date, appointmentReq.TimeSlot, appointmentReq.Notes,
 	)
 	if err != nil {
+		if isDuplicateKeyError(err) {
+			return nil, errors.ErrSlotTaken
+		}
 		return nil, err
 	}
 
@@ -62,6 +89,10 @@ func (r *AppointmentRepository) Create(ctx context.Context, appointmentReq *enti
 		return nil, err
 	}
 
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
 	return r.GetByID(ctx, id)
 }
 
@@ -117,6 +148,32 @@ func (r *AppointmentRepository) GetByClientID(ctx context.Context, clientID int6
 	return appointments, nil
 }
 
+// GetByProviderID devuelve todas las citas de los servicios del proveedor, las más recientes primero
+func (r *AppointmentRepository) GetByProviderID(ctx context.Context, providerID int64) ([]*entities.Appointment, error) {
+	query := `
+		SELECT ` + appointmentColumns + `
+		FROM appointments WHERE provider_id = ?
+		ORDER BY date DESC, time_slot DESC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, providerID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var appointments []*entities.Appointment
+	for rows.Next() {
+		appointment, err := scanAppointment(rows)
+		if err != nil {
+			return nil, err
+		}
+		appointments = append(appointments, appointment)
+	}
+
+	return appointments, rows.Err()
+}
+
 func (r *AppointmentRepository) GetByServiceID(ctx context.Context, serviceID int64) ([]*entities.Appointment, error) {
 	query := `
 		SELECT id, service_id, client_id, provider_id, date, time_slot, status, notes, created_at, updated_at
@@ -177,6 +234,37 @@ func (r *AppointmentRepository) GetByServiceIDAndDate(ctx context.Context, servi
 	return appointments, nil
 }
 
+// GetByServiceIDAndDateRange obtiene las citas activas (pending/accepted) de un servicio entre dos fechas inclusive
+func (r *AppointmentRepository) GetByServiceIDAndDateRange(ctx context.Context, serviceID int64, startDate string, endDate string) ([]*entities.Appointment, error) {
+	query := `
+		SELECT id, service_id, client_id, provider_id, date, time_slot, status, notes, created_at, updated_at
+		FROM appointments WHERE service_id = ? AND date BETWEEN ? AND ? AND status IN ('pending', 'accepted')
+		ORDER BY date ASC, time_slot ASC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, serviceID, startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var appointments []*entities.Appointment
+	for rows.Next() {
+		var appointment entities.Appointment
+		err := rows.Scan(
+			&appointment.ID, &appointment.ServiceID, &appointment.ClientID, &appointment.ProviderID,
+			&appointment.Date, &appointment.TimeSlot, &appointment.Status, &appointment.Notes,
+			&appointment.CreatedAt, &appointment.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		appointments = append(appointments, &appointment)
+	}
+
+	return appointments, nil
+}
+
 func (r *AppointmentRepository) UpdateStatus(ctx context.Context, id int64, status string, userID int64) error {
 	query := `
 		UPDATE appointments 
@@ -223,3 +311,202 @@ func (r *AppointmentRepository) Delete(ctx context.Context, id int64, clientID i
 
 	return nil
 }
+
+// appointmentColumns son las columnas que se leen de la tabla appointments, en el orden que espera scanAppointment
+const appointmentColumns = `id, service_id, client_id, provider_id, date, time_slot, status, notes, created_at, updated_at`
+
+// List devuelve una página de citas que cumplen el filtro, las de fecha más reciente primero
+func (r *AppointmentRepository) List(ctx context.Context, filter *entities.AppointmentFilter) ([]*entities.Appointment, int, error) {
+	conditions := []string{"1 = 1"}
+	var args []interface{}
+
+	if filter.Status != "" {
+		conditions = append(conditions, "status = ?")
+		args = append(args, filter.Status)
+	}
+	if filter.ServiceID != 0 {
+		conditions = append(conditions, "service_id = ?")
+		args = append(args, filter.ServiceID)
+	}
+	if filter.ClientID != 0 {
+		conditions = append(conditions, "client_id = ?")
+		args = append(args, filter.ClientID)
+	}
+	if filter.ProviderID != 0 {
+		conditions = append(conditions, "provider_id = ?")
+		args = append(args, filter.ProviderID)
+	}
+	if filter.DateFrom != "" {
+		conditions = append(conditions, "date >= ?")
+		args = append(args, filter.DateFrom)
+	}
+	if filter.DateTo != "" {
+		conditions = append(conditions, "date <= ?")
+		args = append(args, filter.DateTo)
+	}
+
+	where := strings.Join(conditions, " AND ")
+
+	var total int
+	countQuery := `SELECT COUNT(*) FROM appointments WHERE ` + where
+	if err := r.db.QueryRowContext(ctx, countQuery, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+	if total == 0 {
+		return []*entities.Appointment{}, 0, nil
+	}
+
+	query := `
+		SELECT ` + appointmentColumns + `
+		FROM appointments
+		WHERE ` + where + `
+		ORDER BY date DESC, time_slot DESC, id DESC
+		LIMIT ? OFFSET ?
+	`
+	pageArgs := append(args, filter.PageSize, (filter.Page-1)*filter.PageSize)
+
+	rows, err := r.db.QueryContext(ctx, query, pageArgs...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	var appointments []*entities.Appointment
+	for rows.Next() {
+		appointment, err := scanAppointment(rows)
+		if err != nil {
+			return nil, 0, err
+		}
+		appointments = append(appointments, appointment)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, err
+	}
+
+	return appointments, total, nil
+}
+
+// Reassign mueve una cita activa a otro servicio, fecha u horario
+func (r *AppointmentRepository) Reassign(ctx context.Context, id int64, serviceID int64, date string, timeSlot string) (*entities.Appointment, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	// Bloquear la cita: solo se reasignan las que siguen activas
+	var currentProviderID int64
+	appointmentQuery := `SELECT provider_id FROM appointments WHERE id = ? AND status IN ('pending', 'accepted') FOR UPDATE`
+	if err := tx.QueryRowContext(ctx, appointmentQuery, id).Scan(&currentProviderID); err != nil {
+		return nil, err
+	}
+
+	// Bloquear el servicio de destino igual que al reservar, para serializar con las reservas nuevas
+	var providerID int64
+	serviceQuery := `SELECT user_id FROM services WHERE id = ? AND status = 'active' FOR UPDATE`
+	if err := tx.QueryRowContext(ctx, serviceQuery, serviceID).Scan(&providerID); err != nil {
+		return nil, err
+	}
+
+	// Verificar que ninguna otra cita activa del día se superpone con el horario de destino
+	conflictQuery := `
+		SELECT time_slot FROM appointments
+		WHERE service_id = ? AND date = ? AND id <> ?
+		AND status IN ('pending', 'accepted')
+	`
+	rows, err := tx.QueryContext(ctx, conflictQuery, serviceID, date, id)
+	if err != nil {
+		return nil, err
+	}
+	var occupiedSlots []string
+	for rows.Next() {
+		var occupied string
+		if err := rows.Scan(&occupied); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		occupiedSlots = append(occupiedSlots, occupied)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if utils.IsSlotOccupied(timeSlot, occupiedSlots) {
+		return nil, errors.ErrSlotTaken
+	}
+
+	// Si cambia el proveedor, la cita vuelve a quedar pendiente hasta que el nuevo proveedor la acepte
+	status := "status"
+	if providerID != currentProviderID {
+		status = "'pending'"
+	}
+	updateQuery := `
+		UPDATE appointments
+		SET service_id = ?, provider_id = ?, date = ?, time_slot = ?, status = ` + status + `, updated_at = NOW()
+		WHERE id = ?
+	`
+	if _, err := tx.ExecContext(ctx, updateQuery, serviceID, providerID, date, timeSlot, id); err != nil {
+		if isDuplicateKeyError(err) {
+			return nil, errors.ErrSlotTaken
+		}
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return r.GetByID(ctx, id)
+}
+
+// CountUpcomingByUser cuenta las citas activas (pendientes o aceptadas) desde fromDate en las que el
+// usuario participa como cliente o como proveedor
+func (r *AppointmentRepository) CountUpcomingByUser(ctx context.Context, userID int64, fromDate string) (int, error) {
+	var count int
+	query := `
+		SELECT COUNT(*) FROM appointments
+		WHERE (client_id = ? OR provider_id = ?) AND status IN ('pending', 'accepted') AND date >= ?
+	`
+	if err := r.db.QueryRowContext(ctx, query, userID, userID, fromDate).Scan(&count); err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+func (r *AppointmentRepository) CountCompletedByProvider(ctx context.Context, providerID int64) (int, error) {
+	var count int
+	query := `SELECT COUNT(*) FROM appointments WHERE provider_id = ? AND status = 'completed'`
+	if err := r.db.QueryRowContext(ctx, query, providerID).Scan(&count); err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+func (r *AppointmentRepository) HasAcceptedBooking(ctx context.Context, clientID int64, providerID int64) (bool, error) {
+	var exists bool
+	query := `
+		SELECT EXISTS(
+			SELECT 1 FROM appointments
+			WHERE client_id = ? AND provider_id = ? AND status IN ('accepted', 'completed')
+		)
+	`
+	if err := r.db.QueryRowContext(ctx, query, clientID, providerID).Scan(&exists); err != nil {
+		return false, err
+	}
+	return exists, nil
+}
+
+// scanAppointment escanea las columnas de appointmentColumns
+func scanAppointment(row rowScanner) (*entities.Appointment, error) {
+	var appointment entities.Appointment
+	err := row.Scan(
+		&appointment.ID, &appointment.ServiceID, &appointment.ClientID, &appointment.ProviderID,
+		&appointment.Date, &appointment.TimeSlot, &appointment.Status, &appointment.Notes,
+		&appointment.CreatedAt, &appointment.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &appointment, nil
+}
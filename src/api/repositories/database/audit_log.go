@@ -0,0 +1,123 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"strings"
+
+	"iycds2025_api/src/api/core/entities"
+)
+
+type AuditLogRepository struct {
+	db *sql.DB
+}
+
+func NewAuditLogRepository(db *sql.DB) *AuditLogRepository {
+	return &AuditLogRepository{db: db}
+}
+
+// auditColumns son las columnas que se leen de la tabla audit_log, en el orden que espera scanAuditEntry
+const auditColumns = `id, actor_id, action, target_type, target_id, details, ip_address, created_at`
+
+func (r *AuditLogRepository) Record(ctx context.Context, entry *entities.AuditEntry) error {
+	var details interface{}
+	if len(entry.Details) > 0 {
+		details = string(entry.Details)
+	}
+
+	query := `
+		INSERT INTO audit_log (actor_id, action, target_type, target_id, details, ip_address, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, NOW())
+	`
+	result, err := r.db.ExecContext(ctx, query,
+		entry.ActorID, entry.Action, entry.TargetType, entry.TargetID, details, truncate(entry.IPAddress, 45),
+	)
+	if err != nil {
+		return err
+	}
+
+	entry.ID, err = result.LastInsertId()
+	return err
+}
+
+// List devuelve una página de la auditoría, las acciones más recientes primero
+func (r *AuditLogRepository) List(ctx context.Context, filter *entities.AuditFilter) ([]*entities.AuditEntry, int, error) {
+	conditions := []string{"1 = 1"}
+	var args []interface{}
+
+	if filter.ActorID != 0 {
+		conditions = append(conditions, "actor_id = ?")
+		args = append(args, filter.ActorID)
+	}
+	if filter.Action != "" {
+		conditions = append(conditions, "action = ?")
+		args = append(args, filter.Action)
+	}
+	if filter.TargetType != "" {
+		conditions = append(conditions, "target_type = ?")
+		args = append(args, filter.TargetType)
+	}
+	if filter.TargetID != 0 {
+		conditions = append(conditions, "target_id = ?")
+		args = append(args, filter.TargetID)
+	}
+
+	where := strings.Join(conditions, " AND ")
+
+	var total int
+	countQuery := `SELECT COUNT(*) FROM audit_log WHERE ` + where
+	if err := r.db.QueryRowContext(ctx, countQuery, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+	if total == 0 {
+		return []*entities.AuditEntry{}, 0, nil
+	}
+
+	query := `
+		SELECT ` + auditColumns + `
+		FROM audit_log
+		WHERE ` + where + `
+		ORDER BY created_at DESC, id DESC
+		LIMIT ? OFFSET ?
+	`
+	pageArgs := append(args, filter.PageSize, (filter.Page-1)*filter.PageSize)
+
+	rows, err := r.db.QueryContext(ctx, query, pageArgs...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	var entries []*entities.AuditEntry
+	for rows.Next() {
+		entry, err := scanAuditEntry(rows)
+		if err != nil {
+			return nil, 0, err
+		}
+		entries = append(entries, entry)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, err
+	}
+
+	return entries, total, nil
+}
+
+// scanAuditEntry escanea las columnas de auditColumns
+func scanAuditEntry(row rowScanner) (*entities.AuditEntry, error) {
+	var entry entities.AuditEntry
+	var details sql.NullString
+	err := row.Scan(
+		&entry.ID, &entry.ActorID, &entry.Action, &entry.TargetType, &entry.TargetID,
+		&details, &entry.IPAddress, &entry.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if details.Valid {
+		entry.Details = json.RawMessage(details.String)
+	}
+
+	return &entry, nil
+}
@@ -0,0 +1,234 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"time"
+
+	"iycds2025_api/src/api/core/entities"
+)
+
+type EmailOutboxRepository struct {
+	db *sql.DB
+}
+
+func NewEmailOutboxRepository(db *sql.DB) *EmailOutboxRepository {
+	return &EmailOutboxRepository{db: db}
+}
+
+// outboxColumns son las columnas que se leen de la tabla email_outbox, en el orden que espera scanOutboxEmail
+const outboxColumns = `id, kind, recipient, payload, status, attempts, next_attempt_at, last_error, sent_at, created_at, updated_at`
+
+func (r *EmailOutboxRepository) Enqueue(ctx context.Context, kind string, recipient string, payload string) (int64, error) {
+	query := `
+		INSERT INTO email_outbox (kind, recipient, payload, status, attempts, next_attempt_at, created_at, updated_at)
+		VALUES (?, ?, ?, 'pending', 0, NOW(), NOW(), NOW())
+	`
+
+	result, err := r.db.ExecContext(ctx, query, kind, recipient, payload)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.LastInsertId()
+}
+
+// ClaimDue reserva los correos pendientes cuyo próximo intento ya venció, y los que quedaron
+// tomados por un worker que no terminó antes de que venciera su reserva.
+// SKIP LOCKED permite que varias instancias de la API procesen la cola sin pisarse.
+func (r *EmailOutboxRepository) ClaimDue(ctx context.Context, limit int, lease time.Duration) ([]*entities.OutboxEmail, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	selectQuery := `
+		SELECT id FROM email_outbox
+		WHERE (status = 'pending' AND next_attempt_at <= NOW())
+		OR (status = 'sending' AND locked_until < NOW())
+		ORDER BY next_attempt_at ASC
+		LIMIT ?
+		FOR UPDATE SKIP LOCKED
+	`
+	rows, err := tx.QueryContext(ctx, selectQuery, limit)
+	if err != nil {
+		return nil, err
+	}
+	var ids []interface{}
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(ids)), ", ")
+	updateQuery := `
+		UPDATE email_outbox
+		SET status = 'sending', attempts = attempts + 1,
+			locked_until = DATE_ADD(NOW(), INTERVAL ? SECOND), updated_at = NOW()
+		WHERE id IN (` + placeholders + `)
+	`
+	updateArgs := append([]interface{}{int(lease.Seconds())}, ids...)
+	if _, err := tx.ExecContext(ctx, updateQuery, updateArgs...); err != nil {
+		return nil, err
+	}
+
+	claimed, err := queryOutboxEmails(ctx, tx, `SELECT `+outboxColumns+` FROM email_outbox WHERE id IN (`+placeholders+`) ORDER BY id`, ids...)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return claimed, nil
+}
+
+func (r *EmailOutboxRepository) MarkSent(ctx context.Context, id int64) error {
+	query := `
+		UPDATE email_outbox
+		SET status = 'sent', sent_at = NOW(), locked_until = NULL, last_error = NULL, updated_at = NOW()
+		WHERE id = ?
+	`
+	_, err := r.db.ExecContext(ctx, query, id)
+	return err
+}
+
+func (r *EmailOutboxRepository) MarkFailed(ctx context.Context, id int64, lastError string, retryIn time.Duration) error {
+	query := `
+		UPDATE email_outbox
+		SET status = 'pending', last_error = ?, locked_until = NULL,
+			next_attempt_at = DATE_ADD(NOW(), INTERVAL ? SECOND), updated_at = NOW()
+		WHERE id = ?
+	`
+	_, err := r.db.ExecContext(ctx, query, lastError, int(retryIn.Seconds()), id)
+	return err
+}
+
+func (r *EmailOutboxRepository) MarkDead(ctx context.Context, id int64, lastError string) error {
+	query := `
+		UPDATE email_outbox
+		SET status = 'dead', last_error = ?, locked_until = NULL, updated_at = NOW()
+		WHERE id = ?
+	`
+	_, err := r.db.ExecContext(ctx, query, lastError, id)
+	return err
+}
+
+func (r *EmailOutboxRepository) Requeue(ctx context.Context, id int64) error {
+	query := `
+		UPDATE email_outbox
+		SET status = 'pending', attempts = 0, next_attempt_at = NOW(), updated_at = NOW()
+		WHERE id = ? AND status = 'dead'
+	`
+
+	result, err := r.db.ExecContext(ctx, query, id)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return sql.ErrNoRows // No existe o no está descartado
+	}
+
+	return nil
+}
+
+func (r *EmailOutboxRepository) GetByID(ctx context.Context, id int64) (*entities.OutboxEmail, error) {
+	query := `SELECT ` + outboxColumns + ` FROM email_outbox WHERE id = ?`
+
+	email, err := scanOutboxEmail(r.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return email, nil
+}
+
+// List devuelve los últimos correos de la cola, opcionalmente filtrados por estado
+func (r *EmailOutboxRepository) List(ctx context.Context, status string, limit int) ([]*entities.OutboxEmail, error) {
+	query := `SELECT ` + outboxColumns + ` FROM email_outbox`
+	var args []interface{}
+	if status != "" {
+		query += ` WHERE status = ?`
+		args = append(args, status)
+	}
+	query += ` ORDER BY id DESC LIMIT ?`
+	args = append(args, limit)
+
+	return queryOutboxEmails(ctx, r.db, query, args...)
+}
+
+// queryer permite ejecutar consultas tanto sobre *sql.DB como dentro de una *sql.Tx
+type queryer interface {
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+}
+
+func queryOutboxEmails(ctx context.Context, q queryer, query string, args ...interface{}) ([]*entities.OutboxEmail, error) {
+	rows, err := q.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var emails []*entities.OutboxEmail
+	for rows.Next() {
+		email, err := scanOutboxEmail(rows)
+		if err != nil {
+			return nil, err
+		}
+		emails = append(emails, email)
+	}
+
+	return emails, rows.Err()
+}
+
+func scanOutboxEmail(row rowScanner) (*entities.OutboxEmail, error) {
+	var email entities.OutboxEmail
+	var lastError sql.NullString
+	var sentAt sql.NullTime
+
+	err := row.Scan(
+		&email.ID,
+		&email.Kind,
+		&email.Recipient,
+		&email.Payload,
+		&email.Status,
+		&email.Attempts,
+		&email.NextAttemptAt,
+		&lastError,
+		&sentAt,
+		&email.CreatedAt,
+		&email.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	email.LastError = lastError.String
+	if sentAt.Valid {
+		email.SentAt = &sentAt.Time
+	}
+
+	return &email, nil
+}
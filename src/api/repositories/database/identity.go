@@ -0,0 +1,204 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"iycds2025_api/src/api/core/entities"
+	"iycds2025_api/src/api/core/errors"
+)
+
+type IdentityRepository struct {
+	db *sql.DB
+}
+
+func NewIdentityRepository(db *sql.DB) *IdentityRepository {
+	return &IdentityRepository{db: db}
+}
+
+const identityColumns = `id, user_id, provider, subject, email, created_at, last_login_at`
+
+func (r *IdentityRepository) CreateLoginState(ctx context.Context, state *entities.ExternalLoginState) error {
+	query := `
+		INSERT INTO external_login_states (provider, state_hash, code_verifier, nonce, expires_at, created_at)
+		VALUES (?, ?, ?, ?, ?, NOW())
+	`
+	result, err := r.db.ExecContext(ctx, query, state.Provider, state.StateHash, state.CodeVerifier, state.Nonce, state.ExpiresAt)
+	if err != nil {
+		return err
+	}
+
+	state.ID, err = result.LastInsertId()
+	return err
+}
+
+// ConsumeLoginState lee y marca el estado en una transacción, para que un mismo state no se
+// pueda canjear dos veces
+func (r *IdentityRepository) ConsumeLoginState(ctx context.Context, stateHash string) (*entities.ExternalLoginState, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	state := &entities.ExternalLoginState{StateHash: stateHash}
+	var usedAt sql.NullTime
+	query := `
+		SELECT id, provider, code_verifier, nonce, expires_at, used_at
+		FROM external_login_states
+		WHERE state_hash = ?
+		FOR UPDATE
+	`
+	err = tx.QueryRowContext(ctx, query, stateHash).Scan(&state.ID, &state.Provider, &state.CodeVerifier, &state.Nonce, &state.ExpiresAt, &usedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if usedAt.Valid || time.Now().After(state.ExpiresAt) {
+		return nil, nil
+	}
+
+	if _, err := tx.ExecContext(ctx, `UPDATE external_login_states SET used_at = NOW() WHERE id = ?`, state.ID); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return state, nil
+}
+
+func (r *IdentityRepository) GetByProviderSubject(ctx context.Context, provider string, subject string) (*entities.UserIdentity, error) {
+	query := `SELECT ` + identityColumns + ` FROM user_identities WHERE provider = ? AND subject = ?`
+
+	identity, err := scanIdentity(r.db.QueryRowContext(ctx, query, provider, subject))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return identity, nil
+}
+
+func (r *IdentityRepository) ListByUser(ctx context.Context, userID int64) ([]*entities.UserIdentity, error) {
+	query := `SELECT ` + identityColumns + ` FROM user_identities WHERE user_id = ? ORDER BY provider`
+
+	rows, err := r.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	identities := []*entities.UserIdentity{}
+	for rows.Next() {
+		identity, err := scanIdentity(rows)
+		if err != nil {
+			return nil, err
+		}
+		identities = append(identities, identity)
+	}
+
+	return identities, rows.Err()
+}
+
+func (r *IdentityRepository) Link(ctx context.Context, identity *entities.UserIdentity) error {
+	return r.insertIdentity(ctx, r.db, identity)
+}
+
+// CreateUserWithIdentity crea la cuenta y su identidad en una transacción. La cuenta queda con el
+// password vacío (no se puede iniciar sesión con contraseña) y con el email verificado por el proveedor.
+func (r *IdentityRepository) CreateUserWithIdentity(ctx context.Context, user *entities.User, identity *entities.UserIdentity) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	user.FirstLogin = true
+	user.CreatedAt = time.Now()
+	user.UpdatedAt = user.CreatedAt
+
+	query := `
+		INSERT INTO users (name, email, email_verified_at, password, locality, province, phone, locale, first_login, created_at, updated_at)
+		VALUES (?, ?, ?, '', '', '', '', ?, ?, ?, ?)
+	`
+	result, err := tx.ExecContext(ctx, query,
+		truncate(user.Name, 100), user.Email, user.EmailVerifiedAt, user.Locale, user.FirstLogin, user.CreatedAt, user.UpdatedAt,
+	)
+	if err != nil {
+		// Alguien se registró con el mismo email mientras se completaba el login
+		if isDuplicateKeyError(err) {
+			return errors.NewConflict("Email is already in use")
+		}
+		return err
+	}
+
+	user.ID, err = result.LastInsertId()
+	if err != nil {
+		return err
+	}
+
+	identity.UserID = user.ID
+	if err := r.insertIdentity(ctx, tx, identity); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (r *IdentityRepository) insertIdentity(ctx context.Context, db execer, identity *entities.UserIdentity) error {
+	identity.CreatedAt = time.Now()
+	query := `
+		INSERT INTO user_identities (user_id, provider, subject, email, created_at, last_login_at)
+		VALUES (?, ?, ?, ?, ?, NULL)
+	`
+	result, err := db.ExecContext(ctx, query, identity.UserID, identity.Provider, truncate(identity.Subject, 255), truncate(identity.Email, 255), identity.CreatedAt)
+	if err != nil {
+		if isDuplicateKeyError(err) {
+			return errors.NewConflict("Account is already linked to another identity of this provider")
+		}
+		return err
+	}
+
+	identity.ID, err = result.LastInsertId()
+	return err
+}
+
+func (r *IdentityRepository) RecordLogin(ctx context.Context, id int64, email string) error {
+	_, err := r.db.ExecContext(ctx, `UPDATE user_identities SET email = ?, last_login_at = NOW() WHERE id = ?`, truncate(email, 255), id)
+	return err
+}
+
+func (r *IdentityRepository) Unlink(ctx context.Context, userID int64, provider string) (bool, error) {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM user_identities WHERE user_id = ? AND provider = ?`, userID, provider)
+	if err != nil {
+		return false, err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return rows > 0, nil
+}
+
+func scanIdentity(row rowScanner) (*entities.UserIdentity, error) {
+	identity := &entities.UserIdentity{}
+	var lastLoginAt sql.NullTime
+
+	err := row.Scan(&identity.ID, &identity.UserID, &identity.Provider, &identity.Subject, &identity.Email, &identity.CreatedAt, &lastLoginAt)
+	if err != nil {
+		return nil, err
+	}
+
+	if lastLoginAt.Valid {
+		identity.LastLoginAt = &lastLoginAt.Time
+	}
+	return identity, nil
+}
@@ -0,0 +1,39 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+
+	"iycds2025_api/src/api/core/entities"
+)
+
+type LoginAttemptRepository struct {
+	db *sql.DB
+}
+
+func NewLoginAttemptRepository(db *sql.DB) *LoginAttemptRepository {
+	return &LoginAttemptRepository{db: db}
+}
+
+// Record guarda un intento de login; user_id queda en NULL si el email no corresponde a ninguna cuenta
+func (r *LoginAttemptRepository) Record(ctx context.Context, attempt *entities.LoginAttempt) error {
+	var userID interface{}
+	if attempt.UserID != 0 {
+		userID = attempt.UserID
+	}
+
+	query := `
+		INSERT INTO login_attempts (user_id, email, success, failure_reason, ip_address, user_agent, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, NOW())
+	`
+	result, err := r.db.ExecContext(ctx, query,
+		userID, truncate(attempt.Email, 255), attempt.Success, attempt.FailureReason,
+		truncate(attempt.IPAddress, 45), truncate(attempt.UserAgent, 255),
+	)
+	if err != nil {
+		return err
+	}
+
+	attempt.ID, err = result.LastInsertId()
+	return err
+}
@@ -0,0 +1,95 @@
+-- Esquema inicial: usuarios, roles, permisos, servicios, citas y tokens de restablecimiento
+
+CREATE TABLE IF NOT EXISTS users (
+    id          BIGINT AUTO_INCREMENT PRIMARY KEY,
+    name        VARCHAR(100) NOT NULL,
+    email       VARCHAR(255) NOT NULL,
+    password    VARCHAR(255) NOT NULL,
+    locality    VARCHAR(100) NOT NULL DEFAULT '',
+    province    VARCHAR(100) NOT NULL DEFAULT '',
+    phone       VARCHAR(20)  NOT NULL DEFAULT '',
+    first_login BOOLEAN      NOT NULL DEFAULT TRUE,
+    created_at  DATETIME     NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at  DATETIME     NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE KEY uq_users_email (email)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE TABLE IF NOT EXISTS roles (
+    id        INT AUTO_INCREMENT PRIMARY KEY,
+    role_name VARCHAR(50) NOT NULL,
+    UNIQUE KEY uq_roles_role_name (role_name)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE TABLE IF NOT EXISTS user_roles (
+    user_id BIGINT NOT NULL,
+    role_id INT    NOT NULL,
+    PRIMARY KEY (user_id, role_id),
+    CONSTRAINT fk_user_roles_user FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE,
+    CONSTRAINT fk_user_roles_role FOREIGN KEY (role_id) REFERENCES roles (id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE TABLE IF NOT EXISTS permissions (
+    id              INT AUTO_INCREMENT PRIMARY KEY,
+    permission_name VARCHAR(100) NOT NULL,
+    UNIQUE KEY uq_permissions_permission_name (permission_name)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE TABLE IF NOT EXISTS user_permissions (
+    user_id       BIGINT NOT NULL,
+    permission_id INT    NOT NULL,
+    PRIMARY KEY (user_id, permission_id),
+    CONSTRAINT fk_user_permissions_user FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE,
+    CONSTRAINT fk_user_permissions_permission FOREIGN KEY (permission_id) REFERENCES permissions (id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE TABLE IF NOT EXISTS password_reset_tokens (
+    id         BIGINT AUTO_INCREMENT PRIMARY KEY,
+    user_id    BIGINT      NOT NULL,
+    token      VARCHAR(64) NOT NULL,
+    expires_at DATETIME    NOT NULL,
+    used       BOOLEAN     NOT NULL DEFAULT FALSE,
+    created_at DATETIME    NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE KEY uq_password_reset_tokens_token (token),
+    CONSTRAINT fk_password_reset_tokens_user FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE TABLE IF NOT EXISTS services (
+    id           BIGINT AUTO_INCREMENT PRIMARY KEY,
+    title        VARCHAR(100)   NOT NULL,
+    description  TEXT           NOT NULL,
+    user_id      BIGINT         NOT NULL,
+    category     VARCHAR(50)    NOT NULL,
+    price        DECIMAL(10, 2) NOT NULL DEFAULT 0,
+    availability JSON           NOT NULL,
+    zones        JSON           NOT NULL,
+    status       VARCHAR(20)    NOT NULL DEFAULT 'active',
+    image_url    VARCHAR(500)   NOT NULL DEFAULT '',
+    created_at   DATETIME       NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at   DATETIME       NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    KEY idx_services_user_id (user_id),
+    KEY idx_services_status_created_at (status, created_at),
+    CONSTRAINT fk_services_user FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE TABLE IF NOT EXISTS appointments (
+    id          BIGINT AUTO_INCREMENT PRIMARY KEY,
+    service_id  BIGINT       NOT NULL,
+    client_id   BIGINT       NOT NULL,
+    provider_id BIGINT       NOT NULL,
+    date        CHAR(10)     NOT NULL,
+    time_slot   VARCHAR(11)  NOT NULL,
+    status      VARCHAR(20)  NOT NULL DEFAULT 'pending',
+    notes       VARCHAR(500) NOT NULL DEFAULT '',
+    created_at  DATETIME     NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at  DATETIME     NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    KEY idx_appointments_service_date (service_id, date),
+    KEY idx_appointments_client_id (client_id),
+    KEY idx_appointments_provider_id (provider_id),
+    CONSTRAINT fk_appointments_service FOREIGN KEY (service_id) REFERENCES services (id) ON DELETE CASCADE,
+    CONSTRAINT fk_appointments_client FOREIGN KEY (client_id) REFERENCES users (id) ON DELETE CASCADE,
+    CONSTRAINT fk_appointments_provider FOREIGN KEY (provider_id) REFERENCES users (id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+INSERT IGNORE INTO roles (role_name) VALUES ('user'), ('admin');
+
+INSERT IGNORE INTO permissions (permission_name) VALUES ('read');
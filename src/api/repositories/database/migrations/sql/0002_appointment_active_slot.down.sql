@@ -0,0 +1,3 @@
+ALTER TABLE appointments DROP INDEX uq_appointments_active_slot;
+
+ALTER TABLE appointments DROP COLUMN active_slot;
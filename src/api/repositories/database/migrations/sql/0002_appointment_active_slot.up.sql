@@ -0,0 +1,8 @@
+-- Garantiza que un mismo horario de un servicio no tenga más de una cita activa (pending/accepted).
+-- Las citas canceladas, rechazadas o completadas dejan active_slot en NULL y no participan del índice único.
+
+ALTER TABLE appointments
+    ADD COLUMN active_slot TINYINT GENERATED ALWAYS AS (IF(status IN ('pending', 'accepted'), 1, NULL)) STORED;
+
+ALTER TABLE appointments
+    ADD UNIQUE KEY uq_appointments_active_slot (service_id, date, time_slot, active_slot);
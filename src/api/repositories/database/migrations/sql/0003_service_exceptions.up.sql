@@ -0,0 +1,15 @@
+-- Excepciones de agenda por fecha: días cerrados (feriados, vacaciones) u horarios especiales
+
+CREATE TABLE IF NOT EXISTS service_exceptions (
+    id         BIGINT AUTO_INCREMENT PRIMARY KEY,
+    service_id BIGINT       NOT NULL,
+    start_date CHAR(10)     NOT NULL,
+    end_date   CHAR(10)     NOT NULL,
+    type       VARCHAR(20)  NOT NULL,
+    ranges     JSON         NOT NULL,
+    reason     VARCHAR(255) NOT NULL DEFAULT '',
+    created_at DATETIME     NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME     NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    KEY idx_service_exceptions_service_dates (service_id, start_date, end_date),
+    CONSTRAINT fk_service_exceptions_service FOREIGN KEY (service_id) REFERENCES services (id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
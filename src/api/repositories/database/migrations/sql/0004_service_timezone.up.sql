@@ -0,0 +1,4 @@
+-- Zona horaria IANA en la que se interpretan las fechas y horarios de cada servicio
+
+ALTER TABLE services
+    ADD COLUMN timezone VARCHAR(64) NOT NULL DEFAULT 'America/Argentina/Buenos_Aires' AFTER availability;
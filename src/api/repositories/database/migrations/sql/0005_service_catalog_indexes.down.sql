@@ -0,0 +1,3 @@
+ALTER TABLE services
+    DROP KEY idx_services_status_category,
+    DROP KEY idx_services_status_price;
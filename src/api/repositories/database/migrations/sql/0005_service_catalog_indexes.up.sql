@@ -0,0 +1,5 @@
+-- Índices para filtrar y ordenar el catálogo público de servicios
+
+ALTER TABLE services
+    ADD KEY idx_services_status_category (status, category, created_at),
+    ADD KEY idx_services_status_price (status, price);
@@ -0,0 +1,7 @@
+ALTER TABLE services DROP KEY ft_services_search_title;
+
+ALTER TABLE services DROP KEY ft_services_search;
+
+ALTER TABLE services
+    DROP COLUMN search_title,
+    DROP COLUMN search_body;
@@ -0,0 +1,23 @@
+-- Columnas de búsqueda de texto libre. La aplicación las completa con el texto ya
+-- normalizado (minúsculas, sin acentos ni stopwords) cada vez que se guarda un servicio.
+
+ALTER TABLE services
+    ADD COLUMN search_title VARCHAR(255) NOT NULL DEFAULT '',
+    ADD COLUMN search_body  TEXT NULL;
+
+-- Carga inicial para los servicios existentes; la collation sin acentos de la columna
+-- cubre las diferencias con el texto normalizado hasta que cada servicio se vuelva a guardar
+UPDATE services
+SET search_title = LOWER(title),
+    search_body  = LOWER(CONCAT_WS(' ',
+        description,
+        category,
+        JSON_UNQUOTE(JSON_EXTRACT(zones, '$[*].province')),
+        JSON_UNQUOTE(JSON_EXTRACT(zones, '$[*].locality')),
+        JSON_UNQUOTE(JSON_EXTRACT(zones, '$[*].neighborhood'))
+    ));
+
+-- InnoDB crea un índice FULLTEXT por sentencia
+ALTER TABLE services ADD FULLTEXT KEY ft_services_search (search_title, search_body);
+
+ALTER TABLE services ADD FULLTEXT KEY ft_services_search_title (search_title);
@@ -0,0 +1,5 @@
+ALTER TABLE services
+    DROP COLUMN rating_average,
+    DROP COLUMN review_count;
+
+DROP TABLE IF EXISTS reviews;
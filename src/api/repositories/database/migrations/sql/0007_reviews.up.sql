@@ -0,0 +1,26 @@
+-- Reseñas de citas completadas: una por cita, con una respuesta opcional del proveedor
+
+CREATE TABLE IF NOT EXISTS reviews (
+    id             BIGINT AUTO_INCREMENT PRIMARY KEY,
+    appointment_id BIGINT       NOT NULL,
+    service_id     BIGINT       NOT NULL,
+    client_id      BIGINT       NOT NULL,
+    provider_id    BIGINT       NOT NULL,
+    rating         TINYINT      NOT NULL,
+    comment        TEXT         NULL,
+    reply          TEXT         NULL,
+    replied_at     DATETIME     NULL,
+    created_at     DATETIME     NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at     DATETIME     NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE KEY uq_reviews_appointment_id (appointment_id),
+    KEY idx_reviews_service_created_at (service_id, created_at),
+    CONSTRAINT fk_reviews_appointment FOREIGN KEY (appointment_id) REFERENCES appointments (id) ON DELETE CASCADE,
+    CONSTRAINT fk_reviews_service FOREIGN KEY (service_id) REFERENCES services (id) ON DELETE CASCADE,
+    CONSTRAINT fk_reviews_client FOREIGN KEY (client_id) REFERENCES users (id) ON DELETE CASCADE,
+    CONSTRAINT fk_reviews_provider FOREIGN KEY (provider_id) REFERENCES users (id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- Promedio y cantidad de reseñas desnormalizados para no recalcularlos en cada listado
+ALTER TABLE services
+    ADD COLUMN rating_average DECIMAL(3,2) NOT NULL DEFAULT 0,
+    ADD COLUMN review_count   INT          NOT NULL DEFAULT 0;
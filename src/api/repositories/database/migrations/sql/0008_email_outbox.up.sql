@@ -0,0 +1,18 @@
+-- Cola de correos salientes: los use cases encolan y un worker entrega con reintentos
+
+CREATE TABLE IF NOT EXISTS email_outbox (
+    id              BIGINT AUTO_INCREMENT PRIMARY KEY,
+    kind            VARCHAR(50)  NOT NULL,
+    recipient       VARCHAR(255) NOT NULL,
+    payload         JSON         NOT NULL,
+    status          VARCHAR(20)  NOT NULL DEFAULT 'pending',
+    attempts        INT          NOT NULL DEFAULT 0,
+    next_attempt_at DATETIME     NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    locked_until    DATETIME     NULL,
+    last_error      TEXT         NULL,
+    sent_at         DATETIME     NULL,
+    created_at      DATETIME     NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at      DATETIME     NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    KEY idx_email_outbox_status_next_attempt (status, next_attempt_at),
+    KEY idx_email_outbox_recipient (recipient)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
@@ -0,0 +1,4 @@
+-- Idioma preferido de cada usuario para los correos (es, en, pt)
+
+ALTER TABLE users
+    ADD COLUMN locale VARCHAR(5) NOT NULL DEFAULT 'es' AFTER phone;
@@ -0,0 +1,28 @@
+-- Sesiones de usuario y refresh tokens rotativos (solo se guarda el hash SHA-256 de cada token)
+
+CREATE TABLE IF NOT EXISTS user_sessions (
+    id           BIGINT AUTO_INCREMENT PRIMARY KEY,
+    user_id      BIGINT       NOT NULL,
+    user_agent   VARCHAR(255) NOT NULL DEFAULT '',
+    ip_address   VARCHAR(45)  NOT NULL DEFAULT '',
+    expires_at   DATETIME     NOT NULL,
+    revoked_at   DATETIME     NULL,
+    last_used_at DATETIME     NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    created_at   DATETIME     NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at   DATETIME     NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    KEY idx_user_sessions_user (user_id),
+    CONSTRAINT fk_user_sessions_user FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- Cada rotación marca el token anterior como usado y crea uno nuevo en la misma sesión
+CREATE TABLE IF NOT EXISTS refresh_tokens (
+    id         BIGINT AUTO_INCREMENT PRIMARY KEY,
+    session_id BIGINT   NOT NULL,
+    token_hash CHAR(64) NOT NULL,
+    expires_at DATETIME NOT NULL,
+    used_at    DATETIME NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE KEY uq_refresh_tokens_hash (token_hash),
+    KEY idx_refresh_tokens_session (session_id),
+    CONSTRAINT fk_refresh_tokens_session FOREIGN KEY (session_id) REFERENCES user_sessions (id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
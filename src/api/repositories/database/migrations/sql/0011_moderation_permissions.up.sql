@@ -0,0 +1,3 @@
+-- Permisos de moderación: permiten administrar servicios y citas de cualquier usuario (el rol admin los tiene todos)
+
+INSERT IGNORE INTO permissions (permission_name) VALUES ('services:moderate'), ('appointments:moderate');
@@ -0,0 +1,20 @@
+-- Back-office: suspensión de cuentas y registro de auditoría de las acciones de administración
+
+ALTER TABLE users
+    ADD COLUMN suspended_at      DATETIME     NULL AFTER first_login,
+    ADD COLUMN suspension_reason VARCHAR(255) NOT NULL DEFAULT '' AFTER suspended_at;
+
+-- Sin clave foránea a users: el registro se conserva aunque se elimine al administrador o al usuario afectado
+CREATE TABLE IF NOT EXISTS audit_log (
+    id          BIGINT AUTO_INCREMENT PRIMARY KEY,
+    actor_id    BIGINT      NOT NULL,
+    action      VARCHAR(50) NOT NULL,
+    target_type VARCHAR(20) NOT NULL,
+    target_id   BIGINT      NOT NULL,
+    details     JSON        NULL,
+    ip_address  VARCHAR(45) NOT NULL DEFAULT '',
+    created_at  DATETIME    NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    KEY idx_audit_log_target (target_type, target_id, created_at),
+    KEY idx_audit_log_actor (actor_id, created_at),
+    KEY idx_audit_log_created_at (created_at)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
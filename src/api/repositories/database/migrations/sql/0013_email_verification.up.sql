@@ -0,0 +1,22 @@
+-- Verificación de email: tokens de un solo uso que se envían al registrarse y al cambiar de email
+
+ALTER TABLE users
+    ADD COLUMN email_verified_at DATETIME NULL AFTER email;
+
+-- Las cuentas existentes se dan por verificadas para no bloquear a quienes ya publican o reservan
+UPDATE users SET email_verified_at = created_at;
+
+-- email es la dirección que se confirma: la del registro o el nuevo email de un cambio, que recién
+-- se aplica a la cuenta al verificarlo. Solo se guarda el hash SHA-256 del token.
+CREATE TABLE IF NOT EXISTS email_verification_tokens (
+    id         BIGINT AUTO_INCREMENT PRIMARY KEY,
+    user_id    BIGINT       NOT NULL,
+    email      VARCHAR(255) NOT NULL,
+    token_hash CHAR(64)     NOT NULL,
+    expires_at DATETIME     NOT NULL,
+    used       BOOLEAN      NOT NULL DEFAULT FALSE,
+    created_at DATETIME     NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE KEY uq_email_verification_tokens_hash (token_hash),
+    KEY idx_email_verification_tokens_user (user_id, used),
+    CONSTRAINT fk_email_verification_tokens_user FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
@@ -0,0 +1,22 @@
+-- Protección del login por cuenta: intentos fallidos consecutivos, bloqueo temporal y registro de intentos
+
+ALTER TABLE users
+    ADD COLUMN failed_login_count   INT      NOT NULL DEFAULT 0 AFTER suspension_reason,
+    ADD COLUMN last_failed_login_at DATETIME NULL AFTER failed_login_count,
+    ADD COLUMN locked_until         DATETIME NULL AFTER last_failed_login_at;
+
+-- Un registro por intento de login, exitoso o no. user_id queda en NULL si el email no existe;
+-- sin clave foránea para conservar el historial aunque se elimine la cuenta
+CREATE TABLE IF NOT EXISTS login_attempts (
+    id             BIGINT AUTO_INCREMENT PRIMARY KEY,
+    user_id        BIGINT       NULL,
+    email          VARCHAR(255) NOT NULL,
+    success        BOOLEAN      NOT NULL,
+    failure_reason VARCHAR(30)  NOT NULL DEFAULT '',
+    ip_address     VARCHAR(45)  NOT NULL DEFAULT '',
+    user_agent     VARCHAR(255) NOT NULL DEFAULT '',
+    created_at     DATETIME     NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    KEY idx_login_attempts_user (user_id, created_at),
+    KEY idx_login_attempts_email (email, created_at),
+    KEY idx_login_attempts_ip (ip_address, created_at)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
@@ -0,0 +1,41 @@
+-- Autenticación en dos pasos (TOTP): secreto por usuario, códigos de recuperación y desafíos de login
+
+-- Un registro por usuario que inició la activación; confirmed_at queda en NULL hasta que el usuario
+-- confirma con un código de su app. last_used_step impide reutilizar un código ya aceptado.
+CREATE TABLE IF NOT EXISTS user_totp (
+    user_id        BIGINT      NOT NULL PRIMARY KEY,
+    secret         VARCHAR(64) NOT NULL,
+    confirmed_at   DATETIME    NULL,
+    last_used_step BIGINT      NOT NULL DEFAULT 0,
+    created_at     DATETIME    NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at     DATETIME    NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    CONSTRAINT fk_user_totp_user FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- Códigos de recuperación de un solo uso; solo se guarda el hash
+CREATE TABLE IF NOT EXISTS user_recovery_codes (
+    id         BIGINT AUTO_INCREMENT PRIMARY KEY,
+    user_id    BIGINT   NOT NULL,
+    code_hash  CHAR(64) NOT NULL,
+    used_at    DATETIME NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    KEY idx_user_recovery_codes_user (user_id, code_hash),
+    CONSTRAINT fk_user_recovery_codes_user FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- Desafíos del segundo paso del login: se crean al validar la contraseña de una cuenta con 2FA
+-- y se canjean una sola vez, con un código válido, por los tokens de sesión
+CREATE TABLE IF NOT EXISTS login_challenges (
+    id         BIGINT AUTO_INCREMENT PRIMARY KEY,
+    user_id    BIGINT       NOT NULL,
+    token_hash CHAR(64)     NOT NULL,
+    attempts   INT          NOT NULL DEFAULT 0,
+    expires_at DATETIME     NOT NULL,
+    used_at    DATETIME     NULL,
+    ip_address VARCHAR(45)  NOT NULL DEFAULT '',
+    user_agent VARCHAR(255) NOT NULL DEFAULT '',
+    created_at DATETIME     NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE KEY uq_login_challenges_token (token_hash),
+    KEY idx_login_challenges_user (user_id),
+    CONSTRAINT fk_login_challenges_user FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
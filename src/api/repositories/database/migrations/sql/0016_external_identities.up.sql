@@ -0,0 +1,31 @@
+-- Login con proveedores de identidad externos (OIDC): identidades vinculadas y estado de los logins en curso
+
+-- Una identidad por proveedor y usuario; (provider, subject) identifica al usuario en el proveedor.
+-- Las cuentas creadas con un proveedor quedan con password vacío hasta que definan una contraseña.
+CREATE TABLE IF NOT EXISTS user_identities (
+    id            BIGINT AUTO_INCREMENT PRIMARY KEY,
+    user_id       BIGINT       NOT NULL,
+    provider      VARCHAR(50)  NOT NULL,
+    subject       VARCHAR(255) NOT NULL,
+    email         VARCHAR(255) NOT NULL DEFAULT '',
+    created_at    DATETIME     NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    last_login_at DATETIME     NULL,
+    UNIQUE KEY uq_user_identities_subject (provider, subject),
+    UNIQUE KEY uq_user_identities_user_provider (user_id, provider),
+    CONSTRAINT fk_user_identities_user FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- Estado entre el inicio del login y el callback del proveedor: verificador PKCE y nonce.
+-- Se guarda solo el hash del state y cada registro se usa una sola vez.
+CREATE TABLE IF NOT EXISTS external_login_states (
+    id            BIGINT AUTO_INCREMENT PRIMARY KEY,
+    provider      VARCHAR(50)  NOT NULL,
+    state_hash    CHAR(64)     NOT NULL,
+    code_verifier VARCHAR(128) NOT NULL,
+    nonce         VARCHAR(64)  NOT NULL,
+    expires_at    DATETIME     NOT NULL,
+    used_at       DATETIME     NULL,
+    created_at    DATETIME     NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE KEY uq_external_login_states_state (state_hash),
+    KEY idx_external_login_states_expires (expires_at)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
@@ -0,0 +1,8 @@
+-- Baja de cuentas: la eliminación se programa con un período de gracia y al vencer se anonimizan
+-- los datos personales. La fila de users se conserva para que las citas y reseñas sigan siendo
+-- consistentes para la otra parte.
+ALTER TABLE users
+    ADD COLUMN deletion_requested_at DATETIME NULL AFTER locked_until,
+    ADD COLUMN deletion_scheduled_at DATETIME NULL AFTER deletion_requested_at,
+    ADD COLUMN deleted_at            DATETIME NULL AFTER deletion_scheduled_at,
+    ADD KEY idx_users_deletion_scheduled_at (deletion_scheduled_at);
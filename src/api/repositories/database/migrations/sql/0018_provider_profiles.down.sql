@@ -0,0 +1,4 @@
+ALTER TABLE users
+    DROP COLUMN avatar_url,
+    DROP COLUMN bio,
+    DROP COLUMN display_name;
@@ -0,0 +1,6 @@
+-- Perfil público de los proveedores: nombre a mostrar, presentación y foto. display_name vacío
+-- muestra el nombre de la cuenta.
+ALTER TABLE users
+    ADD COLUMN display_name VARCHAR(100)  NOT NULL DEFAULT '' AFTER name,
+    ADD COLUMN bio          VARCHAR(1000) NOT NULL DEFAULT '' AFTER display_name,
+    ADD COLUMN avatar_url   VARCHAR(500)  NOT NULL DEFAULT '' AFTER bio;
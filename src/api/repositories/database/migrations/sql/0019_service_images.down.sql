@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS service_images;
+
+-- La portada vuelve a ser la URL cargada a mano, si el servicio tenía una
+UPDATE services SET image_url = legacy_image_url WHERE legacy_image_url <> '';
+
+ALTER TABLE services DROP COLUMN legacy_image_url;
@@ -0,0 +1,26 @@
+-- Fotos subidas de los servicios, en orden. La primera es la portada y se copia a services.image_url
+-- para que los listados no tengan que leer esta tabla.
+CREATE TABLE IF NOT EXISTS service_images (
+    id               BIGINT AUTO_INCREMENT PRIMARY KEY,
+    service_id       BIGINT       NOT NULL,
+    position         INT          NOT NULL,
+    image_key        VARCHAR(255) NOT NULL,
+    thumbnail_key    VARCHAR(255) NOT NULL,
+    url              VARCHAR(500) NOT NULL,
+    thumbnail_url    VARCHAR(500) NOT NULL,
+    content_type     VARCHAR(50)  NOT NULL,
+    width            INT          NOT NULL,
+    height           INT          NOT NULL,
+    thumbnail_width  INT          NOT NULL,
+    thumbnail_height INT          NOT NULL,
+    size_bytes       INT          NOT NULL,
+    created_at       DATETIME     NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    KEY idx_service_images_service_position (service_id, position),
+    CONSTRAINT fk_service_images_service FOREIGN KEY (service_id) REFERENCES services (id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- Las URLs cargadas a mano antes de las fotos subidas se conservan: la API ya no acepta nuevas,
+-- pero se guardan aparte para volver a usarlas como portada si el servicio se queda sin fotos
+ALTER TABLE services ADD COLUMN legacy_image_url VARCHAR(500) NOT NULL DEFAULT '' AFTER image_url;
+
+UPDATE services SET legacy_image_url = image_url WHERE image_url <> '';
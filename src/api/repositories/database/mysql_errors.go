@@ -0,0 +1,16 @@
+package database
+
+import (
+	"errors"
+
+	"github.com/go-sql-driver/mysql"
+)
+
+// mysqlDuplicateEntry es el código de error de MySQL para violaciones de índices únicos
+const mysqlDuplicateEntry = 1062
+
+// isDuplicateKeyError indica si el error corresponde a una violación de un índice único
+func isDuplicateKeyError(err error) bool {
+	var mysqlErr *mysql.MySQLError
+	return errors.As(err, &mysqlErr) && mysqlErr.Number == mysqlDuplicateEntry
+}
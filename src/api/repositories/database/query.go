@@ -0,0 +1,11 @@
+package database
+
+import "strings"
+
+// likeEscaper escapa los comodines de LIKE para que el texto del usuario se busque literalmente
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// escapeLike prepara un texto para usarlo dentro de un patrón LIKE
+func escapeLike(text string) string {
+	return likeEscaper.Replace(text)
+}
@@ -0,0 +1,213 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+
+	"iycds2025_api/src/api/core/entities"
+	"iycds2025_api/src/api/core/errors"
+)
+
+type ReviewRepository struct {
+	db *sql.DB
+}
+
+func NewReviewRepository(db *sql.DB) *ReviewRepository {
+	return &ReviewRepository{db: db}
+}
+
+// reviewColumns son las columnas que se leen de la tabla reviews, en el orden que espera scanReview
+const reviewColumns = `id, appointment_id, service_id, client_id, provider_id, rating, comment, reply, replied_at, created_at, updated_at`
+
+func (r *ReviewRepository) Create(ctx context.Context, appointment *entities.Appointment, reviewReq *entities.ReviewCreate) (*entities.Review, error) {
+	// La reseña y el recálculo del promedio del servicio se guardan juntos
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	query := `
+		INSERT INTO reviews (appointment_id, service_id, client_id, provider_id, rating, comment, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, NOW(), NOW())
+	`
+
+	result, err := tx.ExecContext(ctx, query,
+		appointment.ID, appointment.ServiceID, appointment.ClientID, appointment.ProviderID,
+		reviewReq.Rating, reviewReq.Comment,
+	)
+	if err != nil {
+		// El índice único sobre appointment_id garantiza una sola reseña por cita
+		if isDuplicateKeyError(err) {
+			return nil, errors.ErrAlreadyReviewed
+		}
+		return nil, err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return nil, err
+	}
+
+	aggregateQuery := `
+		UPDATE services SET
+			rating_average = (SELECT COALESCE(AVG(rating), 0) FROM reviews WHERE service_id = ?),
+			review_count = (SELECT COUNT(*) FROM reviews WHERE service_id = ?)
+		WHERE id = ?
+	`
+	if _, err := tx.ExecContext(ctx, aggregateQuery, appointment.ServiceID, appointment.ServiceID, appointment.ServiceID); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return r.GetByID(ctx, id)
+}
+
+func (r *ReviewRepository) GetByID(ctx context.Context, id int64) (*entities.Review, error) {
+	query := `SELECT ` + reviewColumns + ` FROM reviews WHERE id = ?`
+
+	review, err := scanReview(r.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return review, nil
+}
+
+// GetByServiceID devuelve una página de reseñas de un servicio, las más recientes primero, y el total
+func (r *ReviewRepository) GetByServiceID(ctx context.Context, serviceID int64, page int, pageSize int) ([]*entities.Review, int, error) {
+	var total int
+	countQuery := `SELECT COUNT(*) FROM reviews WHERE service_id = ?`
+	if err := r.db.QueryRowContext(ctx, countQuery, serviceID).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	query := `
+		SELECT ` + reviewColumns + `
+		FROM reviews
+		WHERE service_id = ?
+		ORDER BY created_at DESC, id DESC
+		LIMIT ? OFFSET ?
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, serviceID, pageSize, (page-1)*pageSize)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	reviews := []*entities.Review{}
+	for rows.Next() {
+		review, err := scanReview(rows)
+		if err != nil {
+			return nil, 0, err
+		}
+		reviews = append(reviews, review)
+	}
+
+	return reviews, total, rows.Err()
+}
+
+// GetByClientID devuelve las reseñas que escribió el cliente, las más recientes primero
+func (r *ReviewRepository) GetByClientID(ctx context.Context, clientID int64) ([]*entities.Review, error) {
+	query := `SELECT ` + reviewColumns + ` FROM reviews WHERE client_id = ? ORDER BY created_at DESC, id DESC`
+	return r.queryReviews(ctx, query, clientID)
+}
+
+// GetByProviderID devuelve las reseñas que recibieron los servicios del proveedor, las más recientes primero
+func (r *ReviewRepository) GetByProviderID(ctx context.Context, providerID int64) ([]*entities.Review, error) {
+	query := `SELECT ` + reviewColumns + ` FROM reviews WHERE provider_id = ? ORDER BY created_at DESC, id DESC`
+	return r.queryReviews(ctx, query, providerID)
+}
+
+// GetProviderRating calcula el promedio sobre todas las reseñas y no sobre el promedio de cada
+// servicio, para que un servicio con pocas reseñas no pese lo mismo que uno con muchas
+func (r *ReviewRepository) GetProviderRating(ctx context.Context, providerID int64) (float64, int, error) {
+	var average float64
+	var count int
+	query := `SELECT COALESCE(ROUND(AVG(rating), 2), 0), COUNT(*) FROM reviews WHERE provider_id = ?`
+	if err := r.db.QueryRowContext(ctx, query, providerID).Scan(&average, &count); err != nil {
+		return 0, 0, err
+	}
+	return average, count, nil
+}
+
+func (r *ReviewRepository) queryReviews(ctx context.Context, query string, args ...interface{}) ([]*entities.Review, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	reviews := []*entities.Review{}
+	for rows.Next() {
+		review, err := scanReview(rows)
+		if err != nil {
+			return nil, err
+		}
+		reviews = append(reviews, review)
+	}
+
+	return reviews, rows.Err()
+}
+
+func (r *ReviewRepository) Reply(ctx context.Context, id int64, providerID int64, reply string) (*entities.Review, error) {
+	query := `
+		UPDATE reviews SET reply = ?, replied_at = NOW(), updated_at = NOW()
+		WHERE id = ? AND provider_id = ? AND reply IS NULL
+	`
+
+	result, err := r.db.ExecContext(ctx, query, reply, id, providerID)
+	if err != nil {
+		return nil, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return nil, err
+	}
+	if rowsAffected == 0 {
+		return nil, sql.ErrNoRows // No existe, no es del proveedor o ya tiene respuesta
+	}
+
+	return r.GetByID(ctx, id)
+}
+
+func scanReview(row rowScanner) (*entities.Review, error) {
+	var review entities.Review
+	var comment, reply sql.NullString
+	var repliedAt sql.NullTime
+
+	err := row.Scan(
+		&review.ID,
+		&review.AppointmentID,
+		&review.ServiceID,
+		&review.ClientID,
+		&review.ProviderID,
+		&review.Rating,
+		&comment,
+		&reply,
+		&repliedAt,
+		&review.CreatedAt,
+		&review.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	review.Comment = comment.String
+	if reply.Valid {
+		review.Reply = &reply.String
+	}
+	if repliedAt.Valid {
+		review.RepliedAt = &repliedAt.Time
+	}
+
+	return &review, nil
+}
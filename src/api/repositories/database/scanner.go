@@ -0,0 +1,6 @@
+package database
+
+// rowScanner permite escanear tanto *sql.Row como *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"encoding/json"
+	"strings"
 
 	"iycds2025_api/src/api/core/entities"
 )
@@ -29,8 +30,8 @@ func (r *ServiceRepository) Create(ctx context.Context, serviceReq *entities.Ser
 	}
 
 	query := `
-		INSERT INTO services (title, description, user_id, category, price, availability, zones, status, image_url, created_at, updated_at)
-		VALUES (?, ?, ?, ?, ?, ?, ?, 'active', ?, NOW(), NOW())
+		INSERT INTO services (title, description, user_id, category, price, availability, timezone, zones, status, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, 'active', NOW(), NOW())
 	`
 
 	result, err := r.db.ExecContext(ctx, query,
@@ -40,8 +41,8 @@ func (r *ServiceRepository) Create(ctx context.Context, serviceReq *entities.Ser
 		serviceReq.Category,
 		serviceReq.Price,
 		string(availabilityJSON),
+		serviceReq.Timezone,
 		string(zonesJSON),
-		serviceReq.ImageURL,
 	)
 	if err != nil {
 		return nil, err
@@ -57,26 +58,12 @@ func (r *ServiceRepository) Create(ctx context.Context, serviceReq *entities.Ser
 
 func (r *ServiceRepository) GetByID(ctx context.Context, id int64) (*entities.Service, error) {
 	query := `
-		SELECT id, title, description, user_id, category, price, availability, zones, status, image_url, created_at, updated_at
+		SELECT ` + serviceColumns + `
 		FROM services
 		WHERE id = ?
 	`
 
-	var service entities.Service
-	err := r.db.QueryRowContext(ctx, query, id).Scan(
-		&service.ID,
-		&service.Title,
-		&service.Description,
-		&service.UserID,
-		&service.Category,
-		&service.Price,
-		&service.Availability,
-		&service.Zones,
-		&service.Status,
-		&service.ImageURL,
-		&service.CreatedAt,
-		&service.UpdatedAt,
-	)
+	service, err := scanService(r.db.QueryRowContext(ctx, query, id))
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, nil
@@ -84,87 +71,155 @@ func (r *ServiceRepository) GetByID(ctx context.Context, id int64) (*entities.Se
 		return nil, err
 	}
 
-	return &service, nil
+	return service, nil
 }
 
 func (r *ServiceRepository) GetByUserID(ctx context.Context, userID int64) ([]*entities.Service, error) {
 	query := `
-		SELECT id, title, description, user_id, category, price, availability, zones, status, image_url, created_at, updated_at
+		SELECT ` + serviceColumns + `
 		FROM services
 		WHERE user_id = ?
 		ORDER BY created_at DESC
 	`
 
-	rows, err := r.db.QueryContext(ctx, query, userID)
-	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
+	return r.queryServices(ctx, query, userID)
+}
 
-	var services []*entities.Service
-	for rows.Next() {
-		var service entities.Service
-		err := rows.Scan(
-			&service.ID,
-			&service.Title,
-			&service.Description,
-			&service.UserID,
-			&service.Category,
-			&service.Price,
-			&service.Availability,
-			&service.Zones,
-			&service.Status,
-			&service.ImageURL,
-			&service.CreatedAt,
-			&service.UpdatedAt,
-		)
-		if err != nil {
-			return nil, err
+// Search devuelve una página de servicios activos que cumplen el filtro y el total de coincidencias
+func (r *ServiceRepository) Search(ctx context.Context, filter *entities.ServiceFilter) ([]*entities.Service, int, error) {
+	conditions := []string{"status = 'active'"}
+	var args []interface{}
+
+	if filter.Category != "" {
+		conditions = append(conditions, "category = ?")
+		args = append(args, filter.Category)
+	}
+	if filter.Province != "" || filter.Locality != "" {
+		// Alguna de las zonas de cobertura debe coincidir; la collation por defecto de
+		// JSON_TABLE ignora mayúsculas y acentos
+		zoneConditions := []string{"1 = 1"}
+		if filter.Province != "" {
+			zoneConditions = append(zoneConditions, "z.province = ?")
+			args = append(args, filter.Province)
 		}
-		services = append(services, &service)
+		if filter.Locality != "" {
+			zoneConditions = append(zoneConditions, "z.locality = ?")
+			args = append(args, filter.Locality)
+		}
+		conditions = append(conditions, `EXISTS (
+			SELECT 1 FROM JSON_TABLE(zones, '$[*]' COLUMNS (
+				province VARCHAR(50) PATH '$.province',
+				locality VARCHAR(50) PATH '$.locality'
+			)) AS z
+			WHERE `+strings.Join(zoneConditions, " AND ")+`
+		)`)
+	}
+	if filter.MinPrice != nil {
+		conditions = append(conditions, "price >= ?")
+		args = append(args, *filter.MinPrice)
+	}
+	if filter.MaxPrice != nil {
+		conditions = append(conditions, "price <= ?")
+		args = append(args, *filter.MaxPrice)
+	}
+	if filter.Query != "" {
+		pattern := "%" + escapeLike(filter.Query) + "%"
+		conditions = append(conditions, "(title LIKE ? OR description LIKE ?)")
+		args = append(args, pattern, pattern)
 	}
 
-	return services, nil
-}
+	where := strings.Join(conditions, " AND ")
+
+	var total int
+	countQuery := `SELECT COUNT(*) FROM services WHERE ` + where
+	if err := r.db.QueryRowContext(ctx, countQuery, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+	if total == 0 {
+		return []*entities.Service{}, 0, nil
+	}
+
+	orderBy, ok := serviceSortOrders[filter.Sort]
+	if !ok {
+		orderBy = serviceSortOrders["newest"]
+	}
 
-func (r *ServiceRepository) GetAllActive(ctx context.Context) ([]*entities.Service, error) {
 	query := `
-		SELECT id, title, description, user_id, category, price, availability, zones, status, image_url, created_at, updated_at
+		SELECT ` + serviceColumns + `
 		FROM services
-		WHERE status = 'active'
-		ORDER BY created_at DESC
+		WHERE ` + where + `
+		ORDER BY ` + orderBy + `
+		LIMIT ? OFFSET ?
 	`
+	pageArgs := append(args, filter.PageSize, (filter.Page-1)*filter.PageSize)
 
-	rows, err := r.db.QueryContext(ctx, query)
+	services, err := r.queryServices(ctx, query, pageArgs...)
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
-	defer rows.Close()
 
-	var services []*entities.Service
-	for rows.Next() {
-		var service entities.Service
-		err := rows.Scan(
-			&service.ID,
-			&service.Title,
-			&service.Description,
-			&service.UserID,
-			&service.Category,
-			&service.Price,
-			&service.Availability,
-			&service.Zones,
-			&service.Status,
-			&service.ImageURL,
-			&service.CreatedAt,
-			&service.UpdatedAt,
-		)
-		if err != nil {
-			return nil, err
-		}
-		services = append(services, &service)
+	return services, total, nil
+}
+
+// ListForAdmin devuelve una página de servicios de cualquier estado y dueño, los más nuevos primero
+func (r *ServiceRepository) ListForAdmin(ctx context.Context, filter *entities.AdminServiceFilter) ([]*entities.Service, int, error) {
+	conditions := []string{"1 = 1"}
+	var args []interface{}
+
+	if filter.Status != "" {
+		conditions = append(conditions, "status = ?")
+		args = append(args, filter.Status)
+	}
+	if filter.Category != "" {
+		conditions = append(conditions, "category = ?")
+		args = append(args, filter.Category)
+	}
+	if filter.UserID != 0 {
+		conditions = append(conditions, "user_id = ?")
+		args = append(args, filter.UserID)
+	}
+	if filter.Query != "" {
+		pattern := "%" + escapeLike(filter.Query) + "%"
+		conditions = append(conditions, "(title LIKE ? OR description LIKE ?)")
+		args = append(args, pattern, pattern)
 	}
 
-	return services, nil
+	where := strings.Join(conditions, " AND ")
+
+	var total int
+	countQuery := `SELECT COUNT(*) FROM services WHERE ` + where
+	if err := r.db.QueryRowContext(ctx, countQuery, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+	if total == 0 {
+		return []*entities.Service{}, 0, nil
+	}
+
+	query := `
+		SELECT ` + serviceColumns + `
+		FROM services
+		WHERE ` + where + `
+		ORDER BY ` + serviceSortOrders["newest"] + `
+		LIMIT ? OFFSET ?
+	`
+	pageArgs := append(args, filter.PageSize, (filter.Page-1)*filter.PageSize)
+
+	services, err := r.queryServices(ctx, query, pageArgs...)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return services, total, nil
+}
+
+// serviceSortOrders traduce los órdenes admitidos en el catálogo a cláusulas ORDER BY;
+// el id desempata para que la paginación sea estable
+var serviceSortOrders = map[string]string{
+	"newest":     "created_at DESC, id DESC",
+	"oldest":     "created_at ASC, id ASC",
+	"price_asc":  "price ASC, id DESC",
+	"price_desc": "price DESC, id DESC",
+	"title":      "title ASC, id DESC",
 }
 
 func (r *ServiceRepository) Update(ctx context.Context, id int64, serviceReq *entities.ServiceUpdate, userID int64) (*entities.Service, error) {
@@ -205,6 +260,10 @@ func (r *ServiceRepository) Update(ctx context.Context, id int64, serviceReq *en
 		setParts = append(setParts, "availability = ?")
 		args = append(args, string(availabilityJSON))
 	}
+	if serviceReq.Timezone != "" {
+		setParts = append(setParts, "timezone = ?")
+		args = append(args, serviceReq.Timezone)
+	}
 	if serviceReq.Zones != nil {
 		zonesJSON, err := json.Marshal(serviceReq.Zones)
 		if err != nil {
@@ -213,10 +272,6 @@ func (r *ServiceRepository) Update(ctx context.Context, id int64, serviceReq *en
 		setParts = append(setParts, "zones = ?")
 		args = append(args, string(zonesJSON))
 	}
-	if serviceReq.ImageURL != "" {
-		setParts = append(setParts, "image_url = ?")
-		args = append(args, serviceReq.ImageURL)
-	}
 
 	if len(setParts) == 0 {
 		return existing, nil // No hay cambios
@@ -288,3 +343,54 @@ func (r *ServiceRepository) Delete(ctx context.Context, id int64, userID int64)
 
 	return nil
 }
+
+// serviceColumns son las columnas que se leen de la tabla services, en el orden que espera scanService
+const serviceColumns = `id, title, description, user_id, category, price, availability, timezone, zones, status, image_url, rating_average, review_count, created_at, updated_at`
+
+// queryServices ejecuta una consulta que devuelve serviceColumns y escanea todas las filas
+func (r *ServiceRepository) queryServices(ctx context.Context, query string, args ...interface{}) ([]*entities.Service, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var services []*entities.Service
+	for rows.Next() {
+		service, err := scanService(rows)
+		if err != nil {
+			return nil, err
+		}
+		services = append(services, service)
+	}
+
+	return services, nil
+}
+
+// scanService escanea las columnas de serviceColumns y, a continuación, las columnas extra
+// que agregue la consulta (por ejemplo, un puntaje de relevancia)
+func scanService(row rowScanner, extra ...interface{}) (*entities.Service, error) {
+	var service entities.Service
+	dest := []interface{}{
+		&service.ID,
+		&service.Title,
+		&service.Description,
+		&service.UserID,
+		&service.Category,
+		&service.Price,
+		&service.Availability,
+		&service.Timezone,
+		&service.Zones,
+		&service.Status,
+		&service.ImageURL,
+		&service.RatingAverage,
+		&service.ReviewCount,
+		&service.CreatedAt,
+		&service.UpdatedAt,
+	}
+	if err := row.Scan(append(dest, extra...)...); err != nil {
+		return nil, err
+	}
+
+	return &service, nil
+}
@@ -0,0 +1,171 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+
+	"iycds2025_api/src/api/core/entities"
+)
+
+type ServiceExceptionRepository struct {
+	db *sql.DB
+}
+
+func NewServiceExceptionRepository(db *sql.DB) *ServiceExceptionRepository {
+	return &ServiceExceptionRepository{db: db}
+}
+
+func (r *ServiceExceptionRepository) Create(ctx context.Context, serviceID int64, exceptionReq *entities.ServiceExceptionRequest) (*entities.ServiceException, error) {
+	rangesJSON, err := marshalRanges(exceptionReq.Ranges)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+		INSERT INTO service_exceptions (service_id, start_date, end_date, type, ranges, reason, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, NOW(), NOW())
+	`
+
+	result, err := r.db.ExecContext(ctx, query,
+		serviceID, exceptionReq.StartDate, exceptionReq.EndDate,
+		exceptionReq.Type, rangesJSON, exceptionReq.Reason,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return nil, err
+	}
+
+	return r.GetByID(ctx, id)
+}
+
+func (r *ServiceExceptionRepository) GetByID(ctx context.Context, id int64) (*entities.ServiceException, error) {
+	query := `
+		SELECT id, service_id, start_date, end_date, type, ranges, reason, created_at, updated_at
+		FROM service_exceptions WHERE id = ?
+	`
+
+	exception, err := scanServiceException(r.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return exception, nil
+}
+
+// GetByServiceIDAndDateRange obtiene las excepciones de un servicio que se superponen con el rango de fechas
+func (r *ServiceExceptionRepository) GetByServiceIDAndDateRange(ctx context.Context, serviceID int64, startDate string, endDate string) ([]*entities.ServiceException, error) {
+	query := `
+		SELECT id, service_id, start_date, end_date, type, ranges, reason, created_at, updated_at
+		FROM service_exceptions
+		WHERE service_id = ? AND start_date <= ? AND end_date >= ?
+		ORDER BY start_date ASC, id ASC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, serviceID, endDate, startDate)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var exceptions []*entities.ServiceException
+	for rows.Next() {
+		exception, err := scanServiceException(rows)
+		if err != nil {
+			return nil, err
+		}
+		exceptions = append(exceptions, exception)
+	}
+
+	return exceptions, nil
+}
+
+func (r *ServiceExceptionRepository) Update(ctx context.Context, id int64, exceptionReq *entities.ServiceExceptionRequest) (*entities.ServiceException, error) {
+	rangesJSON, err := marshalRanges(exceptionReq.Ranges)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+		UPDATE service_exceptions
+		SET start_date = ?, end_date = ?, type = ?, ranges = ?, reason = ?, updated_at = NOW()
+		WHERE id = ?
+	`
+
+	result, err := r.db.ExecContext(ctx, query,
+		exceptionReq.StartDate, exceptionReq.EndDate, exceptionReq.Type,
+		rangesJSON, exceptionReq.Reason, id,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return nil, err
+	}
+	if rowsAffected == 0 {
+		return nil, sql.ErrNoRows
+	}
+
+	return r.GetByID(ctx, id)
+}
+
+func (r *ServiceExceptionRepository) Delete(ctx context.Context, id int64) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM service_exceptions WHERE id = ?`, id)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+func scanServiceException(row rowScanner) (*entities.ServiceException, error) {
+	var exception entities.ServiceException
+	var rangesJSON string
+
+	err := row.Scan(
+		&exception.ID, &exception.ServiceID, &exception.StartDate, &exception.EndDate,
+		&exception.Type, &rangesJSON, &exception.Reason,
+		&exception.CreatedAt, &exception.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal([]byte(rangesJSON), &exception.Ranges); err != nil {
+		return nil, err
+	}
+	if exception.Ranges == nil {
+		exception.Ranges = []entities.TimeRange{}
+	}
+
+	return &exception, nil
+}
+
+func marshalRanges(ranges []entities.TimeRange) (string, error) {
+	if ranges == nil {
+		ranges = []entities.TimeRange{}
+	}
+	rangesJSON, err := json.Marshal(ranges)
+	if err != nil {
+		return "", err
+	}
+	return string(rangesJSON), nil
+}
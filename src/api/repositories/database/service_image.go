@@ -0,0 +1,236 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+
+	"iycds2025_api/src/api/core/entities"
+	"iycds2025_api/src/api/core/errors"
+)
+
+type ServiceImageRepository struct {
+	db *sql.DB
+}
+
+func NewServiceImageRepository(db *sql.DB) *ServiceImageRepository {
+	return &ServiceImageRepository{db: db}
+}
+
+const serviceImageColumns = `id, service_id, position, image_key, thumbnail_key, url, thumbnail_url, content_type,
+	width, height, thumbnail_width, thumbnail_height, size_bytes, created_at`
+
+func (r *ServiceImageRepository) Create(ctx context.Context, image *entities.ServiceImage) (*entities.ServiceImage, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	// Bloquear el servicio para que dos subidas simultáneas no superen el máximo ni repitan la posición
+	if err := lockService(ctx, tx, image.ServiceID); err != nil {
+		return nil, err
+	}
+
+	var count, nextPosition int
+	query := `SELECT COUNT(*), COALESCE(MAX(position) + 1, 0) FROM service_images WHERE service_id = ?`
+	if err := tx.QueryRowContext(ctx, query, image.ServiceID).Scan(&count, &nextPosition); err != nil {
+		return nil, err
+	}
+	if count >= entities.MaxServiceImages {
+		return nil, errors.ErrServiceImageLimit
+	}
+
+	insertQuery := `
+		INSERT INTO service_images (service_id, position, image_key, thumbnail_key, url, thumbnail_url, content_type,
+			width, height, thumbnail_width, thumbnail_height, size_bytes, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, NOW())
+	`
+	result, err := tx.ExecContext(ctx, insertQuery,
+		image.ServiceID, nextPosition, image.ImageKey, image.ThumbnailKey, image.URL, image.ThumbnailURL,
+		image.ContentType, image.Width, image.Height, image.ThumbnailWidth, image.ThumbnailHeight, image.SizeBytes,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := syncCoverImage(ctx, tx, image.ServiceID); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return r.GetByID(ctx, id)
+}
+
+func (r *ServiceImageRepository) GetByID(ctx context.Context, id int64) (*entities.ServiceImage, error) {
+	query := `SELECT ` + serviceImageColumns + ` FROM service_images WHERE id = ?`
+
+	image, err := scanServiceImage(r.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return image, nil
+}
+
+func (r *ServiceImageRepository) GetByServiceID(ctx context.Context, serviceID int64) ([]*entities.ServiceImage, error) {
+	query := `SELECT ` + serviceImageColumns + ` FROM service_images WHERE service_id = ? ORDER BY position, id`
+
+	rows, err := r.db.QueryContext(ctx, query, serviceID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var images []*entities.ServiceImage
+	for rows.Next() {
+		image, err := scanServiceImage(rows)
+		if err != nil {
+			return nil, err
+		}
+		images = append(images, image)
+	}
+
+	return images, rows.Err()
+}
+
+// Delete elimina la foto, corre las siguientes un lugar para no dejar huecos en el orden y
+// actualiza la portada del servicio
+func (r *ServiceImageRepository) Delete(ctx context.Context, id int64) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var serviceID int64
+	var position int
+	query := `SELECT service_id, position FROM service_images WHERE id = ?`
+	if err := tx.QueryRowContext(ctx, query, id).Scan(&serviceID, &position); err != nil {
+		return err
+	}
+	if err := lockService(ctx, tx, serviceID); err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM service_images WHERE id = ?`, id); err != nil {
+		return err
+	}
+	shiftQuery := `UPDATE service_images SET position = position - 1 WHERE service_id = ? AND position > ?`
+	if _, err := tx.ExecContext(ctx, shiftQuery, serviceID, position); err != nil {
+		return err
+	}
+
+	if err := syncCoverImage(ctx, tx, serviceID); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (r *ServiceImageRepository) Reorder(ctx context.Context, serviceID int64, imageIDs []int64) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := lockService(ctx, tx, serviceID); err != nil {
+		return err
+	}
+
+	// Con el servicio bloqueado, la lista tiene que ser exactamente las fotos actuales en otro orden
+	rows, err := tx.QueryContext(ctx, `SELECT id FROM service_images WHERE service_id = ?`, serviceID)
+	if err != nil {
+		return err
+	}
+	current := map[int64]bool{}
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return err
+		}
+		current[id] = true
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	if len(current) != len(imageIDs) {
+		return sql.ErrNoRows
+	}
+	for _, imageID := range imageIDs {
+		if !current[imageID] {
+			return sql.ErrNoRows
+		}
+		// Cada ID se acepta una sola vez
+		delete(current, imageID)
+	}
+
+	for position, imageID := range imageIDs {
+		if _, err := tx.ExecContext(ctx, `UPDATE service_images SET position = ? WHERE id = ?`, position, imageID); err != nil {
+			return err
+		}
+	}
+
+	if err := syncCoverImage(ctx, tx, serviceID); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// lockService bloquea la fila del servicio hasta el fin de la transacción
+func lockService(ctx context.Context, tx *sql.Tx, serviceID int64) error {
+	var id int64
+	return tx.QueryRowContext(ctx, `SELECT id FROM services WHERE id = ? FOR UPDATE`, serviceID).Scan(&id)
+}
+
+// syncCoverImage copia la URL de la primera foto a services.image_url. Si no quedan fotos vuelve a
+// la URL cargada a mano antes de las fotos subidas (vacía en los servicios nuevos).
+func syncCoverImage(ctx context.Context, tx *sql.Tx, serviceID int64) error {
+	query := `
+		UPDATE services
+		SET image_url = COALESCE((SELECT url FROM service_images WHERE service_id = ? ORDER BY position, id LIMIT 1), legacy_image_url),
+		    updated_at = NOW()
+		WHERE id = ?
+	`
+	_, err := tx.ExecContext(ctx, query, serviceID, serviceID)
+	return err
+}
+
+func scanServiceImage(row rowScanner) (*entities.ServiceImage, error) {
+	var image entities.ServiceImage
+	err := row.Scan(
+		&image.ID,
+		&image.ServiceID,
+		&image.Position,
+		&image.ImageKey,
+		&image.ThumbnailKey,
+		&image.URL,
+		&image.ThumbnailURL,
+		&image.ContentType,
+		&image.Width,
+		&image.Height,
+		&image.ThumbnailWidth,
+		&image.ThumbnailHeight,
+		&image.SizeBytes,
+		&image.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &image, nil
+}
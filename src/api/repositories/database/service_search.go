@@ -0,0 +1,91 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+
+	"iycds2025_api/src/api/core/entities"
+	"iycds2025_api/src/api/utils"
+)
+
+// ServiceSearchRepository implementa la búsqueda de servicios con índices FULLTEXT de MySQL.
+// Los textos se indexan ya tokenizados (sin acentos ni stopwords) en las columnas
+// search_title y search_body, que mantiene la aplicación.
+type ServiceSearchRepository struct {
+	db *sql.DB
+}
+
+func NewServiceSearchRepository(db *sql.DB) *ServiceSearchRepository {
+	return &ServiceSearchRepository{db: db}
+}
+
+// titleWeight multiplica la relevancia de las coincidencias en el título
+const titleWeight = 2
+
+func (r *ServiceSearchRepository) Index(ctx context.Context, service *entities.Service) error {
+	titleTokens, bodyTokens := utils.SearchDocument(service)
+
+	query := `UPDATE services SET search_title = ?, search_body = ? WHERE id = ?`
+	_, err := r.db.ExecContext(ctx, query, strings.Join(titleTokens, " "), strings.Join(bodyTokens, " "), service.ID)
+	return err
+}
+
+// Remove no necesita hacer nada: el índice vive en la misma fila que el servicio
+func (r *ServiceSearchRepository) Remove(ctx context.Context, serviceID int64) error {
+	return nil
+}
+
+func (r *ServiceSearchRepository) Search(ctx context.Context, query *entities.ServiceSearchQuery) ([]*entities.ServiceSearchHit, int, error) {
+	// En modo booleano cada término se busca por prefijo ("plom*") y alcanza con que aparezca alguno
+	terms := make([]string, len(query.Terms))
+	for i, term := range query.Terms {
+		terms[i] = term + "*"
+	}
+	against := strings.Join(terms, " ")
+
+	var total int
+	countQuery := `
+		SELECT COUNT(*)
+		FROM services
+		WHERE status = 'active'
+		AND MATCH(search_title, search_body) AGAINST(? IN BOOLEAN MODE)
+	`
+	if err := r.db.QueryRowContext(ctx, countQuery, against).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+	if total == 0 {
+		return []*entities.ServiceSearchHit{}, 0, nil
+	}
+
+	searchQuery := `
+		SELECT ` + serviceColumns + `,
+			MATCH(search_title) AGAINST(? IN BOOLEAN MODE) * ? +
+			MATCH(search_title, search_body) AGAINST(? IN BOOLEAN MODE) AS score
+		FROM services
+		WHERE status = 'active'
+		AND MATCH(search_title, search_body) AGAINST(? IN BOOLEAN MODE)
+		ORDER BY score DESC, id DESC
+		LIMIT ? OFFSET ?
+	`
+	rows, err := r.db.QueryContext(ctx, searchQuery,
+		against, titleWeight, against, against,
+		query.PageSize, (query.Page-1)*query.PageSize,
+	)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	var hits []*entities.ServiceSearchHit
+	for rows.Next() {
+		var score float64
+		service, err := scanService(rows, &score)
+		if err != nil {
+			return nil, 0, err
+		}
+		hits = append(hits, &entities.ServiceSearchHit{Service: service, Score: score})
+	}
+
+	return hits, total, rows.Err()
+}
@@ -0,0 +1,227 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"time"
+	"unicode/utf8"
+
+	"iycds2025_api/src/api/core/entities"
+	"iycds2025_api/src/api/core/errors"
+)
+
+type SessionRepository struct {
+	db *sql.DB
+}
+
+func NewSessionRepository(db *sql.DB) *SessionRepository {
+	return &SessionRepository{db: db}
+}
+
+const sessionColumns = `id, user_id, user_agent, ip_address, expires_at, revoked_at, last_used_at, created_at`
+
+func (r *SessionRepository) Create(ctx context.Context, userID int64, refreshTokenHash string, expiresAt time.Time, client entities.ClientInfo) (*entities.Session, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, `
+		INSERT INTO user_sessions (user_id, user_agent, ip_address, expires_at, last_used_at, created_at, updated_at)
+		VALUES (?, ?, ?, ?, NOW(), NOW(), NOW())
+	`, userID, truncate(client.UserAgent, 255), truncate(client.IPAddress, 45), expiresAt)
+	if err != nil {
+		return nil, err
+	}
+
+	sessionID, err := result.LastInsertId()
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO refresh_tokens (session_id, token_hash, expires_at, created_at)
+		VALUES (?, ?, ?, NOW())
+	`, sessionID, refreshTokenHash, expiresAt)
+	if err != nil {
+		return nil, err
+	}
+
+	session, err := scanSession(tx.QueryRowContext(ctx, `SELECT `+sessionColumns+` FROM user_sessions WHERE id = ?`, sessionID))
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return session, nil
+}
+
+func (r *SessionRepository) GetByID(ctx context.Context, id int64) (*entities.Session, error) {
+	session, err := scanSession(r.db.QueryRowContext(ctx, `SELECT `+sessionColumns+` FROM user_sessions WHERE id = ?`, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return session, nil
+}
+
+func (r *SessionRepository) Rotate(ctx context.Context, refreshTokenHash string, newRefreshTokenHash string, expiresAt time.Time) (*entities.Session, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	// Bloquear el token para que dos refresh simultáneos con el mismo token no roten ambos
+	var tokenID, sessionID int64
+	var tokenExpiresAt time.Time
+	var usedAt sql.NullTime
+	err = tx.QueryRowContext(ctx, `
+		SELECT id, session_id, expires_at, used_at FROM refresh_tokens
+		WHERE token_hash = ?
+		FOR UPDATE
+	`, refreshTokenHash).Scan(&tokenID, &sessionID, &tokenExpiresAt, &usedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil // Token desconocido
+		}
+		return nil, err
+	}
+
+	// Un token ya rotado que vuelve a aparecer indica que fue copiado: se revoca toda la sesión
+	if usedAt.Valid {
+		if _, err := tx.ExecContext(ctx, `
+			UPDATE user_sessions SET revoked_at = NOW(), updated_at = NOW()
+			WHERE id = ? AND revoked_at IS NULL
+		`, sessionID); err != nil {
+			return nil, err
+		}
+		if err := tx.Commit(); err != nil {
+			return nil, err
+		}
+		return nil, errors.ErrRefreshTokenReused
+	}
+
+	session, err := scanSession(tx.QueryRowContext(ctx, `SELECT `+sessionColumns+` FROM user_sessions WHERE id = ? FOR UPDATE`, sessionID))
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	if !session.IsActive(now) || !now.Before(tokenExpiresAt) {
+		return nil, nil // Sesión revocada o token vencido
+	}
+
+	if _, err := tx.ExecContext(ctx, `UPDATE refresh_tokens SET used_at = NOW() WHERE id = ?`, tokenID); err != nil {
+		return nil, err
+	}
+	if _, err := tx.ExecContext(ctx, `
+		INSERT INTO refresh_tokens (session_id, token_hash, expires_at, created_at)
+		VALUES (?, ?, ?, NOW())
+	`, sessionID, newRefreshTokenHash, expiresAt); err != nil {
+		return nil, err
+	}
+	if _, err := tx.ExecContext(ctx, `
+		UPDATE user_sessions SET expires_at = ?, last_used_at = NOW(), updated_at = NOW()
+		WHERE id = ?
+	`, expiresAt, sessionID); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	session.ExpiresAt = expiresAt
+	session.LastUsedAt = now
+	return session, nil
+}
+
+func (r *SessionRepository) Revoke(ctx context.Context, id int64, userID int64) error {
+	query := `
+		UPDATE user_sessions SET revoked_at = NOW(), updated_at = NOW()
+		WHERE id = ? AND user_id = ? AND revoked_at IS NULL
+	`
+
+	result, err := r.db.ExecContext(ctx, query, id, userID)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return sql.ErrNoRows // No existe, no pertenece al usuario o ya estaba revocada
+	}
+
+	return nil
+}
+
+func (r *SessionRepository) RevokeAllForUser(ctx context.Context, userID int64, exceptID int64) (int64, error) {
+	return revokeUserSessions(ctx, r.db, userID, exceptID)
+}
+
+// execer permite ejecutar sentencias tanto sobre *sql.DB como dentro de una *sql.Tx
+type execer interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+}
+
+// revokeUserSessions revoca las sesiones activas de un usuario salvo exceptID; la usan también
+// otros repositorios dentro de sus transacciones (ej: al restablecer la contraseña)
+func revokeUserSessions(ctx context.Context, db execer, userID int64, exceptID int64) (int64, error) {
+	query := `
+		UPDATE user_sessions SET revoked_at = NOW(), updated_at = NOW()
+		WHERE user_id = ? AND id <> ? AND revoked_at IS NULL
+	`
+
+	result, err := db.ExecContext(ctx, query, userID, exceptID)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
+
+func scanSession(row rowScanner) (*entities.Session, error) {
+	var session entities.Session
+	var revokedAt sql.NullTime
+
+	err := row.Scan(
+		&session.ID,
+		&session.UserID,
+		&session.UserAgent,
+		&session.IPAddress,
+		&session.ExpiresAt,
+		&revokedAt,
+		&session.LastUsedAt,
+		&session.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if revokedAt.Valid {
+		session.RevokedAt = &revokedAt.Time
+	}
+
+	return &session, nil
+}
+
+// truncate recorta un texto a max bytes sin cortar caracteres UTF-8 a la mitad
+func truncate(text string, max int) string {
+	if len(text) <= max {
+		return text
+	}
+	for max > 0 && !utf8.RuneStart(text[max]) {
+		max--
+	}
+	return text[:max]
+}
@@ -0,0 +1,247 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"iycds2025_api/src/api/core/entities"
+	"iycds2025_api/src/api/core/errors"
+)
+
+// fakeSessionStore simula las tablas user_sessions y refresh_tokens; el driver de abajo
+// interpreta solo las consultas que usa Rotate y falla ante cualquier otra
+type fakeSessionStore struct {
+	sessions map[int64]*entities.Session
+	tokens   map[string]*fakeRefreshToken
+	nextID   int64
+}
+
+type fakeRefreshToken struct {
+	id        int64
+	sessionID int64
+	expiresAt time.Time
+	usedAt    *time.Time
+}
+
+func newFakeSessionStore() *fakeSessionStore {
+	return &fakeSessionStore{
+		sessions: make(map[int64]*entities.Session),
+		tokens:   make(map[string]*fakeRefreshToken),
+		nextID:   1,
+	}
+}
+
+func (s *fakeSessionStore) addSession(id int64, revoked bool) {
+	now := time.Now()
+	session := &entities.Session{ID: id, UserID: 10, ExpiresAt: now.Add(time.Hour), LastUsedAt: now, CreatedAt: now}
+	if revoked {
+		session.RevokedAt = &now
+	}
+	s.sessions[id] = session
+}
+
+func (s *fakeSessionStore) addToken(hash string, sessionID int64, expiresAt time.Time) {
+	s.tokens[hash] = &fakeRefreshToken{id: s.nextID, sessionID: sessionID, expiresAt: expiresAt}
+	s.nextID++
+}
+
+func (s *fakeSessionStore) query(query string, args []driver.NamedValue) (driver.Rows, error) {
+	switch normalizeQuery(query) {
+	case "SELECT id, session_id, expires_at, used_at FROM refresh_tokens WHERE token_hash = ? FOR UPDATE":
+		token, ok := s.tokens[args[0].Value.(string)]
+		if !ok {
+			return &fakeRows{columns: []string{"id", "session_id", "expires_at", "used_at"}}, nil
+		}
+		var usedAt driver.Value
+		if token.usedAt != nil {
+			usedAt = *token.usedAt
+		}
+		return &fakeRows{
+			columns: []string{"id", "session_id", "expires_at", "used_at"},
+			values:  [][]driver.Value{{token.id, token.sessionID, token.expiresAt, usedAt}},
+		}, nil
+	case "SELECT " + sessionColumns + " FROM user_sessions WHERE id = ? FOR UPDATE":
+		columns := strings.Split(sessionColumns, ", ")
+		session, ok := s.sessions[args[0].Value.(int64)]
+		if !ok {
+			return &fakeRows{columns: columns}, nil
+		}
+		var revokedAt driver.Value
+		if session.RevokedAt != nil {
+			revokedAt = *session.RevokedAt
+		}
+		return &fakeRows{
+			columns: columns,
+			values: [][]driver.Value{{
+				session.ID, session.UserID, session.UserAgent, session.IPAddress,
+				session.ExpiresAt, revokedAt, session.LastUsedAt, session.CreatedAt,
+			}},
+		}, nil
+	}
+	return nil, fmt.Errorf("unexpected query: %s", query)
+}
+
+func (s *fakeSessionStore) exec(query string, args []driver.NamedValue) (driver.Result, error) {
+	now := time.Now()
+	switch normalizeQuery(query) {
+	case "UPDATE user_sessions SET revoked_at = NOW(), updated_at = NOW() WHERE id = ? AND revoked_at IS NULL":
+		if session, ok := s.sessions[args[0].Value.(int64)]; ok && session.RevokedAt == nil {
+			session.RevokedAt = &now
+			return driver.RowsAffected(1), nil
+		}
+		return driver.RowsAffected(0), nil
+	case "UPDATE refresh_tokens SET used_at = NOW() WHERE id = ?":
+		for _, token := range s.tokens {
+			if token.id == args[0].Value.(int64) {
+				token.usedAt = &now
+				return driver.RowsAffected(1), nil
+			}
+		}
+		return driver.RowsAffected(0), nil
+	case "INSERT INTO refresh_tokens (session_id, token_hash, expires_at, created_at) VALUES (?, ?, ?, NOW())":
+		s.addToken(args[1].Value.(string), args[0].Value.(int64), args[2].Value.(time.Time))
+		return driver.RowsAffected(1), nil
+	case "UPDATE user_sessions SET expires_at = ?, last_used_at = NOW(), updated_at = NOW() WHERE id = ?":
+		session := s.sessions[args[1].Value.(int64)]
+		session.ExpiresAt = args[0].Value.(time.Time)
+		session.LastUsedAt = now
+		return driver.RowsAffected(1), nil
+	}
+	return nil, fmt.Errorf("unexpected statement: %s", query)
+}
+
+func normalizeQuery(query string) string {
+	return strings.Join(strings.Fields(query), " ")
+}
+
+// Driver mínimo de database/sql sobre fakeSessionStore; las transacciones no se deshacen
+type fakeConnector struct{ store *fakeSessionStore }
+
+func (c *fakeConnector) Connect(context.Context) (driver.Conn, error) { return &fakeConn{c.store}, nil }
+func (c *fakeConnector) Driver() driver.Driver                        { return fakeDriver{} }
+
+type fakeDriver struct{}
+
+func (fakeDriver) Open(string) (driver.Conn, error) { return nil, fmt.Errorf("use the connector") }
+
+type fakeConn struct{ store *fakeSessionStore }
+
+func (c *fakeConn) Prepare(query string) (driver.Stmt, error) {
+	return nil, fmt.Errorf("prepared statements are not supported")
+}
+func (c *fakeConn) Close() error              { return nil }
+func (c *fakeConn) Begin() (driver.Tx, error) { return fakeTx{}, nil }
+func (c *fakeConn) QueryContext(_ context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	return c.store.query(query, args)
+}
+func (c *fakeConn) ExecContext(_ context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	return c.store.exec(query, args)
+}
+
+type fakeTx struct{}
+
+func (fakeTx) Commit() error   { return nil }
+func (fakeTx) Rollback() error { return nil }
+
+type fakeRows struct {
+	columns []string
+	values  [][]driver.Value
+}
+
+func (r *fakeRows) Columns() []string { return r.columns }
+func (r *fakeRows) Close() error      { return nil }
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if len(r.values) == 0 {
+		return io.EOF
+	}
+	copy(dest, r.values[0])
+	r.values = r.values[1:]
+	return nil
+}
+
+func newFakeSessionRepository(t *testing.T, store *fakeSessionStore) *SessionRepository {
+	t.Helper()
+	db := sql.OpenDB(&fakeConnector{store})
+	t.Cleanup(func() { db.Close() })
+	return NewSessionRepository(db)
+}
+
+func TestSessionRotate(t *testing.T) {
+	ctx := context.Background()
+	store := newFakeSessionStore()
+	store.addSession(1, false)
+	store.addToken("old", 1, time.Now().Add(time.Hour))
+	repo := newFakeSessionRepository(t, store)
+
+	expiresAt := time.Now().Add(24 * time.Hour).Truncate(time.Second)
+	session, err := repo.Rotate(ctx, "old", "new", expiresAt)
+	if err != nil {
+		t.Fatalf("Rotate: %v", err)
+	}
+	if session == nil || session.ID != 1 || !session.ExpiresAt.Equal(expiresAt) {
+		t.Fatalf("Rotate returned %+v, want session 1 expiring at %v", session, expiresAt)
+	}
+	if store.tokens["old"].usedAt == nil {
+		t.Error("rotated token was not marked as used")
+	}
+	if token, ok := store.tokens["new"]; !ok || token.sessionID != 1 {
+		t.Error("new refresh token was not stored for the session")
+	}
+
+	// El token ya rotado vuelve a aparecer: se rechaza y se revoca la sesión completa
+	session, err = repo.Rotate(ctx, "old", "other", expiresAt)
+	if err != errors.ErrRefreshTokenReused {
+		t.Fatalf("reused token: got (%v, %v), want ErrRefreshTokenReused", session, err)
+	}
+	if store.sessions[1].RevokedAt == nil {
+		t.Fatal("session was not revoked after refresh token reuse")
+	}
+	if _, ok := store.tokens["other"]; ok {
+		t.Error("a token was issued for a reused refresh token")
+	}
+
+	// Con la sesión revocada, tampoco sirve el token que se había entregado legítimamente
+	session, err = repo.Rotate(ctx, "new", "other", expiresAt)
+	if err != nil || session != nil {
+		t.Fatalf("token of revoked session: got (%v, %v), want (nil, nil)", session, err)
+	}
+}
+
+func TestSessionRotateRejected(t *testing.T) {
+	tests := []struct {
+		name           string
+		revoked        bool
+		tokenExpiresAt time.Time
+		presented      string
+	}{
+		{name: "unknown token", tokenExpiresAt: time.Now().Add(time.Hour), presented: "missing"},
+		{name: "expired token", tokenExpiresAt: time.Now().Add(-time.Minute), presented: "old"},
+		{name: "revoked session", revoked: true, tokenExpiresAt: time.Now().Add(time.Hour), presented: "old"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := newFakeSessionStore()
+			store.addSession(1, tt.revoked)
+			store.addToken("old", 1, tt.tokenExpiresAt)
+			repo := newFakeSessionRepository(t, store)
+
+			session, err := repo.Rotate(context.Background(), tt.presented, "new", time.Now().Add(time.Hour))
+			if err != nil || session != nil {
+				t.Fatalf("got (%v, %v), want (nil, nil)", session, err)
+			}
+			if store.tokens["old"].usedAt != nil {
+				t.Error("rejected token was marked as used")
+			}
+			if _, ok := store.tokens["new"]; ok {
+				t.Error("a new refresh token was stored")
+			}
+		})
+	}
+}
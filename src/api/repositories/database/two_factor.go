@@ -0,0 +1,245 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"iycds2025_api/src/api/core/entities"
+)
+
+type TwoFactorRepository struct {
+	db *sql.DB
+}
+
+func NewTwoFactorRepository(db *sql.DB) *TwoFactorRepository {
+	return &TwoFactorRepository{db: db}
+}
+
+func (r *TwoFactorRepository) GetTOTP(ctx context.Context, userID int64) (*entities.UserTOTP, error) {
+	totp := &entities.UserTOTP{}
+	var confirmedAt sql.NullTime
+
+	query := `SELECT user_id, secret, confirmed_at, last_used_step FROM user_totp WHERE user_id = ?`
+	err := r.db.QueryRowContext(ctx, query, userID).Scan(&totp.UserID, &totp.Secret, &confirmedAt, &totp.LastUsedStep)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	if confirmedAt.Valid {
+		totp.ConfirmedAt = &confirmedAt.Time
+	}
+	return totp, nil
+}
+
+// SaveTOTPSecret guarda un secreto nuevo pendiente de confirmar. Si el usuario ya tenía uno
+// pendiente se reemplaza; uno confirmado no se toca.
+func (r *TwoFactorRepository) SaveTOTPSecret(ctx context.Context, userID int64, secret string) error {
+	query := `
+		INSERT INTO user_totp (user_id, secret, confirmed_at, last_used_step, created_at, updated_at)
+		VALUES (?, ?, NULL, 0, NOW(), NOW())
+		ON DUPLICATE KEY UPDATE
+			secret = IF(confirmed_at IS NULL, VALUES(secret), secret),
+			updated_at = NOW()
+	`
+	_, err := r.db.ExecContext(ctx, query, userID, secret)
+	return err
+}
+
+// EnableTOTP confirma el secreto pendiente, registra el intervalo del código con el que se
+// confirmó y guarda los códigos de recuperación, todo en una transacción
+func (r *TwoFactorRepository) EnableTOTP(ctx context.Context, userID int64, step int64, recoveryCodeHashes []string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query := `
+		UPDATE user_totp
+		SET confirmed_at = NOW(), last_used_step = ?, updated_at = NOW()
+		WHERE user_id = ? AND confirmed_at IS NULL
+	`
+	result, err := tx.ExecContext(ctx, query, step, userID)
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		// Otro request confirmó primero o el secreto ya no está pendiente
+		return sql.ErrNoRows
+	}
+
+	if err := replaceRecoveryCodes(ctx, tx, userID, recoveryCodeHashes); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (r *TwoFactorRepository) DisableTOTP(ctx context.Context, userID int64) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM user_totp WHERE user_id = ?`, userID); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM user_recovery_codes WHERE user_id = ?`, userID); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, `UPDATE login_challenges SET used_at = NOW() WHERE user_id = ? AND used_at IS NULL`, userID); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// UseTOTPStep acepta el intervalo solo si es posterior al último usado. Al hacerlo con un UPDATE
+// condicional, dos requests con el mismo código no pueden pasar los dos.
+func (r *TwoFactorRepository) UseTOTPStep(ctx context.Context, userID int64, step int64) (bool, error) {
+	query := `
+		UPDATE user_totp
+		SET last_used_step = ?, updated_at = NOW()
+		WHERE user_id = ? AND last_used_step < ?
+	`
+	result, err := r.db.ExecContext(ctx, query, step, userID, step)
+	if err != nil {
+		return false, err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return rows > 0, nil
+}
+
+func (r *TwoFactorRepository) ReplaceRecoveryCodes(ctx context.Context, userID int64, codeHashes []string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := replaceRecoveryCodes(ctx, tx, userID, codeHashes); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// replaceRecoveryCodes borra los códigos anteriores del usuario, usados o no, y guarda los nuevos
+func replaceRecoveryCodes(ctx context.Context, tx *sql.Tx, userID int64, codeHashes []string) error {
+	if _, err := tx.ExecContext(ctx, `DELETE FROM user_recovery_codes WHERE user_id = ?`, userID); err != nil {
+		return err
+	}
+
+	for _, hash := range codeHashes {
+		_, err := tx.ExecContext(ctx, `INSERT INTO user_recovery_codes (user_id, code_hash, created_at) VALUES (?, ?, NOW())`, userID, hash)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *TwoFactorRepository) UseRecoveryCode(ctx context.Context, userID int64, codeHash string) (bool, error) {
+	query := `
+		UPDATE user_recovery_codes
+		SET used_at = NOW()
+		WHERE user_id = ? AND code_hash = ? AND used_at IS NULL
+		LIMIT 1
+	`
+	result, err := r.db.ExecContext(ctx, query, userID, codeHash)
+	if err != nil {
+		return false, err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return rows > 0, nil
+}
+
+func (r *TwoFactorRepository) CountRecoveryCodes(ctx context.Context, userID int64) (int, error) {
+	var count int
+	query := `SELECT COUNT(*) FROM user_recovery_codes WHERE user_id = ? AND used_at IS NULL`
+	if err := r.db.QueryRowContext(ctx, query, userID).Scan(&count); err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+func (r *TwoFactorRepository) CreateChallenge(ctx context.Context, userID int64, tokenHash string, expiresAt time.Time, client entities.ClientInfo) error {
+	query := `
+		INSERT INTO login_challenges (user_id, token_hash, attempts, expires_at, ip_address, user_agent, created_at)
+		VALUES (?, ?, 0, ?, ?, ?, NOW())
+	`
+	_, err := r.db.ExecContext(ctx, query, userID, tokenHash, expiresAt, truncate(client.IPAddress, 45), truncate(client.UserAgent, 255))
+	return err
+}
+
+func (r *TwoFactorRepository) GetChallenge(ctx context.Context, tokenHash string) (*entities.LoginChallenge, error) {
+	challenge := &entities.LoginChallenge{}
+	var usedAt sql.NullTime
+
+	query := `SELECT id, user_id, attempts, expires_at, used_at FROM login_challenges WHERE token_hash = ?`
+	err := r.db.QueryRowContext(ctx, query, tokenHash).Scan(&challenge.ID, &challenge.UserID, &challenge.Attempts, &challenge.ExpiresAt, &usedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	if usedAt.Valid || time.Now().After(challenge.ExpiresAt) {
+		return nil, nil
+	}
+	return challenge, nil
+}
+
+func (r *TwoFactorRepository) RecordChallengeFailure(ctx context.Context, id int64) (int, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `UPDATE login_challenges SET attempts = attempts + 1 WHERE id = ?`, id); err != nil {
+		return 0, err
+	}
+
+	var attempts int
+	if err := tx.QueryRowContext(ctx, `SELECT attempts FROM login_challenges WHERE id = ?`, id).Scan(&attempts); err != nil {
+		return 0, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+
+	return attempts, nil
+}
+
+func (r *TwoFactorRepository) ConsumeChallenge(ctx context.Context, id int64) (bool, error) {
+	result, err := r.db.ExecContext(ctx, `UPDATE login_challenges SET used_at = NOW() WHERE id = ? AND used_at IS NULL`, id)
+	if err != nil {
+		return false, err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return rows > 0, nil
+}
@@ -5,6 +5,7 @@ import (
 	"crypto/rand"
 	"database/sql"
 	"encoding/hex"
+	"strings"
 	"time"
 
 	"iycds2025_api/src/api/core/entities"
@@ -16,14 +17,13 @@ type UserRepository struct {
 	DB *sql.DB
 }
 
+// userColumns son las columnas que se leen de la tabla users, en el orden que espera scanUser
+const userColumns = `id, name, display_name, bio, avatar_url, email, email_verified_at, password, locality, province, phone, locale, first_login, suspended_at, suspension_reason, failed_login_count, locked_until, deletion_requested_at, deletion_scheduled_at, deleted_at, created_at, updated_at`
+
 func (r *UserRepository) GetByEmail(ctx context.Context, email string) (*entities.User, error) {
-	var user entities.User
-	query := `SELECT id, name, email, password, locality, province, phone, first_login, created_at, updated_at FROM users WHERE email = ?`
+	query := `SELECT ` + userColumns + ` FROM users WHERE email = ?`
 
-	err := r.DB.QueryRowContext(ctx, query, email).Scan(
-		&user.ID, &user.Name, &user.Email, &user.Password, &user.Locality,
-		&user.Province, &user.Phone, &user.FirstLogin, &user.CreatedAt, &user.UpdatedAt,
-	)
+	user, err := scanUser(r.DB.QueryRowContext(ctx, query, email))
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, nil // Usuario no encontrado
@@ -31,11 +31,16 @@ func (r *UserRepository) GetByEmail(ctx context.Context, email string) (*entitie
 		return nil, err
 	}
 
-	return &user, nil
+	return user, nil
 }
 
 // Create crea un nuevo usuario en la base de datos
 func (r *UserRepository) Create(ctx context.Context, userRegister *entities.UserRegister) (*entities.User, error) {
+	locale := userRegister.Locale
+	if locale == "" {
+		locale = entities.DefaultLocale
+	}
+
 	user := &entities.User{
 		Name:       userRegister.Name,
 		Email:      userRegister.Email,
@@ -43,17 +48,18 @@ func (r *UserRepository) Create(ctx context.Context, userRegister *entities.User
 		Locality:   userRegister.Locality,
 		Province:   userRegister.Province,
 		Phone:      userRegister.Phone,
+		Locale:     locale,
 		FirstLogin: true,
 		CreatedAt:  time.Now(),
 		UpdatedAt:  time.Now(),
 	}
 
-	query := `INSERT INTO users (name, email, password, locality, province, phone, first_login, created_at, updated_at)
-			  VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`
+	query := `INSERT INTO users (name, email, password, locality, province, phone, locale, first_login, created_at, updated_at)
+			  VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
 
 	result, err := r.DB.ExecContext(ctx, query,
 		user.Name, user.Email, user.Password, user.Locality,
-		user.Province, user.Phone, user.FirstLogin,
+		user.Province, user.Phone, user.Locale, user.FirstLogin,
 		user.CreatedAt, user.UpdatedAt,
 	)
 	if err != nil {
@@ -182,8 +188,13 @@ func (r *UserRepository) ResetPassword(ctx context.Context, token string, newPas
 	}
 	defer tx.Rollback()
 
-	// Actualizar la contraseña del usuario
-	updateUserQuery := `UPDATE users SET password = ?, updated_at = NOW() WHERE id = ?`
+	// Actualizar la contraseña del usuario; quien recibió el enlace en su email es el dueño,
+	// así que también se levanta el bloqueo del login por intentos fallidos
+	updateUserQuery := `
+		UPDATE users
+		SET password = ?, failed_login_count = 0, last_failed_login_at = NULL, locked_until = NULL, updated_at = NOW()
+		WHERE id = ?
+	`
 	_, err = tx.ExecContext(ctx, updateUserQuery, hashedPassword, userID)
 	if err != nil {
 		return err
@@ -196,19 +207,221 @@ func (r *UserRepository) ResetPassword(ctx context.Context, token string, newPas
 		return err
 	}
 
+	// Cerrar todas las sesiones: quien tenía la contraseña anterior no debe seguir conectado
+	if _, err := revokeUserSessions(ctx, tx, userID, 0); err != nil {
+		return err
+	}
+
 	// Commit de la transacción
 	return tx.Commit()
 }
 
+// ChangePassword guarda la nueva contraseña (ya hasheada), marca que el usuario ya no está en su
+// primer login y revoca las demás sesiones salvo la actual; devuelve cuántas sesiones se cerraron
+func (r *UserRepository) ChangePassword(ctx context.Context, userID int64, hashedPassword string, currentSessionID int64) (int64, error) {
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	query := `UPDATE users SET password = ?, first_login = false, updated_at = NOW() WHERE id = ?`
+	result, err := tx.ExecContext(ctx, query, hashedPassword, userID)
+	if err != nil {
+		return 0, err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+	if rowsAffected == 0 {
+		return 0, sql.ErrNoRows
+	}
+
+	// Los enlaces de restablecimiento pendientes se pidieron con la contraseña anterior
+	if _, err := tx.ExecContext(ctx, `UPDATE password_reset_tokens SET used = true WHERE user_id = ? AND used = false`, userID); err != nil {
+		return 0, err
+	}
+
+	revoked, err := revokeUserSessions(ctx, tx, userID, currentSessionID)
+	if err != nil {
+		return 0, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+
+	return revoked, nil
+}
+
+// RecordLoginFailure suma un intento de login fallido y devuelve los fallidos consecutivos.
+// Si el último fallo es anterior a window, el conteo vuelve a empezar.
+func (r *UserRepository) RecordLoginFailure(ctx context.Context, userID int64, window time.Duration) (int, error) {
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	// MySQL asigna en orden: failed_login_count se calcula con el last_failed_login_at anterior
+	query := `
+		UPDATE users
+		SET failed_login_count = IF(last_failed_login_at IS NULL OR last_failed_login_at < ?, 1, failed_login_count + 1),
+		    last_failed_login_at = NOW()
+		WHERE id = ?
+	`
+	if _, err := tx.ExecContext(ctx, query, time.Now().Add(-window), userID); err != nil {
+		return 0, err
+	}
+
+	var failures int
+	if err := tx.QueryRowContext(ctx, `SELECT failed_login_count FROM users WHERE id = ?`, userID).Scan(&failures); err != nil {
+		return 0, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+
+	return failures, nil
+}
+
+// LockLogin bloquea el login de la cuenta hasta la fecha indicada
+func (r *UserRepository) LockLogin(ctx context.Context, userID int64, until time.Time) error {
+	query := `UPDATE users SET locked_until = ? WHERE id = ?`
+	_, err := r.DB.ExecContext(ctx, query, until, userID)
+	return err
+}
+
+// ResetLoginFailures borra los intentos fallidos y el bloqueo después de un login exitoso
+func (r *UserRepository) ResetLoginFailures(ctx context.Context, userID int64) error {
+	query := `UPDATE users SET failed_login_count = 0, last_failed_login_at = NULL, locked_until = NULL WHERE id = ?`
+	_, err := r.DB.ExecContext(ctx, query, userID)
+	return err
+}
+
+// emailVerificationTTL es la vigencia del enlace de verificación de email
+const emailVerificationTTL = 24 * time.Hour
+
+// CreateEmailVerificationToken crea un token para confirmar el email indicado y anula los que el
+// usuario tuviera pendientes: solo el último enlace enviado es válido
+func (r *UserRepository) CreateEmailVerificationToken(ctx context.Context, userID int64, email string) (string, time.Time, error) {
+	tokenBytes := make([]byte, 32)
+	if _, err := rand.Read(tokenBytes); err != nil {
+		return "", time.Time{}, err
+	}
+	token := hex.EncodeToString(tokenBytes)
+	expiresAt := time.Now().Add(emailVerificationTTL)
+
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `UPDATE email_verification_tokens SET used = true WHERE user_id = ? AND used = false`, userID); err != nil {
+		return "", time.Time{}, err
+	}
+
+	query := `
+		INSERT INTO email_verification_tokens (user_id, email, token_hash, expires_at, used)
+		VALUES (?, ?, ?, ?, false)
+	`
+	if _, err := tx.ExecContext(ctx, query, userID, email, utils.HashToken(token), expiresAt); err != nil {
+		return "", time.Time{}, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return "", time.Time{}, err
+	}
+
+	return token, expiresAt, nil
+}
+
+// GetPendingVerificationEmail devuelve el email del último enlace de verificación sin usar del
+// usuario (aunque haya expirado), o "" si no tiene ninguno pendiente
+func (r *UserRepository) GetPendingVerificationEmail(ctx context.Context, userID int64) (string, error) {
+	var email string
+	query := `
+		SELECT email FROM email_verification_tokens
+		WHERE user_id = ? AND used = false
+		ORDER BY id DESC
+		LIMIT 1
+	`
+	err := r.DB.QueryRowContext(ctx, query, userID).Scan(&email)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return "", nil
+		}
+		return "", err
+	}
+
+	return email, nil
+}
+
+// VerifyEmail confirma el email del token: marca la cuenta como verificada y, si el token es de
+// un cambio de email, reemplaza el email de la cuenta por el nuevo
+func (r *UserRepository) VerifyEmail(ctx context.Context, token string) (*entities.User, error) {
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	var userID int64
+	var email string
+	var expiresAt time.Time
+	var used bool
+
+	query := `SELECT user_id, email, expires_at, used FROM email_verification_tokens WHERE token_hash = ? FOR UPDATE`
+	err = tx.QueryRowContext(ctx, query, utils.HashToken(token)).Scan(&userID, &email, &expiresAt, &used)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.NewBadRequest("Invalid or expired token")
+		}
+		return nil, err
+	}
+
+	if used {
+		return nil, errors.NewBadRequest("Token has already been used")
+	}
+
+	if time.Now().After(expiresAt) {
+		return nil, errors.NewBadRequest("Token has expired")
+	}
+
+	// Se conserva la fecha de la primera verificación si el email no cambia
+	updateUserQuery := `
+		UPDATE users
+		SET email_verified_at = IF(email = ?, COALESCE(email_verified_at, NOW()), NOW()), email = ?, updated_at = NOW()
+		WHERE id = ?
+	`
+	if _, err := tx.ExecContext(ctx, updateUserQuery, email, email, userID); err != nil {
+		// Otra cuenta se registró con el nuevo email mientras el cambio esperaba confirmación
+		if isDuplicateKeyError(err) {
+			return nil, errors.NewConflict("Email is already in use")
+		}
+		return nil, err
+	}
+
+	updateTokenQuery := `UPDATE email_verification_tokens SET used = true WHERE user_id = ? AND used = false`
+	if _, err := tx.ExecContext(ctx, updateTokenQuery, userID); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return r.GetByID(ctx, userID)
+}
+
 // GetByID obtiene un usuario por su ID
 func (r *UserRepository) GetByID(ctx context.Context, userID int64) (*entities.User, error) {
-	var user entities.User
-	query := `SELECT id, name, email, password, locality, province, phone, first_login, created_at, updated_at FROM users WHERE id = ?`
+	query := `SELECT ` + userColumns + ` FROM users WHERE id = ?`
 
-	err := r.DB.QueryRowContext(ctx, query, userID).Scan(
-		&user.ID, &user.Name, &user.Email, &user.Password, &user.Locality,
-		&user.Province, &user.Phone, &user.FirstLogin, &user.CreatedAt, &user.UpdatedAt,
-	)
+	user, err := scanUser(r.DB.QueryRowContext(ctx, query, userID))
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, nil // Usuario no encontrado
@@ -216,7 +429,7 @@ func (r *UserRepository) GetByID(ctx context.Context, userID int64) (*entities.U
 		return nil, err
 	}
 
-	return &user, nil
+	return user, nil
 }
 
 // Update actualiza la información de un usuario
@@ -245,6 +458,23 @@ func (r *UserRepository) Update(ctx context.Context, userID int64, userUpdate *e
 		setParts = append(setParts, "phone = ?")
 		args = append(args, userUpdate.Phone)
 	}
+	if userUpdate.Locale != "" {
+		setParts = append(setParts, "locale = ?")
+		args = append(args, userUpdate.Locale)
+	}
+	// Los campos del perfil público se actualizan aunque vengan vacíos (se borran)
+	if userUpdate.DisplayName != nil {
+		setParts = append(setParts, "display_name = ?")
+		args = append(args, *userUpdate.DisplayName)
+	}
+	if userUpdate.Bio != nil {
+		setParts = append(setParts, "bio = ?")
+		args = append(args, *userUpdate.Bio)
+	}
+	if userUpdate.AvatarURL != nil {
+		setParts = append(setParts, "avatar_url = ?")
+		args = append(args, *userUpdate.AvatarURL)
+	}
 
 	if len(setParts) == 0 {
 		// Si no hay cambios, retornar el usuario actual
@@ -272,3 +502,156 @@ func (r *UserRepository) Update(ctx context.Context, userID int64, userUpdate *e
 	// Retornar el usuario actualizado
 	return r.GetByID(ctx, userID)
 }
+
+// List devuelve una página de usuarios que cumplen el filtro y el total de coincidencias
+func (r *UserRepository) List(ctx context.Context, filter *entities.UserFilter) ([]*entities.User, int, error) {
+	conditions := []string{"1 = 1"}
+	var args []interface{}
+
+	if filter.Query != "" {
+		pattern := "%" + escapeLike(filter.Query) + "%"
+		conditions = append(conditions, "(name LIKE ? OR email LIKE ?)")
+		args = append(args, pattern, pattern)
+	}
+	switch filter.Status {
+	case entities.UserStatusActive:
+		conditions = append(conditions, "suspended_at IS NULL AND deleted_at IS NULL")
+	case entities.UserStatusSuspended:
+		conditions = append(conditions, "suspended_at IS NOT NULL AND deleted_at IS NULL")
+	case entities.UserStatusDeleted:
+		conditions = append(conditions, "deleted_at IS NOT NULL")
+	}
+
+	where := strings.Join(conditions, " AND ")
+
+	var total int
+	countQuery := `SELECT COUNT(*) FROM users WHERE ` + where
+	if err := r.DB.QueryRowContext(ctx, countQuery, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+	if total == 0 {
+		return []*entities.User{}, 0, nil
+	}
+
+	query := `
+		SELECT ` + userColumns + `
+		FROM users
+		WHERE ` + where + `
+		ORDER BY created_at DESC, id DESC
+		LIMIT ? OFFSET ?
+	`
+	pageArgs := append(args, filter.PageSize, (filter.Page-1)*filter.PageSize)
+
+	rows, err := r.DB.QueryContext(ctx, query, pageArgs...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	var users []*entities.User
+	for rows.Next() {
+		user, err := scanUser(rows)
+		if err != nil {
+			return nil, 0, err
+		}
+		users = append(users, user)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, err
+	}
+
+	return users, total, nil
+}
+
+// Suspend suspende la cuenta y cierra todas sus sesiones en la misma transacción
+func (r *UserRepository) Suspend(ctx context.Context, userID int64, reason string) error {
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// Si ya estaba suspendida se conserva la fecha original y se actualiza el motivo
+	query := `
+		UPDATE users
+		SET suspended_at = COALESCE(suspended_at, NOW()), suspension_reason = ?, updated_at = NOW()
+		WHERE id = ?
+	`
+	result, err := tx.ExecContext(ctx, query, reason, userID)
+	if err != nil {
+		return err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+
+	// Los access tokens vigentes dejan de valer en cuanto se revoca su sesión
+	if _, err := revokeUserSessions(ctx, tx, userID, 0); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// Reactivate levanta la suspensión de la cuenta
+func (r *UserRepository) Reactivate(ctx context.Context, userID int64) error {
+	query := `
+		UPDATE users
+		SET suspended_at = NULL, suspension_reason = '', updated_at = NOW()
+		WHERE id = ?
+	`
+	result, err := r.DB.ExecContext(ctx, query, userID)
+	if err != nil {
+		return err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// scanUser escanea las columnas de userColumns
+func scanUser(row rowScanner) (*entities.User, error) {
+	var user entities.User
+	var suspendedAt, emailVerifiedAt, lockedUntil sql.NullTime
+	var deletionRequestedAt, deletionScheduledAt, deletedAt sql.NullTime
+	err := row.Scan(
+		&user.ID, &user.Name, &user.DisplayName, &user.Bio, &user.AvatarURL, &user.Email, &emailVerifiedAt, &user.Password, &user.Locality,
+		&user.Province, &user.Phone, &user.Locale, &user.FirstLogin,
+		&suspendedAt, &user.SuspensionReason, &user.FailedLoginCount, &lockedUntil,
+		&deletionRequestedAt, &deletionScheduledAt, &deletedAt,
+		&user.CreatedAt, &user.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if suspendedAt.Valid {
+		user.SuspendedAt = &suspendedAt.Time
+	}
+	if emailVerifiedAt.Valid {
+		user.EmailVerifiedAt = &emailVerifiedAt.Time
+	}
+	if lockedUntil.Valid {
+		user.LockedUntil = &lockedUntil.Time
+	}
+	if deletionRequestedAt.Valid {
+		user.DeletionRequestedAt = &deletionRequestedAt.Time
+	}
+	if deletionScheduledAt.Valid {
+		user.DeletionScheduledAt = &deletionScheduledAt.Time
+	}
+	if deletedAt.Valid {
+		user.DeletedAt = &deletedAt.Time
+	}
+
+	return &user, nil
+}
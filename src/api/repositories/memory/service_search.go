@@ -0,0 +1,108 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"iycds2025_api/src/api/core/entities"
+	"iycds2025_api/src/api/utils"
+)
+
+// titleWeight multiplica la relevancia de las coincidencias en el título, igual que en MySQL
+const titleWeight = 2
+
+// ServiceSearch implementa la búsqueda de servicios en memoria.
+// Sirve para tests y para desarrollo sin MySQL; el índice se pierde al reiniciar.
+type ServiceSearch struct {
+	mu        sync.RWMutex
+	documents map[int64]*searchDocument
+}
+
+type searchDocument struct {
+	service     entities.Service
+	titleTokens []string
+	bodyTokens  []string
+}
+
+func NewServiceSearch() *ServiceSearch {
+	return &ServiceSearch{documents: make(map[int64]*searchDocument)}
+}
+
+func (s *ServiceSearch) Index(ctx context.Context, service *entities.Service) error {
+	titleTokens, bodyTokens := utils.SearchDocument(service)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.documents[service.ID] = &searchDocument{
+		service:     *service,
+		titleTokens: titleTokens,
+		bodyTokens:  bodyTokens,
+	}
+	return nil
+}
+
+func (s *ServiceSearch) Remove(ctx context.Context, serviceID int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.documents, serviceID)
+	return nil
+}
+
+func (s *ServiceSearch) Search(ctx context.Context, query *entities.ServiceSearchQuery) ([]*entities.ServiceSearchHit, int, error) {
+	s.mu.RLock()
+	var hits []*entities.ServiceSearchHit
+	for _, document := range s.documents {
+		if document.service.Status != "active" {
+			continue
+		}
+
+		titleMatches := countMatches(document.titleTokens, query.Terms)
+		bodyMatches := countMatches(document.bodyTokens, query.Terms)
+		if titleMatches+bodyMatches == 0 {
+			continue
+		}
+
+		service := document.service
+		hits = append(hits, &entities.ServiceSearchHit{
+			Service: &service,
+			Score:   float64(titleMatches*titleWeight + titleMatches + bodyMatches),
+		})
+	}
+	s.mu.RUnlock()
+
+	sort.Slice(hits, func(i, j int) bool {
+		if hits[i].Score != hits[j].Score {
+			return hits[i].Score > hits[j].Score
+		}
+		return hits[i].Service.ID > hits[j].Service.ID
+	})
+
+	total := len(hits)
+	from := (query.Page - 1) * query.PageSize
+	if from >= total {
+		return []*entities.ServiceSearchHit{}, total, nil
+	}
+	to := from + query.PageSize
+	if to > total {
+		to = total
+	}
+
+	return hits[from:to], total, nil
+}
+
+// countMatches cuenta cuántos términos del documento coinciden con alguno de los buscados
+func countMatches(tokens []string, terms []string) int {
+	matches := 0
+	for _, token := range tokens {
+		for _, term := range terms {
+			if utils.MatchesTerm(token, term) {
+				matches++
+				break
+			}
+		}
+	}
+	return matches
+}
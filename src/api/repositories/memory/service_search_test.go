@@ -0,0 +1,151 @@
+package memory
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"iycds2025_api/src/api/core/entities"
+	"iycds2025_api/src/api/utils"
+)
+
+func newIndexedSearch(t *testing.T, services ...*entities.Service) *ServiceSearch {
+	t.Helper()
+
+	search := NewServiceSearch()
+	for _, service := range services {
+		if service.Status == "" {
+			service.Status = "active"
+		}
+		if service.Zones == "" {
+			service.Zones = "[]"
+		}
+		if err := search.Index(context.Background(), service); err != nil {
+			t.Fatalf("Index(%d): %v", service.ID, err)
+		}
+	}
+	return search
+}
+
+// searchIDs tokeniza el texto igual que el use case y devuelve los IDs encontrados, en orden, y el total
+func searchIDs(t *testing.T, search *ServiceSearch, text string, page, pageSize int) ([]int64, int) {
+	t.Helper()
+
+	hits, total, err := search.Search(context.Background(), &entities.ServiceSearchQuery{
+		Terms:    utils.UniqueTokens(text),
+		Page:     page,
+		PageSize: pageSize,
+	})
+	if err != nil {
+		t.Fatalf("Search(%q): %v", text, err)
+	}
+
+	ids := []int64{}
+	for _, hit := range hits {
+		ids = append(ids, hit.Service.ID)
+	}
+	return ids, total
+}
+
+func TestServiceSearch(t *testing.T) {
+	search := newIndexedSearch(t,
+		&entities.Service{ID: 1, Title: "Plomería urgente", Description: "Destapaciones y pérdidas de agua", Category: "Plomería"},
+		&entities.Service{ID: 2, Title: "Electricista matriculado", Description: "Instalaciones eléctricas, también plomería menor", Category: "Electricidad"},
+		&entities.Service{ID: 3, Title: "Clases de guitarra", Description: "Para todos los niveles", Category: "Educación",
+			Zones: `[{"province": "Buenos Aires", "locality": "CABA", "neighborhood": "Palermo"}]`},
+		&entities.Service{ID: 4, Title: "Plomero a domicilio", Description: "Servicio pausado", Category: "Plomería", Status: "inactive"},
+	)
+
+	tests := []struct {
+		name string
+		text string
+		want []int64
+	}{
+		{"prefijo", "plom", []int64{1, 2}},
+		{"sin acentos encuentra con acentos", "plomeria", []int64{1, 2}},
+		{"con acentos encuentra sin acentos", "ELÉCTRICAS", []int64{2}},
+		{"el título pesa más que la descripción", "plomería", []int64{1, 2}},
+		{"zonas de cobertura", "palermo", []int64{3}},
+		{"solo stopwords no encuentra nada", "para todos los", []int64{}},
+		{"los servicios inactivos no aparecen", "domicilio", []int64{}},
+		{"sin coincidencias", "jardinería", []int64{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, total := searchIDs(t, search, tt.text, 1, 10)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Search(%q) = %v, want %v", tt.text, got, tt.want)
+			}
+			if total != len(tt.want) {
+				t.Errorf("Search(%q) total = %d, want %d", tt.text, total, len(tt.want))
+			}
+		})
+	}
+}
+
+func TestServiceSearchTitleRanking(t *testing.T) {
+	// El servicio 2 tiene el término dos veces en la descripción; el 1 una sola vez en el título
+	search := newIndexedSearch(t,
+		&entities.Service{ID: 1, Title: "Jardinería", Description: "Corte de pasto"},
+		&entities.Service{ID: 2, Title: "Mantenimiento", Description: "Jardinería y más jardinería"},
+	)
+
+	got, _ := searchIDs(t, search, "jardineria", 1, 10)
+	if want := []int64{1, 2}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Search = %v, want %v", got, want)
+	}
+}
+
+func TestServiceSearchPagination(t *testing.T) {
+	var services []*entities.Service
+	for id := int64(1); id <= 5; id++ {
+		services = append(services, &entities.Service{ID: id, Title: "Pintor", Description: "Pintura de interiores"})
+	}
+	search := newIndexedSearch(t, services...)
+
+	// Con el mismo puntaje se ordena por ID descendente (los más nuevos primero)
+	tests := []struct {
+		page int
+		want []int64
+	}{
+		{1, []int64{5, 4}},
+		{2, []int64{3, 2}},
+		{3, []int64{1}},
+		{4, []int64{}},
+	}
+
+	for _, tt := range tests {
+		got, total := searchIDs(t, search, "pintor", tt.page, 2)
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("page %d = %v, want %v", tt.page, got, tt.want)
+		}
+		if total != 5 {
+			t.Errorf("page %d total = %d, want 5", tt.page, total)
+		}
+	}
+}
+
+func TestServiceSearchReindexAndRemove(t *testing.T) {
+	service := &entities.Service{ID: 1, Title: "Plomero", Description: "Arreglos"}
+	search := newIndexedSearch(t, service)
+
+	// Reindexar reemplaza los términos anteriores
+	service.Title = "Gasista"
+	if err := search.Index(context.Background(), service); err != nil {
+		t.Fatalf("Index: %v", err)
+	}
+	if got, _ := searchIDs(t, search, "plomero", 1, 10); len(got) != 0 {
+		t.Errorf("old title still matches: %v", got)
+	}
+	if got, _ := searchIDs(t, search, "gasista", 1, 10); !reflect.DeepEqual(got, []int64{1}) {
+		t.Errorf("new title = %v, want [1]", got)
+	}
+
+	if err := search.Remove(context.Background(), 1); err != nil {
+		t.Fatalf("Remove: %v", err)
+	}
+	if got, _ := searchIDs(t, search, "gasista", 1, 10); len(got) != 0 {
+		t.Errorf("removed service still matches: %v", got)
+	}
+}
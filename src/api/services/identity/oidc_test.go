@@ -0,0 +1,491 @@
+package identity
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	testClientID     = "client-123"
+	testClientSecret = "s3cret:with/symbols"
+	testRedirectURL  = "http://localhost:3000/auth/callback"
+	testNonce        = "nonce-abc"
+	testVerifier     = "verifier-xyz"
+)
+
+// mockIssuer es un emisor OpenID Connect mínimo: publica el discovery y las claves, y su token
+// endpoint devuelve el id_token que arma cada test y guarda el formulario recibido
+type mockIssuer struct {
+	t      *testing.T
+	server *httptest.Server
+	key    *rsa.PrivateKey
+	kid    string
+
+	mu          sync.Mutex
+	idToken     string
+	tokenStatus int
+	tokenForm   url.Values
+	tokenAuth   [2]string
+	jwksCalls   int
+}
+
+var (
+	testKeyOnce sync.Once
+	testKey     *rsa.PrivateKey
+	otherKey    *rsa.PrivateKey
+)
+
+// testKeys genera una sola vez las claves RSA de los tests
+func testKeys(t *testing.T) (*rsa.PrivateKey, *rsa.PrivateKey) {
+	t.Helper()
+	testKeyOnce.Do(func() {
+		var err error
+		if testKey, err = rsa.GenerateKey(rand.Reader, 2048); err != nil {
+			t.Fatal(err)
+		}
+		if otherKey, err = rsa.GenerateKey(rand.Reader, 2048); err != nil {
+			t.Fatal(err)
+		}
+	})
+	return testKey, otherKey
+}
+
+func newMockIssuer(t *testing.T) *mockIssuer {
+	t.Helper()
+	key, _ := testKeys(t)
+	issuer := &mockIssuer{t: t, key: key, kid: "key-1", tokenStatus: http.StatusOK}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]string{
+			"issuer":                 issuer.server.URL,
+			"authorization_endpoint": issuer.server.URL + "/authorize",
+			"token_endpoint":         issuer.server.URL + "/token",
+			"jwks_uri":               issuer.server.URL + "/jwks",
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		issuer.mu.Lock()
+		issuer.jwksCalls++
+		issuer.mu.Unlock()
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"keys": []map[string]string{
+				// Una clave de cifrado y una de tipo desconocido que se deben ignorar
+				{"kty": "RSA", "kid": "enc-1", "use": "enc", "n": "AQAB", "e": "AQAB"},
+				{"kty": "oct", "kid": "hmac-1", "k": "c2VjcmV0"},
+				{
+					"kty": "RSA",
+					"kid": issuer.kid,
+					"use": "sig",
+					"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+					"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+				},
+			},
+		})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		if err := r.ParseForm(); err != nil {
+			t.Errorf("token request form: %v", err)
+		}
+		issuer.mu.Lock()
+		defer issuer.mu.Unlock()
+		issuer.tokenForm = r.PostForm
+		if user, password, ok := r.BasicAuth(); ok {
+			issuer.tokenAuth = [2]string{user, password}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(issuer.tokenStatus)
+		if issuer.tokenStatus != http.StatusOK {
+			w.Write([]byte(`{"error":"invalid_grant","error_description":"code expired"}`))
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]string{"access_token": "opaque", "token_type": "Bearer", "id_token": issuer.idToken})
+	})
+
+	issuer.server = httptest.NewServer(mux)
+	t.Cleanup(issuer.server.Close)
+	return issuer
+}
+
+func (m *mockIssuer) provider(clientSecret string) *OIDCProvider {
+	return NewOIDCProvider(OIDCConfig{
+		Name:         "mock",
+		Issuer:       m.server.URL + "/",
+		ClientID:     testClientID,
+		ClientSecret: clientSecret,
+		RedirectURL:  testRedirectURL,
+	})
+}
+
+// claims devuelve los claims de un id_token válido para el cliente de prueba
+func (m *mockIssuer) claims() jwt.MapClaims {
+	now := time.Now()
+	return jwt.MapClaims{
+		"iss":            m.server.URL,
+		"aud":            testClientID,
+		"sub":            "user-1",
+		"exp":            now.Add(5 * time.Minute).Unix(),
+		"iat":            now.Unix(),
+		"nonce":          testNonce,
+		"email":          " Ana@Example.com ",
+		"email_verified": true,
+		"name":           "Ana Gómez",
+	}
+}
+
+func (m *mockIssuer) sign(t *testing.T, method jwt.SigningMethod, kid string, key interface{}, claims jwt.MapClaims) string {
+	t.Helper()
+	token := jwt.NewWithClaims(method, claims)
+	if kid != "" {
+		token.Header["kid"] = kid
+	}
+	signed, err := token.SignedString(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return signed
+}
+
+func TestOIDCExchange(t *testing.T) {
+	issuer := newMockIssuer(t)
+	issuer.idToken = issuer.sign(t, jwt.SigningMethodRS256, issuer.kid, issuer.key, issuer.claims())
+
+	identity, err := issuer.provider(testClientSecret).Exchange(context.Background(), "auth-code", testVerifier, testNonce)
+	if err != nil {
+		t.Fatalf("Exchange: %v", err)
+	}
+	if identity.Provider != "mock" || identity.Subject != "user-1" || identity.Email != "ana@example.com" || !identity.EmailVerified || identity.Name != "Ana Gómez" {
+		t.Errorf("unexpected identity %+v", identity)
+	}
+
+	// El canje envía el verificador PKCE y el resto del authorization code grant
+	want := map[string]string{
+		"grant_type":    "authorization_code",
+		"code":          "auth-code",
+		"code_verifier": testVerifier,
+		"redirect_uri":  testRedirectURL,
+		"client_id":     testClientID,
+	}
+	for name, value := range want {
+		if got := issuer.tokenForm.Get(name); got != value {
+			t.Errorf("token request %s = %q, want %q", name, got, value)
+		}
+	}
+	// client_secret_basic con las credenciales codificadas (RFC 6749, sección 2.3.1)
+	if issuer.tokenAuth != [2]string{testClientID, url.QueryEscape(testClientSecret)} {
+		t.Errorf("token request basic auth = %v", issuer.tokenAuth)
+	}
+	if issuer.tokenForm.Has("client_secret") {
+		t.Error("client secret sent in the form body")
+	}
+}
+
+func TestOIDCExchangePublicClient(t *testing.T) {
+	issuer := newMockIssuer(t)
+	issuer.idToken = issuer.sign(t, jwt.SigningMethodRS256, issuer.kid, issuer.key, issuer.claims())
+
+	if _, err := issuer.provider("").Exchange(context.Background(), "auth-code", testVerifier, testNonce); err != nil {
+		t.Fatalf("Exchange: %v", err)
+	}
+	if issuer.tokenAuth != [2]string{} {
+		t.Errorf("public client sent basic auth %v", issuer.tokenAuth)
+	}
+	if got := issuer.tokenForm.Get("code_verifier"); got != testVerifier {
+		t.Errorf("code_verifier = %q, want %q", got, testVerifier)
+	}
+}
+
+func TestOIDCExchangeRejectsIDToken(t *testing.T) {
+	_, other := testKeys(t)
+
+	tests := []struct {
+		name    string
+		wantErr string
+		token   func(m *mockIssuer) string
+	}{
+		{"nonce mismatch", "nonce mismatch", func(m *mockIssuer) string {
+			claims := m.claims()
+			claims["nonce"] = "other-nonce"
+			return m.sign(t, jwt.SigningMethodRS256, m.kid, m.key, claims)
+		}},
+		{"missing nonce", "nonce mismatch", func(m *mockIssuer) string {
+			claims := m.claims()
+			delete(claims, "nonce")
+			return m.sign(t, jwt.SigningMethodRS256, m.kid, m.key, claims)
+		}},
+		{"wrong iss", "invalid issuer", func(m *mockIssuer) string {
+			claims := m.claims()
+			claims["iss"] = "https://evil.example.com"
+			return m.sign(t, jwt.SigningMethodRS256, m.kid, m.key, claims)
+		}},
+		{"wrong aud", "invalid audience", func(m *mockIssuer) string {
+			claims := m.claims()
+			claims["aud"] = "another-client"
+			return m.sign(t, jwt.SigningMethodRS256, m.kid, m.key, claims)
+		}},
+		{"multiple audiences without azp", "unexpected azp", func(m *mockIssuer) string {
+			claims := m.claims()
+			claims["aud"] = []string{testClientID, "another-client"}
+			return m.sign(t, jwt.SigningMethodRS256, m.kid, m.key, claims)
+		}},
+		{"multiple audiences with wrong azp", "unexpected azp", func(m *mockIssuer) string {
+			claims := m.claims()
+			claims["aud"] = []string{testClientID, "another-client"}
+			claims["azp"] = "another-client"
+			return m.sign(t, jwt.SigningMethodRS256, m.kid, m.key, claims)
+		}},
+		{"expired", "token is expired", func(m *mockIssuer) string {
+			claims := m.claims()
+			claims["exp"] = time.Now().Add(-5 * time.Minute).Unix()
+			claims["iat"] = time.Now().Add(-10 * time.Minute).Unix()
+			return m.sign(t, jwt.SigningMethodRS256, m.kid, m.key, claims)
+		}},
+		{"missing exp", "exp claim is required", func(m *mockIssuer) string {
+			claims := m.claims()
+			delete(claims, "exp")
+			return m.sign(t, jwt.SigningMethodRS256, m.kid, m.key, claims)
+		}},
+		{"issued in the future", "used before issued", func(m *mockIssuer) string {
+			claims := m.claims()
+			claims["iat"] = time.Now().Add(5 * time.Minute).Unix()
+			claims["exp"] = time.Now().Add(10 * time.Minute).Unix()
+			return m.sign(t, jwt.SigningMethodRS256, m.kid, m.key, claims)
+		}},
+		{"missing sub", "missing sub", func(m *mockIssuer) string {
+			claims := m.claims()
+			delete(claims, "sub")
+			return m.sign(t, jwt.SigningMethodRS256, m.kid, m.key, claims)
+		}},
+		{"alg none", "signing method none is invalid", func(m *mockIssuer) string {
+			return m.sign(t, jwt.SigningMethodNone, m.kid, jwt.UnsafeAllowNoneSignatureType, m.claims())
+		}},
+		{"HS256 with the client secret", "signing method HS256 is invalid", func(m *mockIssuer) string {
+			return m.sign(t, jwt.SigningMethodHS256, m.kid, []byte(testClientSecret), m.claims())
+		}},
+		{"HS256 with the public key as secret", "signing method HS256 is invalid", func(m *mockIssuer) string {
+			return m.sign(t, jwt.SigningMethodHS256, m.kid, m.key.PublicKey.N.Bytes(), m.claims())
+		}},
+		{"signed by another key with the same kid", "verification error", func(m *mockIssuer) string {
+			return m.sign(t, jwt.SigningMethodRS256, m.kid, other, m.claims())
+		}},
+		{"unknown kid", "unknown signing key \"key-2\"", func(m *mockIssuer) string {
+			return m.sign(t, jwt.SigningMethodRS256, "key-2", other, m.claims())
+		}},
+		{"kid of an encryption key", "unknown signing key \"enc-1\"", func(m *mockIssuer) string {
+			return m.sign(t, jwt.SigningMethodRS256, "enc-1", m.key, m.claims())
+		}},
+		{"tampered payload", "verification error", func(m *mockIssuer) string {
+			parts := strings.Split(m.sign(t, jwt.SigningMethodRS256, m.kid, m.key, m.claims()), ".")
+			claims := m.claims()
+			claims["sub"] = "admin"
+			payload, _ := json.Marshal(claims)
+			parts[1] = base64.RawURLEncoding.EncodeToString(payload)
+			return strings.Join(parts, ".")
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			issuer := newMockIssuer(t)
+			issuer.idToken = tt.token(issuer)
+
+			identity, err := issuer.provider(testClientSecret).Exchange(context.Background(), "auth-code", testVerifier, testNonce)
+			if err == nil {
+				t.Fatalf("id_token accepted: %+v", identity)
+			}
+			if !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("got error %q, want it to mention %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestOIDCExchangeAcceptsAzpWithMultipleAudiences(t *testing.T) {
+	issuer := newMockIssuer(t)
+	claims := issuer.claims()
+	claims["aud"] = []string{"another-client", testClientID}
+	claims["azp"] = testClientID
+	issuer.idToken = issuer.sign(t, jwt.SigningMethodRS256, issuer.kid, issuer.key, claims)
+
+	if _, err := issuer.provider(testClientSecret).Exchange(context.Background(), "auth-code", testVerifier, testNonce); err != nil {
+		t.Fatalf("Exchange: %v", err)
+	}
+}
+
+func TestOIDCExchangeEmailVerified(t *testing.T) {
+	tests := []struct {
+		name  string
+		value interface{}
+		want  bool
+	}{
+		{"boolean true", true, true},
+		{"boolean false", false, false},
+		{"string true", "true", true},
+		{"string false", "false", false},
+		{"missing", nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			issuer := newMockIssuer(t)
+			claims := issuer.claims()
+			if tt.value == nil {
+				delete(claims, "email_verified")
+			} else {
+				claims["email_verified"] = tt.value
+			}
+			issuer.idToken = issuer.sign(t, jwt.SigningMethodRS256, issuer.kid, issuer.key, claims)
+
+			identity, err := issuer.provider("").Exchange(context.Background(), "auth-code", testVerifier, testNonce)
+			if err != nil {
+				t.Fatalf("Exchange: %v", err)
+			}
+			if identity.EmailVerified != tt.want {
+				t.Errorf("EmailVerified = %v, want %v", identity.EmailVerified, tt.want)
+			}
+		})
+	}
+}
+
+func TestOIDCExchangeTokenEndpointError(t *testing.T) {
+	issuer := newMockIssuer(t)
+	issuer.tokenStatus = http.StatusBadRequest
+
+	_, err := issuer.provider(testClientSecret).Exchange(context.Background(), "auth-code", testVerifier, testNonce)
+	if err == nil || !strings.Contains(err.Error(), "invalid_grant") {
+		t.Fatalf("got %v, want the invalid_grant error", err)
+	}
+}
+
+func TestOIDCDiscoveryIssuerMismatch(t *testing.T) {
+	issuer := newMockIssuer(t)
+	provider := NewOIDCProvider(OIDCConfig{Name: "mock", Issuer: issuer.server.URL + "/other", ClientID: testClientID})
+
+	if _, err := provider.AuthCodeURL(context.Background(), "state", testNonce, "challenge"); err == nil {
+		t.Fatal("discovery with a different issuer was accepted")
+	}
+}
+
+func TestOIDCUnknownKidRefreshIsRateLimited(t *testing.T) {
+	_, other := testKeys(t)
+	issuer := newMockIssuer(t)
+	provider := issuer.provider("")
+
+	issuer.idToken = issuer.sign(t, jwt.SigningMethodRS256, issuer.kid, issuer.key, issuer.claims())
+	if _, err := provider.Exchange(context.Background(), "auth-code", testVerifier, testNonce); err != nil {
+		t.Fatal(err)
+	}
+
+	// Un kid desconocido no vuelve a pedir las claves antes de jwksRefreshInterval
+	issuer.idToken = issuer.sign(t, jwt.SigningMethodRS256, "key-2", other, issuer.claims())
+	for i := 0; i < 3; i++ {
+		if _, err := provider.Exchange(context.Background(), "auth-code", testVerifier, testNonce); err == nil {
+			t.Fatal("token with unknown kid accepted")
+		}
+	}
+	if issuer.jwksCalls != 1 {
+		t.Errorf("JWKS fetched %d times, want 1", issuer.jwksCalls)
+	}
+}
+
+func TestOIDCAuthCodeURL(t *testing.T) {
+	issuer := newMockIssuer(t)
+	verifier, challenge, err := GeneratePKCE()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	authURL, err := issuer.provider(testClientSecret).AuthCodeURL(context.Background(), "state-1", testNonce, challenge)
+	if err != nil {
+		t.Fatalf("AuthCodeURL: %v", err)
+	}
+	parsed, err := url.Parse(authURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := parsed.Scheme + "://" + parsed.Host + parsed.Path; got != issuer.server.URL+"/authorize" {
+		t.Errorf("endpoint = %s, want the discovered authorization_endpoint", got)
+	}
+
+	want := map[string]string{
+		"response_type":         "code",
+		"client_id":             testClientID,
+		"redirect_uri":          testRedirectURL,
+		"scope":                 "openid email profile",
+		"state":                 "state-1",
+		"nonce":                 testNonce,
+		"code_challenge":        challenge,
+		"code_challenge_method": "S256",
+	}
+	query := parsed.Query()
+	for name, value := range want {
+		if got := query.Get(name); got != value {
+			t.Errorf("%s = %q, want %q", name, got, value)
+		}
+	}
+	// El verificador nunca viaja en la URL, solo su desafío
+	if strings.Contains(authURL, verifier) {
+		t.Error("authorization URL contains the PKCE verifier")
+	}
+}
+
+func TestOIDCAuthCodeURLOverride(t *testing.T) {
+	// Con AuthorizationURL configurada no hace falta el discovery
+	provider := NewOIDCProvider(OIDCConfig{
+		Name:             "mock",
+		Issuer:           "http://issuer.invalid",
+		ClientID:         testClientID,
+		AuthorizationURL: "http://localhost:9000/authorize?prompt=login",
+	})
+
+	authURL, err := provider.AuthCodeURL(context.Background(), "state-1", testNonce, "challenge")
+	if err != nil {
+		t.Fatalf("AuthCodeURL: %v", err)
+	}
+	if !strings.HasPrefix(authURL, "http://localhost:9000/authorize?prompt=login&") || !strings.Contains(authURL, "code_challenge_method=S256") {
+		t.Errorf("AuthCodeURL = %s", authURL)
+	}
+}
+
+func TestGeneratePKCE(t *testing.T) {
+	verifier, challenge, err := GeneratePKCE()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// RFC 7636: el verificador tiene entre 43 y 128 caracteres y el desafío es BASE64URL(SHA256(verificador))
+	if len(verifier) < 43 || len(verifier) > 128 {
+		t.Errorf("verifier length %d out of range", len(verifier))
+	}
+	sum := sha256.Sum256([]byte(verifier))
+	if want := base64.RawURLEncoding.EncodeToString(sum[:]); challenge != want {
+		t.Errorf("challenge = %s, want %s", challenge, want)
+	}
+
+	other, _, err := GeneratePKCE()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if other == verifier {
+		t.Error("GeneratePKCE returned the same verifier twice")
+	}
+}
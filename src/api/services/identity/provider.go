@@ -0,0 +1,48 @@
+package identity
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"sort"
+
+	"iycds2025_api/src/api/core/entities"
+)
+
+// IdentityProvider es un proveedor de identidad externo con el flujo authorization code + PKCE.
+// El usuario se autentica en el proveedor, que lo devuelve al frontend con un código; el backend
+// canjea ese código (con el verificador PKCE) y obtiene la identidad verificada.
+type IdentityProvider interface {
+	// Name es el identificador del proveedor en las URLs (por ejemplo "google")
+	Name() string
+	// AuthCodeURL arma la URL de autorización del proveedor a la que se redirige al usuario
+	AuthCodeURL(ctx context.Context, state string, nonce string, codeChallenge string) (string, error)
+	// Exchange canjea el código de autorización y devuelve la identidad confirmada por el proveedor
+	Exchange(ctx context.Context, code string, codeVerifier string, nonce string) (*entities.ExternalIdentity, error)
+}
+
+// Providers son los proveedores configurados, por nombre
+type Providers map[string]IdentityProvider
+
+// Names devuelve los nombres de los proveedores configurados en orden alfabético
+func (p Providers) Names() []string {
+	names := make([]string, 0, len(p))
+	for name := range p {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// GeneratePKCE crea un verificador PKCE aleatorio y su desafío S256 (RFC 7636)
+func GeneratePKCE() (string, string, error) {
+	verifierBytes := make([]byte, 32)
+	if _, err := rand.Read(verifierBytes); err != nil {
+		return "", "", err
+	}
+
+	verifier := base64.RawURLEncoding.EncodeToString(verifierBytes)
+	sum := sha256.Sum256([]byte(verifier))
+	return verifier, base64.RawURLEncoding.EncodeToString(sum[:]), nil
+}
@@ -0,0 +1,133 @@
+package images
+
+import (
+	"encoding/binary"
+	"image"
+	"image/draw"
+)
+
+// exifOrientationTag es el tag de orientación en el IFD0 de EXIF
+const exifOrientationTag = 0x0112
+
+// jpegOrientation lee la orientación EXIF (1 a 8) de un JPEG. Devuelve 1 (sin transformar) si no
+// tiene EXIF o si el bloque está mal formado.
+func jpegOrientation(data []byte) int {
+	if len(data) < 4 || data[0] != 0xFF || data[1] != 0xD8 {
+		return 1
+	}
+
+	// Recorrer los segmentos hasta encontrar APP1 con "Exif\0\0"; la imagen empieza en SOS
+	pos := 2
+	for pos+4 <= len(data) {
+		if data[pos] != 0xFF {
+			return 1
+		}
+		marker := data[pos+1]
+		if marker == 0xFF {
+			// Relleno entre segmentos
+			pos++
+			continue
+		}
+		if marker == 0xDA || marker == 0xD9 {
+			return 1
+		}
+		length := int(binary.BigEndian.Uint16(data[pos+2:]))
+		if length < 2 || pos+2+length > len(data) {
+			return 1
+		}
+		segment := data[pos+4 : pos+2+length]
+		if marker == 0xE1 && len(segment) > 6 && string(segment[:6]) == "Exif\x00\x00" {
+			return tiffOrientation(segment[6:])
+		}
+		pos += 2 + length
+	}
+
+	return 1
+}
+
+// tiffOrientation busca el tag de orientación en el primer IFD del bloque TIFF de EXIF
+func tiffOrientation(tiff []byte) int {
+	if len(tiff) < 8 {
+		return 1
+	}
+
+	var order binary.ByteOrder
+	switch string(tiff[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return 1
+	}
+	if order.Uint16(tiff[2:]) != 42 {
+		return 1
+	}
+
+	ifd := int(order.Uint32(tiff[4:]))
+	if ifd < 8 || ifd+2 > len(tiff) {
+		return 1
+	}
+	entries := int(order.Uint16(tiff[ifd:]))
+	for i := 0; i < entries; i++ {
+		entry := ifd + 2 + i*12
+		if entry+12 > len(tiff) {
+			return 1
+		}
+		// Tipo 3 = SHORT: el valor viene en los primeros dos bytes del campo
+		if order.Uint16(tiff[entry:]) == exifOrientationTag && order.Uint16(tiff[entry+2:]) == 3 {
+			orientation := int(order.Uint16(tiff[entry+8:]))
+			if orientation < 1 || orientation > 8 {
+				return 1
+			}
+			return orientation
+		}
+	}
+
+	return 1
+}
+
+// applyOrientation rota o espeja la imagen según la orientación EXIF para que quede derecha
+func applyOrientation(src image.Image, orientation int) image.Image {
+	if orientation < 2 || orientation > 8 {
+		return src
+	}
+
+	bounds := src.Bounds()
+	source := image.NewRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+	draw.Draw(source, source.Bounds(), src, bounds.Min, draw.Src)
+
+	width, height := bounds.Dx(), bounds.Dy()
+	dstWidth, dstHeight := width, height
+	// De 5 a 8 la imagen está girada 90°: se intercambian ancho y alto
+	if orientation >= 5 {
+		dstWidth, dstHeight = height, width
+	}
+	dst := image.NewRGBA(image.Rect(0, 0, dstWidth, dstHeight))
+
+	for y := 0; y < dstHeight; y++ {
+		for x := 0; x < dstWidth; x++ {
+			// Píxel de origen que corresponde a (x, y) en la imagen ya orientada
+			var sx, sy int
+			switch orientation {
+			case 2: // espejada horizontalmente
+				sx, sy = width-1-x, y
+			case 3: // girada 180°
+				sx, sy = width-1-x, height-1-y
+			case 4: // espejada verticalmente
+				sx, sy = x, height-1-y
+			case 5: // transpuesta
+				sx, sy = y, x
+			case 6: // hay que girarla 90° en sentido horario
+				sx, sy = y, height-1-x
+			case 7: // transversa
+				sx, sy = width-1-y, height-1-x
+			case 8: // hay que girarla 90° en sentido antihorario
+				sx, sy = width-1-y, x
+			}
+			copy(dst.Pix[dst.PixOffset(x, y):dst.PixOffset(x, y)+4], source.Pix[source.PixOffset(sx, sy):source.PixOffset(sx, sy)+4])
+		}
+	}
+
+	return dst
+}
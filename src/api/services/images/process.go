@@ -0,0 +1,139 @@
+package images
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/jpeg"
+	"image/png"
+
+	"github.com/gabriel-vasile/mimetype"
+)
+
+const (
+	// MaxUploadBytes es el tamaño máximo del archivo subido
+	MaxUploadBytes = 8 << 20
+	// MaxPixels limita la resolución del original: una imagen chica en bytes puede ocupar
+	// cientos de MB al decodificarla
+	MaxPixels = 40_000_000
+	// MaxDimension es el lado mayor de la imagen que se guarda; las más grandes se achican
+	MaxDimension = 2048
+	// ThumbnailDimension es el lado mayor de la miniatura
+	ThumbnailDimension = 400
+
+	jpegQuality          = 85
+	thumbnailJPEGQuality = 80
+)
+
+var (
+	// ErrUnsupportedType se devuelve cuando el contenido no es una imagen JPEG o PNG
+	ErrUnsupportedType = errors.New("unsupported image type")
+	// ErrInvalidImage se devuelve cuando el archivo dice ser una imagen pero no se puede decodificar
+	ErrInvalidImage = errors.New("invalid image")
+	// ErrTooManyPixels se devuelve cuando la resolución supera MaxPixels
+	ErrTooManyPixels = errors.New("image resolution is too large")
+)
+
+// Processed es una imagen lista para guardar: el original normalizado y su miniatura
+type Processed struct {
+	ContentType string
+	Extension   string
+	Data        []byte
+	Width       int
+	Height      int
+
+	Thumbnail       []byte
+	ThumbnailWidth  int
+	ThumbnailHeight int
+}
+
+// Process valida una imagen subida y la vuelve a codificar. El tipo se detecta por el contenido, no
+// por el nombre ni el Content-Type que manda el cliente. Volver a codificar descarta los metadatos
+// (EXIF con la ubicación GPS de la foto, por ejemplo), así que antes se aplica la orientación EXIF
+// para que las fotos de celular no queden giradas.
+func Process(data []byte) (*Processed, error) {
+	detected := mimetype.Detect(data)
+	var isJPEG bool
+	switch {
+	case detected.Is("image/jpeg"):
+		isJPEG = true
+	case detected.Is("image/png"):
+		isJPEG = false
+	default:
+		return nil, ErrUnsupportedType
+	}
+
+	// Revisar la resolución antes de decodificar la imagen completa
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil || config.Width <= 0 || config.Height <= 0 {
+		return nil, ErrInvalidImage
+	}
+	if config.Width*config.Height > MaxPixels {
+		return nil, ErrTooManyPixels
+	}
+
+	var img image.Image
+	if isJPEG {
+		img, err = jpeg.Decode(bytes.NewReader(data))
+	} else {
+		img, err = png.Decode(bytes.NewReader(data))
+	}
+	if err != nil {
+		return nil, ErrInvalidImage
+	}
+
+	// El límite es un cuadrado, así que achicar antes de rotar da el mismo resultado y rota menos píxeles
+	img = fit(img, MaxDimension)
+	if isJPEG {
+		img = applyOrientation(img, jpegOrientation(data))
+	}
+	thumbnail := fit(img, ThumbnailDimension)
+
+	processed := &Processed{
+		Width:           img.Bounds().Dx(),
+		Height:          img.Bounds().Dy(),
+		ThumbnailWidth:  thumbnail.Bounds().Dx(),
+		ThumbnailHeight: thumbnail.Bounds().Dy(),
+	}
+
+	// Se conserva el formato: PNG mantiene la transparencia y JPEG el tamaño chico de las fotos
+	if isJPEG {
+		processed.ContentType = "image/jpeg"
+		processed.Extension = ".jpg"
+		processed.Data, err = encodeJPEG(img, jpegQuality)
+		if err != nil {
+			return nil, err
+		}
+		processed.Thumbnail, err = encodeJPEG(thumbnail, thumbnailJPEGQuality)
+	} else {
+		processed.ContentType = "image/png"
+		processed.Extension = ".png"
+		processed.Data, err = encodePNG(img)
+		if err != nil {
+			return nil, err
+		}
+		processed.Thumbnail, err = encodePNG(thumbnail)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return processed, nil
+}
+
+func encodeJPEG(img image.Image, quality int) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: quality}); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func encodePNG(img image.Image) ([]byte, error) {
+	var buf bytes.Buffer
+	encoder := png.Encoder{CompressionLevel: png.BestCompression}
+	if err := encoder.Encode(&buf, img); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
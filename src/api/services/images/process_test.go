@@ -0,0 +1,259 @@
+package images
+
+import (
+	"bytes"
+	"encoding/binary"
+	"hash/crc32"
+	"image"
+	"image/color"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"testing"
+)
+
+// testImage arma una imagen con la mitad izquierda roja y la derecha azul, para poder
+// comprobar hacia dónde quedó girada
+func testImage(width, height int) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			c := color.RGBA{B: 255, A: 255}
+			if x < width/2 {
+				c = color.RGBA{R: 255, A: 255}
+			}
+			img.SetRGBA(x, y, c)
+		}
+	}
+	return img
+}
+
+func encodeTestJPEG(t *testing.T, img image.Image) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: 90}); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func encodeTestPNG(t *testing.T, img image.Image) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+// withEXIFOrientation inserta después del SOI un segmento APP1 con un EXIF mínimo (big-endian)
+// que solo tiene el tag de orientación
+func withEXIFOrientation(jpegData []byte, orientation uint16) []byte {
+	tiff := []byte{'M', 'M', 0, 42, 0, 0, 0, 8}
+	tiff = binary.BigEndian.AppendUint16(tiff, 1) // una entrada en el IFD0
+	tiff = binary.BigEndian.AppendUint16(tiff, exifOrientationTag)
+	tiff = binary.BigEndian.AppendUint16(tiff, 3) // SHORT
+	tiff = binary.BigEndian.AppendUint32(tiff, 1) // un valor
+	tiff = binary.BigEndian.AppendUint16(tiff, orientation)
+	tiff = append(tiff, 0, 0)       // relleno del campo de valor
+	tiff = append(tiff, 0, 0, 0, 0) // sin IFD siguiente
+
+	payload := append([]byte("Exif\x00\x00"), tiff...)
+	segment := []byte{0xFF, 0xE1}
+	segment = binary.BigEndian.AppendUint16(segment, uint16(len(payload)+2))
+	segment = append(segment, payload...)
+
+	result := append([]byte{}, jpegData[:2]...)
+	result = append(result, segment...)
+	return append(result, jpegData[2:]...)
+}
+
+// pngHeader arma un PNG con solo la firma y el chunk IHDR: alcanza para DecodeConfig
+func pngHeader(width, height uint32) []byte {
+	ihdr := []byte("IHDR")
+	ihdr = binary.BigEndian.AppendUint32(ihdr, width)
+	ihdr = binary.BigEndian.AppendUint32(ihdr, height)
+	ihdr = append(ihdr, 8, 2, 0, 0, 0) // 8 bits, RGB, sin entrelazado
+
+	data := []byte("\x89PNG\r\n\x1a\n")
+	data = binary.BigEndian.AppendUint32(data, uint32(len(ihdr)-4))
+	data = append(data, ihdr...)
+	return binary.BigEndian.AppendUint32(data, crc32.ChecksumIEEE(ihdr))
+}
+
+func TestProcessDimensions(t *testing.T) {
+	tests := []struct {
+		name                string
+		data                func(t *testing.T) []byte
+		wantType            string
+		wantWidth           int
+		wantHeight          int
+		wantThumbnailWidth  int
+		wantThumbnailHeight int
+	}{
+		{
+			name:      "small PNG is kept as is",
+			data:      func(t *testing.T) []byte { return encodeTestPNG(t, testImage(300, 200)) },
+			wantType:  "image/png",
+			wantWidth: 300, wantHeight: 200,
+			wantThumbnailWidth: 300, wantThumbnailHeight: 200,
+		},
+		{
+			name:      "wide JPEG is scaled down",
+			data:      func(t *testing.T) []byte { return encodeTestJPEG(t, testImage(3000, 1000)) },
+			wantType:  "image/jpeg",
+			wantWidth: 2048, wantHeight: 682,
+			wantThumbnailWidth: 400, wantThumbnailHeight: 133,
+		},
+		{
+			name:      "tall PNG is scaled down",
+			data:      func(t *testing.T) []byte { return encodeTestPNG(t, testImage(500, 2500)) },
+			wantType:  "image/png",
+			wantWidth: 409, wantHeight: 2048,
+			wantThumbnailWidth: 79, wantThumbnailHeight: 400,
+		},
+		{
+			name:      "JPEG with EXIF orientation 6 is rotated",
+			data:      func(t *testing.T) []byte { return withEXIFOrientation(encodeTestJPEG(t, testImage(3000, 1000)), 6) },
+			wantType:  "image/jpeg",
+			wantWidth: 682, wantHeight: 2048,
+			wantThumbnailWidth: 133, wantThumbnailHeight: 400,
+		},
+		{
+			name:      "JPEG with EXIF orientation 3 keeps its size",
+			data:      func(t *testing.T) []byte { return withEXIFOrientation(encodeTestJPEG(t, testImage(600, 400)), 3) },
+			wantType:  "image/jpeg",
+			wantWidth: 600, wantHeight: 400,
+			wantThumbnailWidth: 400, wantThumbnailHeight: 266,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			processed, err := Process(tt.data(t))
+			if err != nil {
+				t.Fatalf("Process: %v", err)
+			}
+			if processed.ContentType != tt.wantType {
+				t.Errorf("ContentType = %s, want %s", processed.ContentType, tt.wantType)
+			}
+			if processed.Width != tt.wantWidth || processed.Height != tt.wantHeight {
+				t.Errorf("size = %dx%d, want %dx%d", processed.Width, processed.Height, tt.wantWidth, tt.wantHeight)
+			}
+			if processed.ThumbnailWidth != tt.wantThumbnailWidth || processed.ThumbnailHeight != tt.wantThumbnailHeight {
+				t.Errorf("thumbnail size = %dx%d, want %dx%d", processed.ThumbnailWidth, processed.ThumbnailHeight, tt.wantThumbnailWidth, tt.wantThumbnailHeight)
+			}
+
+			// Las dimensiones informadas coinciden con los archivos codificados
+			assertEncodedSize(t, processed.Data, processed.Width, processed.Height)
+			assertEncodedSize(t, processed.Thumbnail, processed.ThumbnailWidth, processed.ThumbnailHeight)
+		})
+	}
+}
+
+func assertEncodedSize(t *testing.T, data []byte, width, height int) {
+	t.Helper()
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("output does not decode: %v", err)
+	}
+	if config.Width != width || config.Height != height {
+		t.Errorf("encoded size = %dx%d, want %dx%d", config.Width, config.Height, width, height)
+	}
+	if width > MaxDimension || height > MaxDimension {
+		t.Errorf("size %dx%d exceeds MaxDimension", width, height)
+	}
+}
+
+func TestProcessAppliesOrientation(t *testing.T) {
+	// Orientación 6: la cámara guardó la foto girada y hay que rotarla 90° en sentido horario,
+	// así que la mitad izquierda (roja) pasa a ser la de arriba
+	processed, err := Process(withEXIFOrientation(encodeTestJPEG(t, testImage(400, 200)), 6))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	img, err := jpeg.Decode(bytes.NewReader(processed.Data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	top := color.RGBAModel.Convert(img.At(100, 50)).(color.RGBA)
+	bottom := color.RGBAModel.Convert(img.At(100, 350)).(color.RGBA)
+	if top.R < 200 || top.B > 60 || bottom.B < 200 || bottom.R > 60 {
+		t.Errorf("top pixel %v and bottom pixel %v, want red on top and blue at the bottom", top, bottom)
+	}
+
+	// Volver a codificar descarta el EXIF: la orientación ya está aplicada
+	if orientation := jpegOrientation(processed.Data); orientation != 1 {
+		t.Errorf("output still has EXIF orientation %d", orientation)
+	}
+}
+
+func TestProcessSniffsContent(t *testing.T) {
+	// Un PNG renombrado a .jpg (o enviado como image/jpeg) sigue siendo un PNG: Process solo mira el contenido
+	processed, err := Process(encodeTestPNG(t, testImage(10, 10)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if processed.ContentType != "image/png" || processed.Extension != ".png" {
+		t.Errorf("got %s %s, want image/png .png", processed.ContentType, processed.Extension)
+	}
+}
+
+func TestProcessRejects(t *testing.T) {
+	var gifData bytes.Buffer
+	if err := gif.Encode(&gifData, testImage(10, 10), nil); err != nil {
+		t.Fatal(err)
+	}
+	truncatedJPEG := encodeTestJPEG(t, testImage(100, 100))
+	truncatedJPEG = truncatedJPEG[:len(truncatedJPEG)/2]
+
+	tests := []struct {
+		name    string
+		data    []byte
+		wantErr error
+	}{
+		{"empty", nil, ErrUnsupportedType},
+		{"text", []byte("hello, this is not an image"), ErrUnsupportedType},
+		{"HTML", []byte("<html><body><img src=x onerror=alert(1)></body></html>"), ErrUnsupportedType},
+		{"GIF", gifData.Bytes(), ErrUnsupportedType},
+		{"PDF", []byte("%PDF-1.4\n1 0 obj\n<<>>\nendobj\n"), ErrUnsupportedType},
+		{"header over MaxPixels", pngHeader(10000, 5000), ErrTooManyPixels},
+		{"header just over MaxPixels", pngHeader(MaxPixels/1000+1, 1000), ErrTooManyPixels},
+		{"PNG header without data", pngHeader(100, 100), ErrInvalidImage},
+		{"zero width", pngHeader(0, 100), ErrInvalidImage},
+		{"truncated JPEG", truncatedJPEG, ErrInvalidImage},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			processed, err := Process(tt.data)
+			if err != tt.wantErr {
+				t.Fatalf("got (%v, %v), want %v", processed, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestJPEGOrientation(t *testing.T) {
+	plain := encodeTestJPEG(t, testImage(8, 8))
+
+	tests := []struct {
+		name string
+		data []byte
+		want int
+	}{
+		{"without EXIF", plain, 1},
+		{"orientation 6", withEXIFOrientation(plain, 6), 6},
+		{"orientation 8", withEXIFOrientation(plain, 8), 8},
+		{"out of range", withEXIFOrientation(plain, 9), 1},
+		{"not a JPEG", []byte("not a jpeg"), 1},
+		{"truncated segment", withEXIFOrientation(plain, 6)[:20], 1},
+	}
+
+	for _, tt := range tests {
+		if got := jpegOrientation(tt.data); got != tt.want {
+			t.Errorf("%s: jpegOrientation = %d, want %d", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,68 @@
+package images
+
+import (
+	"image"
+	"image/draw"
+)
+
+// fit achica la imagen para que su lado mayor no supere maxDimension, manteniendo la proporción.
+// Las imágenes que ya entran se devuelven sin cambios (nunca se agrandan).
+func fit(src image.Image, maxDimension int) image.Image {
+	bounds := src.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	if width <= maxDimension && height <= maxDimension {
+		return src
+	}
+
+	if width >= height {
+		height = max(1, height*maxDimension/width)
+		width = maxDimension
+	} else {
+		width = max(1, width*maxDimension/height)
+		height = maxDimension
+	}
+	return resize(src, width, height)
+}
+
+// resize reduce la imagen promediando el área de origen que cubre cada píxel de destino (filtro
+// de caja). Para achicar da resultados sin el aliasing del vecino más cercano y solo usa la
+// biblioteca estándar.
+func resize(src image.Image, width, height int) *image.RGBA {
+	// Trabajar sobre RGBA (alfa premultiplicado) permite promediar los canales directamente
+	bounds := src.Bounds()
+	source := image.NewRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+	draw.Draw(source, source.Bounds(), src, bounds.Min, draw.Src)
+
+	srcWidth, srcHeight := bounds.Dx(), bounds.Dy()
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+
+	for y := 0; y < height; y++ {
+		y0 := y * srcHeight / height
+		y1 := max(y0+1, (y+1)*srcHeight/height)
+		for x := 0; x < width; x++ {
+			x0 := x * srcWidth / width
+			x1 := max(x0+1, (x+1)*srcWidth/width)
+
+			var r, g, b, a, count uint64
+			for sy := y0; sy < y1; sy++ {
+				offset := source.PixOffset(x0, sy)
+				for sx := x0; sx < x1; sx++ {
+					r += uint64(source.Pix[offset])
+					g += uint64(source.Pix[offset+1])
+					b += uint64(source.Pix[offset+2])
+					a += uint64(source.Pix[offset+3])
+					offset += 4
+					count++
+				}
+			}
+
+			offset := dst.PixOffset(x, y)
+			dst.Pix[offset] = uint8((r + count/2) / count)
+			dst.Pix[offset+1] = uint8((g + count/2) / count)
+			dst.Pix[offset+2] = uint8((b + count/2) / count)
+			dst.Pix[offset+3] = uint8((a + count/2) / count)
+		}
+	}
+
+	return dst
+}
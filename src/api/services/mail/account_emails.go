@@ -0,0 +1,159 @@
+package mail
+
+// EmailVerificationData contiene los datos del correo para confirmar una dirección de email
+type EmailVerificationData struct {
+	VerifyLink  string `json:"verify_link"`
+	EmailChange bool   `json:"email_change"` // la dirección es el nuevo email de una cuenta existente y no la del registro
+}
+
+// PasswordChangedData contiene los datos del aviso de seguridad por cambio de contraseña
+type PasswordChangedData struct {
+	ChangedAt         string `json:"changed_at"` // fecha y hora del cambio, ya formateada
+	IPAddress         string `json:"ip_address"` // IP desde la que se hizo el cambio
+	ForgotPasswordURL string `json:"forgot_password_url"`
+}
+
+// AccountLockedData contiene los datos del aviso de bloqueo del login por intentos fallidos
+type AccountLockedData struct {
+	FailedAttempts    int    `json:"failed_attempts"`
+	LockedUntil       string `json:"locked_until"` // fecha y hora en que termina el bloqueo, ya formateada
+	IPAddress         string `json:"ip_address"`   // IP del último intento
+	ForgotPasswordURL string `json:"forgot_password_url"`
+}
+
+// AccountDeletionScheduledData contiene los datos del aviso de baja programada de la cuenta
+type AccountDeletionScheduledData struct {
+	ScheduledFor string `json:"scheduled_for"` // fecha y hora en que se eliminará la cuenta, ya formateada
+	IPAddress    string `json:"ip_address"`    // IP desde la que se pidió la baja
+	LoginURL     string `json:"login_url"`     // iniciando sesión se puede cancelar la baja
+}
+
+// buildEmailVerificationEmail arma el correo de verificación de email en el idioma del destinatario
+func buildEmailVerificationEmail(to Recipient, data EmailVerificationData) (renderedEmail, error) {
+	return templates.render(KindEmailVerification, to, data)
+}
+
+// buildPasswordChangedEmail arma el aviso de cambio de contraseña en el idioma del destinatario
+func buildPasswordChangedEmail(to Recipient, data PasswordChangedData) (renderedEmail, error) {
+	return templates.render(KindPasswordChanged, to, data)
+}
+
+// buildAccountLockedEmail arma el aviso de bloqueo del login en el idioma del destinatario
+func buildAccountLockedEmail(to Recipient, data AccountLockedData) (renderedEmail, error) {
+	return templates.render(KindAccountLocked, to, data)
+}
+
+// buildAccountDeletionScheduledEmail arma el aviso de baja programada en el idioma del destinatario
+func buildAccountDeletionScheduledEmail(to Recipient, data AccountDeletionScheduledData) (renderedEmail, error) {
+	return templates.render(KindAccountDeletionScheduled, to, data)
+}
+
+// SMTP
+
+// SendEmailVerificationEmail envía el enlace para confirmar la dirección de email
+func (s *SMTPEmailService) SendEmailVerificationEmail(to Recipient, data EmailVerificationData) error {
+	content, err := buildEmailVerificationEmail(to, data)
+	if err != nil {
+		return err
+	}
+	return s.send(to, content)
+}
+
+// SendPasswordChangedEmail avisa al usuario que se cambió su contraseña
+func (s *SMTPEmailService) SendPasswordChangedEmail(to Recipient, data PasswordChangedData) error {
+	content, err := buildPasswordChangedEmail(to, data)
+	if err != nil {
+		return err
+	}
+	return s.send(to, content)
+}
+
+// SendAccountLockedEmail avisa al usuario que se bloqueó el login de su cuenta
+func (s *SMTPEmailService) SendAccountLockedEmail(to Recipient, data AccountLockedData) error {
+	content, err := buildAccountLockedEmail(to, data)
+	if err != nil {
+		return err
+	}
+	return s.send(to, content)
+}
+
+// SendAccountDeletionScheduledEmail avisa al usuario que se programó la baja de su cuenta
+func (s *SMTPEmailService) SendAccountDeletionScheduledEmail(to Recipient, data AccountDeletionScheduledData) error {
+	content, err := buildAccountDeletionScheduledEmail(to, data)
+	if err != nil {
+		return err
+	}
+	return s.send(to, content)
+}
+
+// SendGrid
+
+// SendEmailVerificationEmail envía el enlace para confirmar la dirección de email
+func (s *SendGridEmailService) SendEmailVerificationEmail(to Recipient, data EmailVerificationData) error {
+	content, err := buildEmailVerificationEmail(to, data)
+	if err != nil {
+		return err
+	}
+	return s.send(to, content)
+}
+
+// SendPasswordChangedEmail avisa al usuario que se cambió su contraseña
+func (s *SendGridEmailService) SendPasswordChangedEmail(to Recipient, data PasswordChangedData) error {
+	content, err := buildPasswordChangedEmail(to, data)
+	if err != nil {
+		return err
+	}
+	return s.send(to, content)
+}
+
+// SendAccountLockedEmail avisa al usuario que se bloqueó el login de su cuenta
+func (s *SendGridEmailService) SendAccountLockedEmail(to Recipient, data AccountLockedData) error {
+	content, err := buildAccountLockedEmail(to, data)
+	if err != nil {
+		return err
+	}
+	return s.send(to, content)
+}
+
+// SendAccountDeletionScheduledEmail avisa al usuario que se programó la baja de su cuenta
+func (s *SendGridEmailService) SendAccountDeletionScheduledEmail(to Recipient, data AccountDeletionScheduledData) error {
+	content, err := buildAccountDeletionScheduledEmail(to, data)
+	if err != nil {
+		return err
+	}
+	return s.send(to, content)
+}
+
+// Mock
+
+func (m *MockEmailService) SendEmailVerificationEmail(to Recipient, data EmailVerificationData) error {
+	content, err := buildEmailVerificationEmail(to, data)
+	if err != nil {
+		return err
+	}
+	return m.send(to, content)
+}
+
+func (m *MockEmailService) SendPasswordChangedEmail(to Recipient, data PasswordChangedData) error {
+	content, err := buildPasswordChangedEmail(to, data)
+	if err != nil {
+		return err
+	}
+	return m.send(to, content)
+}
+
+func (m *MockEmailService) SendAccountLockedEmail(to Recipient, data AccountLockedData) error {
+	content, err := buildAccountLockedEmail(to, data)
+	if err != nil {
+		return err
+	}
+	return m.send(to, content)
+}
+
+func (m *MockEmailService) SendAccountDeletionScheduledEmail(to Recipient, data AccountDeletionScheduledData) error {
+	content, err := buildAccountDeletionScheduledEmail(to, data)
+	if err != nil {
+		return err
+	}
+	return m.send(to, content)
+}
@@ -0,0 +1,101 @@
+package mail
+
+import (
+	"fmt"
+)
+
+// Recipient representa el destinatario de un correo
+type Recipient struct {
+	Email  string `json:"email"`
+	Name   string `json:"name"`
+	Locale string `json:"locale"` // idioma del correo; vacío usa el idioma por defecto
+}
+
+// AppointmentEmailData contiene los datos de una cita que se incluyen en las notificaciones
+type AppointmentEmailData struct {
+	AppointmentID   int64  `json:"appointment_id"`
+	ServiceTitle    string `json:"service_title"`
+	Date            string `json:"date"`      // YYYY-MM-DD
+	TimeSlot        string `json:"time_slot"` // HH:MM-HH:MM
+	Timezone        string `json:"timezone"`
+	Status          string `json:"status"`           // "pending", "accepted", "rejected", "cancelled", "completed"
+	CounterpartName string `json:"counterpart_name"` // la otra parte de la cita (cliente o proveedor); vacío usa "Un usuario"
+	Notes           string `json:"notes"`
+	AppointmentURL  string `json:"appointment_url"`
+}
+
+// buildNewAppointmentEmail arma el aviso de nueva reserva para el proveedor
+func buildNewAppointmentEmail(to Recipient, data AppointmentEmailData) (renderedEmail, error) {
+	return templates.render(KindNewAppointment, to, data)
+}
+
+// buildAppointmentStatusEmail arma el aviso de cambio de estado de una cita
+func buildAppointmentStatusEmail(to Recipient, data AppointmentEmailData) (renderedEmail, error) {
+	if !hasAppointmentStatusEmail(data.Status) {
+		return renderedEmail{}, fmt.Errorf("no hay notificación para el estado %q", data.Status)
+	}
+	return templates.render(KindAppointmentStatus, to, data)
+}
+
+// hasAppointmentStatusEmail indica si hay notificación para un estado de cita
+func hasAppointmentStatusEmail(status string) bool {
+	return templates.hasKey("appointment_status." + status + ".subject")
+}
+
+// SMTP
+
+// SendNewAppointmentEmail avisa al proveedor que recibió una nueva reserva
+func (s *SMTPEmailService) SendNewAppointmentEmail(to Recipient, data AppointmentEmailData) error {
+	content, err := buildNewAppointmentEmail(to, data)
+	if err != nil {
+		return err
+	}
+	return s.send(to, content)
+}
+
+// SendAppointmentStatusEmail avisa a la otra parte que la cita cambió de estado
+func (s *SMTPEmailService) SendAppointmentStatusEmail(to Recipient, data AppointmentEmailData) error {
+	content, err := buildAppointmentStatusEmail(to, data)
+	if err != nil {
+		return err
+	}
+	return s.send(to, content)
+}
+
+// SendGrid
+
+// SendNewAppointmentEmail avisa al proveedor que recibió una nueva reserva
+func (s *SendGridEmailService) SendNewAppointmentEmail(to Recipient, data AppointmentEmailData) error {
+	content, err := buildNewAppointmentEmail(to, data)
+	if err != nil {
+		return err
+	}
+	return s.send(to, content)
+}
+
+// SendAppointmentStatusEmail avisa a la otra parte que la cita cambió de estado
+func (s *SendGridEmailService) SendAppointmentStatusEmail(to Recipient, data AppointmentEmailData) error {
+	content, err := buildAppointmentStatusEmail(to, data)
+	if err != nil {
+		return err
+	}
+	return s.send(to, content)
+}
+
+// Mock
+
+func (m *MockEmailService) SendNewAppointmentEmail(to Recipient, data AppointmentEmailData) error {
+	content, err := buildNewAppointmentEmail(to, data)
+	if err != nil {
+		return err
+	}
+	return m.send(to, content)
+}
+
+func (m *MockEmailService) SendAppointmentStatusEmail(to Recipient, data AppointmentEmailData) error {
+	content, err := buildAppointmentStatusEmail(to, data)
+	if err != nil {
+		return err
+	}
+	return m.send(to, content)
+}
@@ -11,7 +11,20 @@ import (
 
 // EmailService define la interfaz para enviar correos electrónicos
 type EmailService interface {
-	SendPasswordResetEmail(to string, resetLink string) error
+	// SendPasswordResetEmail envía el enlace para restablecer la contraseña
+	SendPasswordResetEmail(to Recipient, resetLink string) error
+	// SendEmailVerificationEmail envía el enlace para confirmar la dirección de email (registro o cambio de email)
+	SendEmailVerificationEmail(to Recipient, data EmailVerificationData) error
+	// SendPasswordChangedEmail avisa al usuario que se cambió su contraseña (aviso de seguridad)
+	SendPasswordChangedEmail(to Recipient, data PasswordChangedData) error
+	// SendAccountLockedEmail avisa al usuario que se bloqueó el login de su cuenta por intentos fallidos
+	SendAccountLockedEmail(to Recipient, data AccountLockedData) error
+	// SendAccountDeletionScheduledEmail avisa al usuario que se programó la baja de su cuenta y hasta cuándo puede cancelarla
+	SendAccountDeletionScheduledEmail(to Recipient, data AccountDeletionScheduledData) error
+	// SendNewAppointmentEmail avisa al proveedor que recibió una nueva reserva
+	SendNewAppointmentEmail(to Recipient, data AppointmentEmailData) error
+	// SendAppointmentStatusEmail avisa a la otra parte que la cita fue aceptada, rechazada, cancelada o completada
+	SendAppointmentStatusEmail(to Recipient, data AppointmentEmailData) error
 }
 
 // SMTPEmailService implementa el servicio de correo usando SMTP
@@ -68,7 +81,16 @@ func NewSMTPEmailService() *SMTPEmailService {
 }
 
 // SendPasswordResetEmail envía un correo de restablecimiento de contraseña
-func (s *SMTPEmailService) SendPasswordResetEmail(to string, resetLink string) error {
+func (s *SMTPEmailService) SendPasswordResetEmail(to Recipient, resetLink string) error {
+	content, err := buildPasswordResetEmail(to, resetLink)
+	if err != nil {
+		return err
+	}
+	return s.send(to, content)
+}
+
+// send envía un correo ya armado, en texto plano con alternativa HTML
+func (s *SMTPEmailService) send(to Recipient, content renderedEmail) error {
 	m := gomail.NewMessage()
 
 	// Configurar remitente con formato "Nombre <email@dominio.com>"
@@ -77,65 +99,11 @@ func (s *SMTPEmailService) SendPasswordResetEmail(to string, resetLink string) e
 	} else {
 		m.SetHeader("From", s.From)
 	}
+	m.SetAddressHeader("To", to.Email, to.Name)
+	m.SetHeader("Subject", content.Subject)
 
-	m.SetHeader("To", to)
-	m.SetHeader("Subject", "Restablecimiento de contraseña - IYCDS 2025")
-
-	// Cuerpo HTML del correo
-	body := fmt.Sprintf(`
-		<html>
-			<head>
-				<style>
-					body {
-						font-family: Arial, sans-serif;
-						line-height: 1.6;
-						color: #333;
-					}
-					.container {
-						max-width: 600px;
-						margin: 0 auto;
-						padding: 20px;
-						border: 1px solid #ddd;
-						border-radius: 5px;
-					}
-					h2 {
-						color: #2c5282;
-					}
-					.button {
-						display: inline-block;
-						background-color: #2c5282;
-						color: white;
-						padding: 12px 24px;
-						text-decoration: none;
-						border-radius: 4px;
-						margin: 15px 0;
-					}
-					.footer {
-						margin-top: 30px;
-						font-size: 12px;
-						color: #666;
-					}
-				</style>
-			</head>
-			<body>
-				<div class="container">
-					<h2>Restablecimiento de contraseña</h2>
-					<p>Has solicitado restablecer tu contraseña en IYCDS 2025.</p>
-					<p>Haz clic en el siguiente enlace para crear una nueva contraseña:</p>
-					<p>
-						<a href="%s" class="button">Restablecer contraseña</a>
-					</p>
-					<p>Si no solicitaste este cambio, puedes ignorar este correo.</p>
-					<p>Este enlace expirará en 1 hora por motivos de seguridad.</p>
-					<div class="footer">
-						<p>Este correo fue enviado automáticamente. Por favor, no respondas a este mensaje.</p>
-					</div>
-				</div>
-			</body>
-		</html>
-	`, resetLink)
-
-	m.SetBody("text/html", body)
+	m.SetBody("text/plain", content.Text)
+	m.AddAlternative("text/html", content.HTML)
 
 	// Configurar dialer SMTP
 	d := gomail.NewDialer(s.Host, s.Port, s.Username, s.Password)
@@ -145,16 +113,24 @@ func (s *SMTPEmailService) SendPasswordResetEmail(to string, resetLink string) e
 		return fmt.Errorf("error al enviar correo: %v", err)
 	}
 
-	fmt.Printf("Correo de restablecimiento enviado a: %s\n", to)
+	fmt.Printf("Correo enviado a: %s (%s)\n", to.Email, content.Subject)
 	return nil
 }
 
 // MockEmailService implementa el servicio de correo para testing
 type MockEmailService struct{}
 
-func (m *MockEmailService) SendPasswordResetEmail(email, resetLink string) error {
-	// Por ahora simulamos el envío de email
-	fmt.Printf("Mock: Sending password reset email to %s with link %s\n", email, resetLink)
+func (m *MockEmailService) SendPasswordResetEmail(to Recipient, resetLink string) error {
+	content, err := buildPasswordResetEmail(to, resetLink)
+	if err != nil {
+		return err
+	}
+	return m.send(to, content)
+}
+
+// send solo registra el correo en los logs
+func (m *MockEmailService) send(to Recipient, content renderedEmail) error {
+	fmt.Printf("Mock: Sending email to %s with subject %q\n%s\n", to.Email, content.Subject, content.Text)
 	return nil
 }
 
@@ -197,81 +173,20 @@ func NewSendGridEmailService() *SendGridEmailService {
 }
 
 // SendPasswordResetEmail envía un correo de restablecimiento de contraseña usando SendGrid
-func (s *SendGridEmailService) SendPasswordResetEmail(to string, resetLink string) error {
+func (s *SendGridEmailService) SendPasswordResetEmail(to Recipient, resetLink string) error {
+	content, err := buildPasswordResetEmail(to, resetLink)
+	if err != nil {
+		return err
+	}
+	return s.send(to, content)
+}
+
+// send envía un correo ya armado con la API de SendGrid
+func (s *SendGridEmailService) send(to Recipient, content renderedEmail) error {
 	from := mail.NewEmail(s.FromName, s.FromEmail)
-	toEmail := mail.NewEmail("", to)
-	subject := "Restablecimiento de contraseña - IYCDS 2025"
-
-	// Cuerpo HTML del correo
-	htmlContent := fmt.Sprintf(`
-		<html>
-			<head>
-				<style>
-					body {
-						font-family: Arial, sans-serif;
-						line-height: 1.6;
-						color: #333;
-					}
-					.container {
-						max-width: 600px;
-						margin: 0 auto;
-						padding: 20px;
-						border: 1px solid #ddd;
-						border-radius: 5px;
-					}
-					h2 {
-						color: #2c5282;
-					}
-					.button {
-						display: inline-block;
-						background-color: #2c5282;
-						color: white;
-						padding: 12px 24px;
-						text-decoration: none;
-						border-radius: 4px;
-						margin: 15px 0;
-					}
-					.footer {
-						margin-top: 30px;
-						font-size: 12px;
-						color: #666;
-					}
-				</style>
-			</head>
-			<body>
-				<div class="container">
-					<h2>Restablecimiento de contraseña</h2>
-					<p>Has solicitado restablecer tu contraseña en IYCDS 2025.</p>
-					<p>Haz clic en el siguiente enlace para crear una nueva contraseña:</p>
-					<p>
-						<a href="%s" class="button">Restablecer contraseña</a>
-					</p>
-					<p>Si no solicitaste este cambio, puedes ignorar este correo.</p>
-					<p>Este enlace expirará en 1 hora por motivos de seguridad.</p>
-					<div class="footer">
-						<p>Este correo fue enviado automáticamente. Por favor, no respondas a este mensaje.</p>
-					</div>
-				</div>
-			</body>
-		</html>
-	`, resetLink)
-
-	// Texto plano como alternativa
-	plainTextContent := fmt.Sprintf(`
-Restablecimiento de contraseña - IYCDS 2025
-
-Has solicitado restablecer tu contraseña en IYCDS 2025.
-
-Visita el siguiente enlace para crear una nueva contraseña:
-%s
-
-Si no solicitaste este cambio, puedes ignorar este correo.
-Este enlace expirará en 1 hora por motivos de seguridad.
-
-Este correo fue enviado automáticamente. Por favor, no respondas a este mensaje.
-	`, resetLink)
-
-	message := mail.NewSingleEmail(from, subject, toEmail, plainTextContent, htmlContent)
+	toEmail := mail.NewEmail(to.Name, to.Email)
+
+	message := mail.NewSingleEmail(from, content.Subject, toEmail, content.Text, content.HTML)
 
 	client := sendgrid.NewSendClient(s.APIKey)
 	response, err := client.Send(message)
@@ -282,9 +197,19 @@ Este correo fue enviado automáticamente. Por favor, no respondas a este mensaje
 
 	// SendGrid devuelve 202 para éxito
 	if response.StatusCode >= 200 && response.StatusCode < 300 {
-		fmt.Printf("Correo de restablecimiento enviado exitosamente a: %s (Status: %d)\n", to, response.StatusCode)
+		fmt.Printf("Correo enviado exitosamente a: %s (Status: %d)\n", to.Email, response.StatusCode)
 		return nil
 	}
 
 	return fmt.Errorf("SendGrid devolvió error: Status %d, Body: %s", response.StatusCode, response.Body)
 }
+
+// passwordResetEmailData son los datos de la plantilla de restablecimiento de contraseña
+type passwordResetEmailData struct {
+	ResetLink string
+}
+
+// buildPasswordResetEmail arma el correo de restablecimiento de contraseña en el idioma del destinatario
+func buildPasswordResetEmail(to Recipient, resetLink string) (renderedEmail, error) {
+	return templates.render(KindPasswordReset, to, passwordResetEmailData{ResetLink: resetLink})
+}
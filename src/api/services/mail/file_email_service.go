@@ -0,0 +1,258 @@
+package mail
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	gomail "gopkg.in/mail.v2"
+)
+
+// DefaultMailboxDir es el directorio donde se guardan los correos si no se configura EMAIL_FILE_DIR
+const DefaultMailboxDir = "tmp/mailbox"
+
+// capturedEmailID valida los IDs de los correos guardados: evita leer archivos fuera del directorio
+var capturedEmailID = regexp.MustCompile(`^\d{8}-\d{6}\.\d{9}-[0-9a-f]{8}$`)
+
+// CapturedEmail es un correo guardado por FileEmailService
+type CapturedEmail struct {
+	ID        string    `json:"id"`
+	To        string    `json:"to"`
+	ToName    string    `json:"to_name"`
+	Locale    string    `json:"locale"`
+	Subject   string    `json:"subject"`
+	Text      string    `json:"text"`
+	HTML      string    `json:"html"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// FileEmailService implementa el servicio de correo guardando cada mensaje en un directorio,
+// como .eml (el mensaje MIME completo) y como .json (para inspeccionarlo desde tests o herramientas).
+// Pensado para desarrollo y tests de integración: no envía nada.
+type FileEmailService struct {
+	Dir      string
+	From     string
+	FromName string
+}
+
+// NewFileEmailService crea el servicio que guarda los correos en dir
+func NewFileEmailService(dir string) *FileEmailService {
+	if dir == "" {
+		dir = DefaultMailboxDir
+	}
+
+	return &FileEmailService{
+		Dir:      dir,
+		From:     "noreply@iycds2025.com",
+		FromName: "IYCDS 2025",
+	}
+}
+
+// SendPasswordResetEmail guarda el correo de restablecimiento de contraseña
+func (s *FileEmailService) SendPasswordResetEmail(to Recipient, resetLink string) error {
+	content, err := buildPasswordResetEmail(to, resetLink)
+	if err != nil {
+		return err
+	}
+	return s.send(to, content)
+}
+
+// SendEmailVerificationEmail guarda el correo para confirmar la dirección de email
+func (s *FileEmailService) SendEmailVerificationEmail(to Recipient, data EmailVerificationData) error {
+	content, err := buildEmailVerificationEmail(to, data)
+	if err != nil {
+		return err
+	}
+	return s.send(to, content)
+}
+
+// SendPasswordChangedEmail guarda el aviso de cambio de contraseña
+func (s *FileEmailService) SendPasswordChangedEmail(to Recipient, data PasswordChangedData) error {
+	content, err := buildPasswordChangedEmail(to, data)
+	if err != nil {
+		return err
+	}
+	return s.send(to, content)
+}
+
+// SendAccountLockedEmail guarda el aviso de bloqueo del login
+func (s *FileEmailService) SendAccountLockedEmail(to Recipient, data AccountLockedData) error {
+	content, err := buildAccountLockedEmail(to, data)
+	if err != nil {
+		return err
+	}
+	return s.send(to, content)
+}
+
+// SendAccountDeletionScheduledEmail guarda el aviso de baja programada de la cuenta
+func (s *FileEmailService) SendAccountDeletionScheduledEmail(to Recipient, data AccountDeletionScheduledData) error {
+	content, err := buildAccountDeletionScheduledEmail(to, data)
+	if err != nil {
+		return err
+	}
+	return s.send(to, content)
+}
+
+// SendNewAppointmentEmail guarda el aviso de nueva reserva para el proveedor
+func (s *FileEmailService) SendNewAppointmentEmail(to Recipient, data AppointmentEmailData) error {
+	content, err := buildNewAppointmentEmail(to, data)
+	if err != nil {
+		return err
+	}
+	return s.send(to, content)
+}
+
+// SendAppointmentStatusEmail guarda el aviso de cambio de estado de una cita
+func (s *FileEmailService) SendAppointmentStatusEmail(to Recipient, data AppointmentEmailData) error {
+	content, err := buildAppointmentStatusEmail(to, data)
+	if err != nil {
+		return err
+	}
+	return s.send(to, content)
+}
+
+func (s *FileEmailService) send(to Recipient, content renderedEmail) error {
+	if err := os.MkdirAll(s.Dir, 0o755); err != nil {
+		return fmt.Errorf("error al crear el directorio de correos: %v", err)
+	}
+
+	id, err := newCapturedEmailID()
+	if err != nil {
+		return err
+	}
+
+	// Mensaje MIME completo, igual al que enviaría el backend SMTP
+	m := gomail.NewMessage()
+	m.SetHeader("From", fmt.Sprintf("%s <%s>", s.FromName, s.From))
+	m.SetAddressHeader("To", to.Email, to.Name)
+	m.SetHeader("Subject", content.Subject)
+	m.SetBody("text/plain", content.Text)
+	m.AddAlternative("text/html", content.HTML)
+
+	emlFile, err := os.Create(filepath.Join(s.Dir, id+".eml"))
+	if err != nil {
+		return fmt.Errorf("error al guardar correo: %v", err)
+	}
+	if _, err := m.WriteTo(emlFile); err != nil {
+		emlFile.Close()
+		return fmt.Errorf("error al guardar correo: %v", err)
+	}
+	if err := emlFile.Close(); err != nil {
+		return fmt.Errorf("error al guardar correo: %v", err)
+	}
+
+	captured := CapturedEmail{
+		ID:        id,
+		To:        to.Email,
+		ToName:    to.Name,
+		Locale:    NormalizeLocale(to.Locale),
+		Subject:   content.Subject,
+		Text:      content.Text,
+		HTML:      content.HTML,
+		CreatedAt: time.Now(),
+	}
+	encoded, err := json.MarshalIndent(captured, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	// El .json se escribe al final y con rename para que nunca se lea a medio escribir:
+	// su presencia indica que el correo está completo
+	tmpPath := filepath.Join(s.Dir, "."+id+".json.tmp")
+	if err := os.WriteFile(tmpPath, encoded, 0o644); err != nil {
+		return fmt.Errorf("error al guardar correo: %v", err)
+	}
+	if err := os.Rename(tmpPath, filepath.Join(s.Dir, id+".json")); err != nil {
+		return fmt.Errorf("error al guardar correo: %v", err)
+	}
+
+	fmt.Printf("Correo guardado en %s: %s (%s)\n", filepath.Join(s.Dir, id+".eml"), to.Email, content.Subject)
+	return nil
+}
+
+// List devuelve los correos guardados, del más reciente al más antiguo
+func (s *FileEmailService) List() ([]*CapturedEmail, error) {
+	entries, err := os.ReadDir(s.Dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []*CapturedEmail{}, nil
+		}
+		return nil, err
+	}
+
+	var ids []string
+	for _, entry := range entries {
+		id := strings.TrimSuffix(entry.Name(), ".json")
+		if id != entry.Name() && capturedEmailID.MatchString(id) {
+			ids = append(ids, id)
+		}
+	}
+	// Los IDs empiezan con la fecha, así que ordenarlos como texto los ordena por fecha
+	sort.Sort(sort.Reverse(sort.StringSlice(ids)))
+
+	emails := make([]*CapturedEmail, 0, len(ids))
+	for _, id := range ids {
+		email, err := s.Get(id)
+		if err != nil {
+			return nil, err
+		}
+		if email != nil {
+			emails = append(emails, email)
+		}
+	}
+
+	return emails, nil
+}
+
+// Get devuelve un correo guardado; nil si no existe
+func (s *FileEmailService) Get(id string) (*CapturedEmail, error) {
+	if !capturedEmailID.MatchString(id) {
+		return nil, nil
+	}
+
+	content, err := os.ReadFile(filepath.Join(s.Dir, id+".json"))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var email CapturedEmail
+	if err := json.Unmarshal(content, &email); err != nil {
+		return nil, fmt.Errorf("correo %s dañado: %v", id, err)
+	}
+	return &email, nil
+}
+
+// EML devuelve el mensaje MIME completo de un correo guardado; nil si no existe
+func (s *FileEmailService) EML(id string) ([]byte, error) {
+	if !capturedEmailID.MatchString(id) {
+		return nil, nil
+	}
+
+	content, err := os.ReadFile(filepath.Join(s.Dir, id+".eml"))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return content, nil
+}
+
+// newCapturedEmailID genera un ID ordenable por fecha, ej: "20250113-103000.123456789-1a2b3c4d"
+func newCapturedEmailID() (string, error) {
+	suffix := make([]byte, 4)
+	if _, err := rand.Read(suffix); err != nil {
+		return "", err
+	}
+	return time.Now().UTC().Format("20060102-150405.000000000") + "-" + hex.EncodeToString(suffix), nil
+}
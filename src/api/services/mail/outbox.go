@@ -0,0 +1,188 @@
+package mail
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"iycds2025_api/src/api/core/entities"
+	"iycds2025_api/src/api/core/interfaces"
+)
+
+// Tipos de correo que se pueden encolar en el outbox
+const (
+	KindPasswordReset     = "password_reset"
+	KindEmailVerification = "email_verification"
+	KindPasswordChanged   = "password_changed"
+	KindAccountLocked     = "account_locked"
+	KindNewAppointment    = "new_appointment"
+	KindAppointmentStatus = "appointment_status"
+
+	KindAccountDeletionScheduled = "account_deletion_scheduled"
+)
+
+// ErrInvalidOutboxEmail indica que un correo del outbox no se puede armar y no tiene sentido reintentarlo
+var ErrInvalidOutboxEmail = errors.New("invalid outbox email")
+
+// enqueueTimeout limita cuánto puede demorar el INSERT en la cola dentro de un request
+const enqueueTimeout = 5 * time.Second
+
+// passwordResetPayload son los datos que se guardan para reenviar un correo de restablecimiento
+type passwordResetPayload struct {
+	To        string `json:"to"`
+	Name      string `json:"name,omitempty"`
+	Locale    string `json:"locale,omitempty"`
+	ResetLink string `json:"reset_link"`
+}
+
+// emailVerificationPayload son los datos que se guardan para reenviar un correo de verificación de email
+type emailVerificationPayload struct {
+	To   Recipient             `json:"to"`
+	Data EmailVerificationData `json:"data"`
+}
+
+// passwordChangedPayload son los datos que se guardan para reenviar un aviso de cambio de contraseña
+type passwordChangedPayload struct {
+	To   Recipient           `json:"to"`
+	Data PasswordChangedData `json:"data"`
+}
+
+// accountLockedPayload son los datos que se guardan para reenviar un aviso de bloqueo del login
+type accountLockedPayload struct {
+	To   Recipient         `json:"to"`
+	Data AccountLockedData `json:"data"`
+}
+
+// accountDeletionScheduledPayload son los datos que se guardan para reenviar un aviso de baja programada
+type accountDeletionScheduledPayload struct {
+	To   Recipient                    `json:"to"`
+	Data AccountDeletionScheduledData `json:"data"`
+}
+
+// appointmentPayload son los datos que se guardan para reenviar una notificación de cita
+type appointmentPayload struct {
+	To   Recipient            `json:"to"`
+	Data AppointmentEmailData `json:"data"`
+}
+
+// OutboxEmailService implementa EmailService guardando cada correo en la tabla email_outbox.
+// El envío real lo hace el worker del outbox, fuera del request HTTP y con reintentos.
+type OutboxEmailService struct {
+	Outbox interfaces.EmailOutbox
+}
+
+// NewOutboxEmailService crea un servicio de correo que encola en lugar de enviar
+func NewOutboxEmailService(outbox interfaces.EmailOutbox) *OutboxEmailService {
+	return &OutboxEmailService{Outbox: outbox}
+}
+
+// SendPasswordResetEmail encola un correo de restablecimiento de contraseña
+func (s *OutboxEmailService) SendPasswordResetEmail(to Recipient, resetLink string) error {
+	return s.enqueue(KindPasswordReset, to.Email, passwordResetPayload{To: to.Email, Name: to.Name, Locale: to.Locale, ResetLink: resetLink})
+}
+
+// SendEmailVerificationEmail encola el correo para confirmar la dirección de email
+func (s *OutboxEmailService) SendEmailVerificationEmail(to Recipient, data EmailVerificationData) error {
+	return s.enqueue(KindEmailVerification, to.Email, emailVerificationPayload{To: to, Data: data})
+}
+
+// SendPasswordChangedEmail encola el aviso de cambio de contraseña
+func (s *OutboxEmailService) SendPasswordChangedEmail(to Recipient, data PasswordChangedData) error {
+	return s.enqueue(KindPasswordChanged, to.Email, passwordChangedPayload{To: to, Data: data})
+}
+
+// SendAccountLockedEmail encola el aviso de bloqueo del login
+func (s *OutboxEmailService) SendAccountLockedEmail(to Recipient, data AccountLockedData) error {
+	return s.enqueue(KindAccountLocked, to.Email, accountLockedPayload{To: to, Data: data})
+}
+
+// SendAccountDeletionScheduledEmail encola el aviso de baja programada de la cuenta
+func (s *OutboxEmailService) SendAccountDeletionScheduledEmail(to Recipient, data AccountDeletionScheduledData) error {
+	return s.enqueue(KindAccountDeletionScheduled, to.Email, accountDeletionScheduledPayload{To: to, Data: data})
+}
+
+// SendNewAppointmentEmail encola el aviso de nueva reserva para el proveedor
+func (s *OutboxEmailService) SendNewAppointmentEmail(to Recipient, data AppointmentEmailData) error {
+	return s.enqueue(KindNewAppointment, to.Email, appointmentPayload{To: to, Data: data})
+}
+
+// SendAppointmentStatusEmail encola el aviso de cambio de estado de una cita
+func (s *OutboxEmailService) SendAppointmentStatusEmail(to Recipient, data AppointmentEmailData) error {
+	// Validar el estado ahora para no encolar un correo que nunca se va a poder armar
+	if !hasAppointmentStatusEmail(data.Status) {
+		return fmt.Errorf("no hay notificación para el estado %q", data.Status)
+	}
+	return s.enqueue(KindAppointmentStatus, to.Email, appointmentPayload{To: to, Data: data})
+}
+
+func (s *OutboxEmailService) enqueue(kind string, recipient string, payload interface{}) error {
+	encoded, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("error al serializar el correo: %v", err)
+	}
+
+	// El correo se encola aunque el request que lo originó ya se haya cancelado
+	ctx, cancel := context.WithTimeout(context.Background(), enqueueTimeout)
+	defer cancel()
+
+	if _, err := s.Outbox.Enqueue(ctx, kind, recipient, string(encoded)); err != nil {
+		return fmt.Errorf("error al encolar correo: %v", err)
+	}
+	return nil
+}
+
+// Deliver envía un correo del outbox con el servicio indicado según su tipo
+func Deliver(service EmailService, message *entities.OutboxEmail) error {
+	switch message.Kind {
+	case KindPasswordReset:
+		var payload passwordResetPayload
+		if err := json.Unmarshal([]byte(message.Payload), &payload); err != nil {
+			return fmt.Errorf("%w: payload inválido: %v", ErrInvalidOutboxEmail, err)
+		}
+		to := Recipient{Email: payload.To, Name: payload.Name, Locale: payload.Locale}
+		return service.SendPasswordResetEmail(to, payload.ResetLink)
+
+	case KindEmailVerification:
+		var payload emailVerificationPayload
+		if err := json.Unmarshal([]byte(message.Payload), &payload); err != nil {
+			return fmt.Errorf("%w: payload inválido: %v", ErrInvalidOutboxEmail, err)
+		}
+		return service.SendEmailVerificationEmail(payload.To, payload.Data)
+
+	case KindPasswordChanged:
+		var payload passwordChangedPayload
+		if err := json.Unmarshal([]byte(message.Payload), &payload); err != nil {
+			return fmt.Errorf("%w: payload inválido: %v", ErrInvalidOutboxEmail, err)
+		}
+		return service.SendPasswordChangedEmail(payload.To, payload.Data)
+
+	case KindAccountLocked:
+		var payload accountLockedPayload
+		if err := json.Unmarshal([]byte(message.Payload), &payload); err != nil {
+			return fmt.Errorf("%w: payload inválido: %v", ErrInvalidOutboxEmail, err)
+		}
+		return service.SendAccountLockedEmail(payload.To, payload.Data)
+
+	case KindAccountDeletionScheduled:
+		var payload accountDeletionScheduledPayload
+		if err := json.Unmarshal([]byte(message.Payload), &payload); err != nil {
+			return fmt.Errorf("%w: payload inválido: %v", ErrInvalidOutboxEmail, err)
+		}
+		return service.SendAccountDeletionScheduledEmail(payload.To, payload.Data)
+
+	case KindNewAppointment, KindAppointmentStatus:
+		var payload appointmentPayload
+		if err := json.Unmarshal([]byte(message.Payload), &payload); err != nil {
+			return fmt.Errorf("%w: payload inválido: %v", ErrInvalidOutboxEmail, err)
+		}
+		if message.Kind == KindNewAppointment {
+			return service.SendNewAppointmentEmail(payload.To, payload.Data)
+		}
+		return service.SendAppointmentStatusEmail(payload.To, payload.Data)
+
+	default:
+		return fmt.Errorf("%w: tipo de correo desconocido %q", ErrInvalidOutboxEmail, message.Kind)
+	}
+}
@@ -0,0 +1,211 @@
+package mail
+
+import (
+	"bytes"
+	"embed"
+	"encoding/json"
+	"fmt"
+	htmltemplate "html/template"
+	"sort"
+	"strings"
+	texttemplate "text/template"
+
+	"iycds2025_api/src/api/core/entities"
+)
+
+// Las plantillas y los textos de cada idioma se embeben en el binario
+//
+//go:embed templates/*.tmpl templates/locales/*.json
+var templateFS embed.FS
+
+// Nombres de las plantillas de correo; coinciden con los tipos de correo del outbox
+var templateNames = []string{KindPasswordReset, KindEmailVerification, KindPasswordChanged, KindAccountLocked, KindAccountDeletionScheduled, KindNewAppointment, KindAppointmentStatus}
+
+// renderedEmail es el contenido ya armado de un correo
+type renderedEmail struct {
+	Subject string
+	HTML    string
+	Text    string
+}
+
+// templateView son los datos que reciben todas las plantillas
+type templateView struct {
+	To    Recipient
+	Title string
+	Data  interface{}
+}
+
+// emailTemplates contiene las plantillas ya compiladas para cada idioma
+type emailTemplates struct {
+	catalogs map[string]map[string]string
+	html     map[string]map[string]*htmltemplate.Template // idioma -> plantilla -> template
+	text     map[string]map[string]*texttemplate.Template
+}
+
+// templates se carga al iniciar: un error en las plantillas embebidas es un error de programación
+var templates = mustLoadTemplates()
+
+func mustLoadTemplates() *emailTemplates {
+	loaded, err := loadTemplates()
+	if err != nil {
+		panic("mail templates: " + err.Error())
+	}
+	return loaded
+}
+
+func loadTemplates() (*emailTemplates, error) {
+	loaded := &emailTemplates{
+		catalogs: make(map[string]map[string]string),
+		html:     make(map[string]map[string]*htmltemplate.Template),
+		text:     make(map[string]map[string]*texttemplate.Template),
+	}
+
+	for _, locale := range entities.SupportedLocales {
+		content, err := templateFS.ReadFile("templates/locales/" + locale + ".json")
+		if err != nil {
+			return nil, err
+		}
+		var catalog map[string]string
+		if err := json.Unmarshal(content, &catalog); err != nil {
+			return nil, fmt.Errorf("locale %s: %v", locale, err)
+		}
+		loaded.catalogs[locale] = catalog
+	}
+
+	// Todos los idiomas deben tener los mismos textos que el idioma por defecto
+	for _, locale := range entities.SupportedLocales {
+		if missing := missingKeys(loaded.catalogs[entities.DefaultLocale], loaded.catalogs[locale]); len(missing) > 0 {
+			return nil, fmt.Errorf("locale %s is missing %s", locale, strings.Join(missing, ", "))
+		}
+	}
+
+	for _, locale := range entities.SupportedLocales {
+		funcs := loaded.funcs(locale)
+		loaded.html[locale] = make(map[string]*htmltemplate.Template)
+		loaded.text[locale] = make(map[string]*texttemplate.Template)
+
+		for _, name := range templateNames {
+			htmlTemplate, err := htmltemplate.New(name).Funcs(htmltemplate.FuncMap(funcs)).ParseFS(templateFS,
+				"templates/layout.html.tmpl", "templates/partials.html.tmpl", "templates/"+name+".html.tmpl")
+			if err != nil {
+				return nil, err
+			}
+			textTemplate, err := texttemplate.New(name).Funcs(funcs).ParseFS(templateFS,
+				"templates/layout.txt.tmpl", "templates/partials.txt.tmpl", "templates/"+name+".txt.tmpl")
+			if err != nil {
+				return nil, err
+			}
+
+			loaded.html[locale][name] = htmlTemplate
+			loaded.text[locale][name] = textTemplate
+		}
+	}
+
+	return loaded, nil
+}
+
+// funcs devuelve las funciones disponibles en las plantillas de un idioma:
+// t traduce una clave (con argumentos estilo Printf) y counterpart nombra a la otra parte de una cita
+func (e *emailTemplates) funcs(locale string) texttemplate.FuncMap {
+	return texttemplate.FuncMap{
+		"t": func(key string, args ...interface{}) (string, error) {
+			return e.translate(locale, key, args...)
+		},
+		"counterpart": func(name string) (string, error) {
+			if name != "" {
+				return name, nil
+			}
+			return e.translate(locale, "someone")
+		},
+	}
+}
+
+func (e *emailTemplates) translate(locale, key string, args ...interface{}) (string, error) {
+	message, ok := e.catalogs[locale][key]
+	if !ok {
+		return "", fmt.Errorf("missing translation %q for locale %s", key, locale)
+	}
+	if len(args) == 0 {
+		return message, nil
+	}
+	return fmt.Sprintf(message, args...), nil
+}
+
+// hasKey indica si existe un texto, ej: para saber si hay notificación para un estado de cita
+func (e *emailTemplates) hasKey(key string) bool {
+	_, ok := e.catalogs[entities.DefaultLocale][key]
+	return ok
+}
+
+// render arma un correo con la plantilla indicada en el idioma del destinatario
+func (e *emailTemplates) render(name string, to Recipient, data interface{}) (renderedEmail, error) {
+	locale := NormalizeLocale(to.Locale)
+	textTemplate, ok := e.text[locale][name]
+	if !ok {
+		return renderedEmail{}, fmt.Errorf("unknown email template %q", name)
+	}
+	htmlTemplate := e.html[locale][name]
+
+	view := templateView{To: to, Data: data}
+
+	subject, err := executeText(textTemplate, "subject", view)
+	if err != nil {
+		return renderedEmail{}, err
+	}
+	title, err := executeText(textTemplate, "title", view)
+	if err != nil {
+		return renderedEmail{}, err
+	}
+	view.Title = title
+
+	text, err := executeText(textTemplate, "layout", view)
+	if err != nil {
+		return renderedEmail{}, err
+	}
+
+	var html bytes.Buffer
+	if err := htmlTemplate.ExecuteTemplate(&html, "layout", view); err != nil {
+		return renderedEmail{}, err
+	}
+
+	return renderedEmail{
+		// El asunto va en un encabezado: no puede tener saltos de línea aunque el título del servicio los tenga
+		Subject: strings.Join(strings.Fields(subject), " ") + " - IYCDS 2025",
+		HTML:    html.String(),
+		Text:    text,
+	}, nil
+}
+
+func executeText(tmpl *texttemplate.Template, name string, view templateView) (string, error) {
+	var buffer bytes.Buffer
+	if err := tmpl.ExecuteTemplate(&buffer, name, view); err != nil {
+		return "", err
+	}
+	return buffer.String(), nil
+}
+
+// NormalizeLocale convierte una preferencia de idioma ("en", "pt-BR", "ES") en uno de los
+// idiomas soportados; si no se reconoce, usa el idioma por defecto
+func NormalizeLocale(locale string) string {
+	locale = strings.ToLower(strings.TrimSpace(locale))
+	if i := strings.IndexAny(locale, "-_"); i >= 0 {
+		locale = locale[:i]
+	}
+	for _, supported := range entities.SupportedLocales {
+		if locale == supported {
+			return locale
+		}
+	}
+	return entities.DefaultLocale
+}
+
+func missingKeys(reference, catalog map[string]string) []string {
+	var missing []string
+	for key := range reference {
+		if _, ok := catalog[key]; !ok {
+			missing = append(missing, key)
+		}
+	}
+	sort.Strings(missing)
+	return missing
+}
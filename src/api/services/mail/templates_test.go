@@ -0,0 +1,141 @@
+package mail
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"iycds2025_api/src/api/core/entities"
+)
+
+// update regenera los archivos esperados: go test ./src/api/services/mail -run TestTemplatesGolden -update
+var update = flag.Bool("update", false, "regenerate the golden files in testdata/")
+
+// goldenCase es un correo renderizado con datos fijos; cada plantilla tiene al menos un caso
+type goldenCase struct {
+	name     string
+	template string
+	data     interface{}
+}
+
+func goldenCases() []goldenCase {
+	appointment := AppointmentEmailData{
+		AppointmentID:   42,
+		ServiceTitle:    "Plomería <urgente> & gas",
+		Date:            "2025-03-14",
+		TimeSlot:        "10:00-11:00",
+		Timezone:        "America/Argentina/Buenos_Aires",
+		Status:          "pending",
+		CounterpartName: "Ana Gómez",
+		Notes:           "Timbre 3B",
+		AppointmentURL:  "https://app.example.com/appointments/42",
+	}
+
+	cases := []goldenCase{
+		{"password_reset", KindPasswordReset, passwordResetEmailData{ResetLink: "https://app.example.com/reset-password?token=abc123"}},
+		{"email_verification", KindEmailVerification, EmailVerificationData{VerifyLink: "https://app.example.com/verify-email?token=abc123"}},
+		{"email_verification_change", KindEmailVerification, EmailVerificationData{VerifyLink: "https://app.example.com/verify-email?token=abc123", EmailChange: true}},
+		{"password_changed", KindPasswordChanged, PasswordChangedData{
+			ChangedAt:         "14/03/2025 10:30 (UTC)",
+			IPAddress:         "203.0.113.7",
+			ForgotPasswordURL: "https://app.example.com/forgot-password",
+		}},
+		{"account_locked", KindAccountLocked, AccountLockedData{
+			FailedAttempts:    5,
+			LockedUntil:       "14/03/2025 10:45 (UTC)",
+			IPAddress:         "203.0.113.7",
+			ForgotPasswordURL: "https://app.example.com/forgot-password",
+		}},
+		{"account_deletion_scheduled", KindAccountDeletionScheduled, AccountDeletionScheduledData{
+			ScheduledFor: "13/04/2025 10:30 (UTC)",
+			IPAddress:    "203.0.113.7",
+			LoginURL:     "https://app.example.com/login",
+		}},
+		{"new_appointment", KindNewAppointment, appointment},
+	}
+
+	// Un caso por cada estado de cita con notificación; el último sin nombre de la otra parte
+	for _, status := range []string{"accepted", "rejected", "cancelled", "completed"} {
+		data := appointment
+		data.Status = status
+		if status == "completed" {
+			data.CounterpartName = ""
+		}
+		cases = append(cases, goldenCase{"appointment_status_" + status, KindAppointmentStatus, data})
+	}
+
+	return cases
+}
+
+// TestTemplatesGolden renderiza cada plantilla en cada idioma, en HTML y en texto, y compara el
+// resultado con testdata/<caso>.<idioma>.{html,txt}. El archivo de texto empieza con el asunto.
+func TestTemplatesGolden(t *testing.T) {
+	covered := map[string]bool{}
+
+	for _, tc := range goldenCases() {
+		covered[tc.template] = true
+
+		for _, locale := range entities.SupportedLocales {
+			t.Run(tc.name+"/"+locale, func(t *testing.T) {
+				to := Recipient{Email: "juan@example.com", Name: "Juan Pérez", Locale: locale}
+				content, err := templates.render(tc.template, to, tc.data)
+				if err != nil {
+					t.Fatalf("render: %v", err)
+				}
+
+				base := filepath.Join("testdata", tc.name+"."+locale)
+				assertGolden(t, base+".html", content.HTML)
+				assertGolden(t, base+".txt", "Subject: "+content.Subject+"\n\n"+content.Text)
+			})
+		}
+	}
+
+	for _, name := range templateNames {
+		if !covered[name] {
+			t.Errorf("template %s has no golden case", name)
+		}
+	}
+}
+
+func assertGolden(t *testing.T, path string, got string) {
+	t.Helper()
+
+	if *update {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(got), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("%v (run with -update to create it)", err)
+	}
+	if got != string(want) {
+		t.Errorf("%s does not match the rendered output (run with -update to regenerate):\n--- got ---\n%s\n--- want ---\n%s", path, got, want)
+	}
+}
+
+func TestNormalizeLocale(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"en", "en"},
+		{"pt-BR", "pt"},
+		{"ES", "es"},
+		{"en_US", "en"},
+		{"fr", entities.DefaultLocale},
+		{"", entities.DefaultLocale},
+	}
+
+	for _, tt := range tests {
+		if got := NormalizeLocale(tt.input); got != tt.want {
+			t.Errorf("NormalizeLocale(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
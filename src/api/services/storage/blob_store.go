@@ -0,0 +1,48 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"mime"
+	"path"
+	"regexp"
+)
+
+// ErrNotFound se devuelve cuando no existe un objeto con la clave pedida
+var ErrNotFound = errors.New("blob not found")
+
+// keyPattern son las claves válidas: segmentos en minúscula separados por "/". Cada segmento empieza
+// con una letra o un número, así que una clave no puede salir del directorio del almacenamiento local.
+var keyPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9._-]*(/[a-z0-9][a-z0-9._-]*)*$`)
+
+// BlobStore guarda archivos binarios (fotos de los servicios) identificados por una clave con forma
+// de ruta, por ejemplo "services/12/3f9a.jpg"
+type BlobStore interface {
+	// Put guarda el contenido con la clave indicada, reemplazándolo si ya existía
+	Put(ctx context.Context, key string, data []byte, contentType string) error
+	// Get devuelve el contenido guardado; ErrNotFound si la clave no existe. El llamador cierra Body.
+	Get(ctx context.Context, key string) (*Object, error)
+	// Delete elimina el objeto; no es un error que la clave no exista
+	Delete(ctx context.Context, key string) error
+}
+
+// Object es un archivo leído del almacenamiento
+type Object struct {
+	Body        io.ReadCloser
+	ContentType string
+	Size        int64
+}
+
+// ValidKey indica si la clave tiene un formato aceptado por los almacenamientos
+func ValidKey(key string) bool {
+	return len(key) <= 255 && keyPattern.MatchString(key)
+}
+
+// contentTypeFor deduce el tipo de contenido a partir de la extensión de la clave
+func contentTypeFor(key string) string {
+	if contentType := mime.TypeByExtension(path.Ext(key)); contentType != "" {
+		return contentType
+	}
+	return "application/octet-stream"
+}
@@ -0,0 +1,106 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// DefaultLocalDir es el directorio donde se guardan los archivos si no se configura otro
+const DefaultLocalDir = "./tmp/uploads"
+
+// LocalBlobStore guarda los archivos en un directorio del disco. Pensado para desarrollo o para una
+// única instancia de la API; con varias instancias se usa S3BlobStore.
+type LocalBlobStore struct {
+	Dir string
+}
+
+// NewLocalBlobStore crea el almacenamiento en el directorio indicado (DefaultLocalDir si está vacío)
+func NewLocalBlobStore(dir string) *LocalBlobStore {
+	if dir == "" {
+		dir = DefaultLocalDir
+	}
+	return &LocalBlobStore{Dir: dir}
+}
+
+func (s *LocalBlobStore) Put(ctx context.Context, key string, data []byte, contentType string) error {
+	filePath, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(filePath), 0o755); err != nil {
+		return err
+	}
+
+	// Se escribe en un archivo temporal y se renombra para que nunca se sirva un archivo a medio escribir
+	tmp, err := os.CreateTemp(filepath.Dir(filePath), ".upload-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), 0o644); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), filePath)
+}
+
+func (s *LocalBlobStore) Get(ctx context.Context, key string) (*Object, error) {
+	filePath, err := s.path(key)
+	if err != nil {
+		return nil, ErrNotFound
+	}
+
+	file, err := os.Open(filePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+	if info.IsDir() {
+		file.Close()
+		return nil, ErrNotFound
+	}
+
+	return &Object{
+		Body:        file,
+		ContentType: contentTypeFor(key),
+		Size:        info.Size(),
+	}, nil
+}
+
+func (s *LocalBlobStore) Delete(ctx context.Context, key string) error {
+	filePath, err := s.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.Remove(filePath); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// path devuelve la ruta en disco de la clave, que debe ser válida (ver ValidKey)
+func (s *LocalBlobStore) path(key string) (string, error) {
+	if !ValidKey(key) {
+		return "", fmt.Errorf("invalid blob key %q", key)
+	}
+	return filepath.Join(s.Dir, filepath.FromSlash(key)), nil
+}
@@ -0,0 +1,122 @@
+package storage
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestValidKey(t *testing.T) {
+	tests := []struct {
+		key   string
+		valid bool
+	}{
+		{"services/12/3f9a.jpg", true},
+		{"services/12/3f9a_thumb.png", true},
+		{"a", true},
+		{strings.Repeat("a", 255), true},
+		{"", false},
+		{"../x", false},
+		{"services/../../etc/passwd", false},
+		{"./x", false},
+		{"/abs", false},
+		{"/etc/passwd", false},
+		{"services//1.jpg", false},
+		{"services/1/", false},
+		{"services/.hidden", false},
+		{"Services/1.jpg", false},
+		{"services/1.JPG", false},
+		{`services\1.jpg`, false},
+		{"services/1 2.jpg", false},
+		{"services/ñ.jpg", false},
+		{strings.Repeat("a", 256), false},
+	}
+
+	for _, tt := range tests {
+		if got := ValidKey(tt.key); got != tt.valid {
+			t.Errorf("ValidKey(%q) = %v, want %v", tt.key, got, tt.valid)
+		}
+	}
+}
+
+func TestLocalBlobStoreRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	store := NewLocalBlobStore(t.TempDir())
+	key := "services/12/3f9a.png"
+
+	if err := store.Put(ctx, key, []byte("png data"), "image/png"); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+	// Reemplazar el contenido de una clave existente
+	if err := store.Put(ctx, key, []byte("new png data"), "image/png"); err != nil {
+		t.Fatalf("Put replacing: %v", err)
+	}
+
+	object, err := store.Get(ctx, key)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	data, _ := io.ReadAll(object.Body)
+	object.Body.Close()
+	if string(data) != "new png data" || object.ContentType != "image/png" || object.Size != int64(len(data)) {
+		t.Errorf("Get = (%q, %s, %d)", data, object.ContentType, object.Size)
+	}
+
+	// No quedan archivos temporales junto al guardado
+	entries, err := os.ReadDir(filepath.Join(store.Dir, "services", "12"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 {
+		t.Errorf("directory has %d entries, want only the stored file", len(entries))
+	}
+
+	if err := store.Delete(ctx, key); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if _, err := store.Get(ctx, key); err != ErrNotFound {
+		t.Errorf("Get after Delete: got %v, want ErrNotFound", err)
+	}
+	if err := store.Delete(ctx, key); err != nil {
+		t.Errorf("Delete of a missing key: %v", err)
+	}
+
+	// Un directorio no es un objeto
+	if _, err := store.Get(ctx, "services"); err != ErrNotFound {
+		t.Errorf("Get of a directory: got %v, want ErrNotFound", err)
+	}
+}
+
+func TestLocalBlobStoreRejectsTraversal(t *testing.T) {
+	ctx := context.Background()
+	parent := t.TempDir()
+	store := NewLocalBlobStore(filepath.Join(parent, "uploads"))
+
+	// Un archivo fuera del directorio del almacenamiento que no debe poder leerse ni borrarse
+	outside := filepath.Join(parent, "secret.txt")
+	if err := os.WriteFile(outside, []byte("secret"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, key := range []string{"../secret.txt", "../x", outside, "/abs", "Secret.txt", strings.Repeat("a", 256)} {
+		if err := store.Put(ctx, key, []byte("data"), "text/plain"); err == nil {
+			t.Errorf("Put(%q) succeeded, want an invalid key error", key)
+		}
+		if _, err := store.Get(ctx, key); err != ErrNotFound {
+			t.Errorf("Get(%q): got %v, want ErrNotFound", key, err)
+		}
+		if err := store.Delete(ctx, key); err == nil {
+			t.Errorf("Delete(%q) succeeded, want an invalid key error", key)
+		}
+	}
+
+	if data, err := os.ReadFile(outside); err != nil || string(data) != "secret" {
+		t.Errorf("file outside the store was modified: (%q, %v)", data, err)
+	}
+	if _, err := os.Stat(filepath.Join(parent, "x")); !os.IsNotExist(err) {
+		t.Error("Put wrote a file outside the store directory")
+	}
+}
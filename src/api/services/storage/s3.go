@@ -0,0 +1,170 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// S3Config es la configuración de un almacenamiento compatible con S3 (AWS S3, MinIO, R2, etc.)
+type S3Config struct {
+	// Endpoint es la URL base del servicio, ej: "http://minio:9000". Vacío usa AWS: https://s3.<región>.amazonaws.com
+	Endpoint        string
+	Region          string
+	Bucket          string
+	AccessKeyID     string
+	SecretAccessKey string
+	// PathStyle arma las URLs como <endpoint>/<bucket>/<clave> en lugar de <bucket>.<host>/<clave>;
+	// lo necesitan MinIO y la mayoría de los servicios compatibles
+	PathStyle bool
+}
+
+// S3BlobStore guarda los archivos en un bucket de un servicio compatible con S3. Las peticiones se
+// firman con AWS Signature Version 4.
+type S3BlobStore struct {
+	config   S3Config
+	endpoint *url.URL
+	client   *http.Client
+}
+
+// NewS3BlobStore valida la configuración y crea el almacenamiento
+func NewS3BlobStore(config S3Config) (*S3BlobStore, error) {
+	if config.Bucket == "" || config.AccessKeyID == "" || config.SecretAccessKey == "" {
+		return nil, fmt.Errorf("s3 storage requires a bucket, an access key ID and a secret access key")
+	}
+	if config.Region == "" {
+		config.Region = "us-east-1"
+	}
+	if config.Endpoint == "" {
+		config.Endpoint = "https://s3." + config.Region + ".amazonaws.com"
+	}
+
+	endpoint, err := url.Parse(strings.TrimRight(config.Endpoint, "/"))
+	if err != nil || (endpoint.Scheme != "http" && endpoint.Scheme != "https") || endpoint.Host == "" {
+		return nil, fmt.Errorf("invalid s3 endpoint %q", config.Endpoint)
+	}
+
+	return &S3BlobStore{
+		config:   config,
+		endpoint: endpoint,
+		client:   &http.Client{Timeout: 30 * time.Second},
+	}, nil
+}
+
+func (s *S3BlobStore) Put(ctx context.Context, key string, data []byte, contentType string) error {
+	req, err := s.newRequest(ctx, http.MethodPut, key, data)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", contentType)
+	req.ContentLength = int64(len(data))
+	signV4(req, hashHex(data), s.config.AccessKeyID, s.config.SecretAccessKey, s.config.Region, time.Now())
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return s3Error(req, resp)
+	}
+	return nil
+}
+
+func (s *S3BlobStore) Get(ctx context.Context, key string) (*Object, error) {
+	if !ValidKey(key) {
+		return nil, ErrNotFound
+	}
+
+	req, err := s.newRequest(ctx, http.MethodGet, key, nil)
+	if err != nil {
+		return nil, err
+	}
+	signV4(req, emptyPayloadHash, s.config.AccessKeyID, s.config.SecretAccessKey, s.config.Region, time.Now())
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		contentType := resp.Header.Get("Content-Type")
+		if contentType == "" {
+			contentType = contentTypeFor(key)
+		}
+		return &Object{
+			Body:        resp.Body,
+			ContentType: contentType,
+			Size:        resp.ContentLength,
+		}, nil
+	case http.StatusNotFound:
+		resp.Body.Close()
+		return nil, ErrNotFound
+	default:
+		defer resp.Body.Close()
+		return nil, s3Error(req, resp)
+	}
+}
+
+func (s *S3BlobStore) Delete(ctx context.Context, key string) error {
+	req, err := s.newRequest(ctx, http.MethodDelete, key, nil)
+	if err != nil {
+		return err
+	}
+	signV4(req, emptyPayloadHash, s.config.AccessKeyID, s.config.SecretAccessKey, s.config.Region, time.Now())
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	// S3 responde 204 aunque el objeto no exista
+	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNotFound {
+		return s3Error(req, resp)
+	}
+	return nil
+}
+
+// newRequest arma la petición al objeto con el estilo de URL configurado
+func (s *S3BlobStore) newRequest(ctx context.Context, method, key string, body []byte) (*http.Request, error) {
+	if !ValidKey(key) {
+		return nil, fmt.Errorf("invalid blob key %q", key)
+	}
+
+	objectURL := *s.endpoint
+	basePath := strings.TrimRight(objectURL.Path, "/")
+	if s.config.PathStyle {
+		objectURL.Path = basePath + "/" + s.config.Bucket + "/" + key
+	} else {
+		objectURL.Host = s.config.Bucket + "." + objectURL.Host
+		objectURL.Path = basePath + "/" + key
+	}
+
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+	return http.NewRequestWithContext(ctx, method, objectURL.String(), reader)
+}
+
+// s3Error arma el error a partir de la respuesta XML de S3 (<Error><Code>...</Code><Message>...</Message></Error>)
+func s3Error(req *http.Request, resp *http.Response) error {
+	var body struct {
+		Code    string `xml:"Code"`
+		Message string `xml:"Message"`
+	}
+	raw, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+	if err := xml.Unmarshal(raw, &body); err != nil || body.Code == "" {
+		return fmt.Errorf("s3 %s %s: status %d", req.Method, req.URL.Path, resp.StatusCode)
+	}
+	return fmt.Errorf("s3 %s %s: %s: %s (status %d)", req.Method, req.URL.Path, body.Code, body.Message, resp.StatusCode)
+}
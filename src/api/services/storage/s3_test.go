@@ -0,0 +1,209 @@
+package storage
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeS3 es un servicio compatible con S3 mínimo (estilo de URL por ruta, como MinIO) que guarda
+// los objetos en memoria y verifica la firma de cada petición con las mismas credenciales
+type fakeS3 struct {
+	t       *testing.T
+	bucket  string
+	mu      sync.Mutex
+	objects map[string]fakeObject
+}
+
+type fakeObject struct {
+	data        []byte
+	contentType string
+}
+
+func newFakeS3(t *testing.T) (*fakeS3, *S3BlobStore) {
+	t.Helper()
+	fake := &fakeS3{t: t, bucket: "uploads", objects: make(map[string]fakeObject)}
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+
+	store, err := NewS3BlobStore(S3Config{
+		Endpoint:        server.URL,
+		Region:          exampleRegion,
+		Bucket:          fake.bucket,
+		AccessKeyID:     exampleAccessKeyID,
+		SecretAccessKey: exampleSecretAccessKey,
+		PathStyle:       true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return fake, store
+}
+
+func (f *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+
+	if !f.validSignature(r, body) {
+		w.WriteHeader(http.StatusForbidden)
+		io.WriteString(w, `<?xml version="1.0" encoding="UTF-8"?><Error><Code>SignatureDoesNotMatch</Code><Message>The request signature we calculated does not match the signature you provided.</Message></Error>`)
+		return
+	}
+
+	prefix := "/" + f.bucket + "/"
+	if !strings.HasPrefix(r.URL.Path, prefix) {
+		w.WriteHeader(http.StatusNotFound)
+		io.WriteString(w, `<Error><Code>NoSuchBucket</Code><Message>The specified bucket does not exist</Message></Error>`)
+		return
+	}
+	key := strings.TrimPrefix(r.URL.Path, prefix)
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	switch r.Method {
+	case http.MethodPut:
+		f.objects[key] = fakeObject{data: body, contentType: r.Header.Get("Content-Type")}
+		w.WriteHeader(http.StatusOK)
+	case http.MethodGet:
+		object, ok := f.objects[key]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			io.WriteString(w, `<Error><Code>NoSuchKey</Code><Message>The specified key does not exist.</Message></Error>`)
+			return
+		}
+		w.Header().Set("Content-Type", object.contentType)
+		w.Write(object.data)
+	case http.MethodDelete:
+		delete(f.objects, key)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+// validSignature vuelve a firmar la petición tal como llegó al servidor y compara el resultado.
+// Detecta headers firmados que se pierden o cambian en el camino y cuerpos que no coinciden con su hash.
+func (f *fakeS3) validSignature(r *http.Request, body []byte) bool {
+	payloadHash := r.Header.Get("X-Amz-Content-Sha256")
+	if payloadHash != hashHex(body) {
+		return false
+	}
+	signedAt, err := time.Parse("20060102T150405Z", r.Header.Get("X-Amz-Date"))
+	if err != nil {
+		return false
+	}
+
+	check, err := http.NewRequest(r.Method, "http://"+r.Host+r.URL.RequestURI(), nil)
+	if err != nil {
+		f.t.Fatal(err)
+	}
+	for name, values := range r.Header {
+		lower := strings.ToLower(name)
+		if lower == "content-type" || lower == "range" || (strings.HasPrefix(lower, "x-amz-") && lower != "x-amz-date" && lower != "x-amz-content-sha256") {
+			check.Header[name] = values
+		}
+	}
+	signV4(check, payloadHash, exampleAccessKeyID, exampleSecretAccessKey, exampleRegion, signedAt)
+
+	return check.Header.Get("Authorization") == r.Header.Get("Authorization")
+}
+
+func TestS3BlobStoreRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	fake, store := newFakeS3(t)
+	key := "services/12/3f9a.jpg"
+
+	if err := store.Put(ctx, key, []byte("jpeg data"), "image/jpeg"); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+	if got := string(fake.objects[key].data); got != "jpeg data" {
+		t.Fatalf("stored %q, want %q", got, "jpeg data")
+	}
+
+	object, err := store.Get(ctx, key)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	data, _ := io.ReadAll(object.Body)
+	object.Body.Close()
+	if string(data) != "jpeg data" || object.ContentType != "image/jpeg" || object.Size != int64(len(data)) {
+		t.Errorf("Get = (%q, %s, %d), want (%q, image/jpeg, %d)", data, object.ContentType, object.Size, "jpeg data", len(data))
+	}
+
+	if err := store.Delete(ctx, key); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if _, err := store.Get(ctx, key); err != ErrNotFound {
+		t.Errorf("Get after Delete: got %v, want ErrNotFound", err)
+	}
+
+	// Borrar una clave que no existe no es un error
+	if err := store.Delete(ctx, key); err != nil {
+		t.Errorf("Delete of a missing key: %v", err)
+	}
+}
+
+func TestS3BlobStoreErrors(t *testing.T) {
+	ctx := context.Background()
+	_, store := newFakeS3(t)
+
+	if _, err := store.Get(ctx, "services/1/missing.jpg"); err != ErrNotFound {
+		t.Errorf("Get of a missing key: got %v, want ErrNotFound", err)
+	}
+
+	// Con otro secreto la firma no coincide y el error incluye el código de S3
+	store.config.SecretAccessKey = "wrong-secret"
+	err := store.Put(ctx, "services/1/a.jpg", []byte("data"), "image/jpeg")
+	if err == nil || !strings.Contains(err.Error(), "SignatureDoesNotMatch") || !strings.Contains(err.Error(), "status 403") {
+		t.Errorf("Put with a wrong secret: got %v, want SignatureDoesNotMatch (status 403)", err)
+	}
+
+	// Las claves inválidas se rechazan sin llegar al servidor
+	for _, key := range []string{"../x", "/abs.jpg", "Services/1.jpg"} {
+		if err := store.Put(ctx, key, []byte("data"), "image/jpeg"); err == nil {
+			t.Errorf("Put(%q) succeeded, want an invalid key error", key)
+		}
+		if _, err := store.Get(ctx, key); err != ErrNotFound {
+			t.Errorf("Get(%q): got %v, want ErrNotFound", key, err)
+		}
+	}
+}
+
+func TestNewS3BlobStoreConfig(t *testing.T) {
+	valid := S3Config{Bucket: "uploads", AccessKeyID: "key", SecretAccessKey: "secret"}
+
+	store, err := NewS3BlobStore(valid)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if store.config.Region != "us-east-1" || store.endpoint.String() != "https://s3.us-east-1.amazonaws.com" {
+		t.Errorf("defaults = region %s endpoint %s", store.config.Region, store.endpoint)
+	}
+
+	// Sin estilo de ruta el bucket va en el host
+	req, err := store.newRequest(context.Background(), http.MethodGet, "services/1/a.jpg", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := req.URL.String(); got != "https://uploads.s3.us-east-1.amazonaws.com/services/1/a.jpg" {
+		t.Errorf("virtual-hosted URL = %s", got)
+	}
+
+	invalid := []S3Config{
+		{AccessKeyID: "key", SecretAccessKey: "secret"},
+		{Bucket: "uploads", SecretAccessKey: "secret"},
+		{Bucket: "uploads", AccessKeyID: "key"},
+		{Bucket: "uploads", AccessKeyID: "key", SecretAccessKey: "secret", Endpoint: "ftp://minio:9000"},
+		{Bucket: "uploads", AccessKeyID: "key", SecretAccessKey: "secret", Endpoint: "minio:9000"},
+	}
+	for _, config := range invalid {
+		if _, err := NewS3BlobStore(config); err == nil {
+			t.Errorf("NewS3BlobStore(%+v) succeeded, want an error", config)
+		}
+	}
+}
@@ -1,36 +1,103 @@
 package utils
 
 import (
-	"os"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
 )
 
-var JwtSecret = []byte(os.Getenv("JWT_SECRET"))
+const (
+	// AccessTokenTTL es la vida de un access token; al vencer se pide otro con el refresh token
+	AccessTokenTTL = 15 * time.Minute
+	// RefreshTokenTTL es la vida de un refresh token; cada rotación entrega uno nuevo con la vida completa
+	RefreshTokenTTL = 30 * 24 * time.Hour
+)
 
 type Claims struct {
 	ID          int64    `json:"id"`
+	SessionID   int64    `json:"sid"` // sesión a la que pertenece el token; si se revoca, el token deja de valer
 	Role        string   `json:"role"`
 	Permissions []string `json:"permissions"`
 	FirstLogin  bool     `json:"first_login"`
+	// EmailVerified le indica al cliente si falta confirmar el email; los casos de uso lo verifican en la base
+	EmailVerified bool `json:"email_verified"`
 	jwt.RegisteredClaims
 }
 
-// GenerateJWT crea un token JWT para el usuario.
-func GenerateJWT(id int64, role string, permissions []string, firstLogin bool) (string, error) {
-	expirationTime := time.Now().Add(24 * time.Hour)
+// GenerateJWT crea un access token JWT para el usuario dentro de una sesión, firmado con la clave activa.
+func (k *KeySet) GenerateJWT(id int64, sessionID int64, role string, permissions []string, firstLogin bool, emailVerified bool) (string, error) {
+	now := time.Now()
 	claims := &Claims{
 		ID:          id,
+		SessionID:   sessionID,
 		Role:        role,
 		Permissions: permissions,
 		FirstLogin:  firstLogin,
+		// Se actualiza en el próximo refresh después de verificar el email
+		EmailVerified: emailVerified,
 		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(expirationTime),
-			IssuedAt:  jwt.NewNumericDate(time.Now()),
+			Issuer:    k.issuer,
+			Subject:   fmt.Sprintf("%d", id),
+			ExpiresAt: jwt.NewNumericDate(now.Add(AccessTokenTTL)),
+			IssuedAt:  jwt.NewNumericDate(now),
 		},
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString(JwtSecret)
+	token := jwt.NewWithClaims(k.active.method(), claims)
+	// El kid indica con qué clave verificar; permite rotar claves sin invalidar los tokens vigentes
+	token.Header["kid"] = k.active.ID
+	return token.SignedString(k.active.private)
+}
+
+// ParseJWT valida la firma, el emisor y el vencimiento de un access token y devuelve sus claims
+func (k *KeySet) ParseJWT(tokenString string) (*Claims, error) {
+	claims := &Claims{}
+
+	token, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
+		kid, _ := token.Header["kid"].(string)
+		key, ok := k.keys[kid]
+		if !ok {
+			return nil, fmt.Errorf("unknown signing key %q", kid)
+		}
+		// El algoritmo lo fija la clave, nunca el token: evita ataques de confusión de algoritmo
+		if token.Method.Alg() != key.Algorithm {
+			return nil, fmt.Errorf("unexpected signing method %s for key %q", token.Method.Alg(), kid)
+		}
+		return key.public, nil
+	},
+		jwt.WithValidMethods([]string{AlgorithmRS256, AlgorithmEdDSA}),
+		jwt.WithIssuer(k.issuer),
+		jwt.WithExpirationRequired(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	if !token.Valid {
+		return nil, fmt.Errorf("invalid token")
+	}
+
+	return claims, nil
+}
+
+// GenerateRefreshToken crea un refresh token aleatorio; devuelve el token para el cliente
+// y su hash, que es lo único que se guarda en la base de datos
+func GenerateRefreshToken() (string, string, error) {
+	tokenBytes := make([]byte, 32)
+	if _, err := rand.Read(tokenBytes); err != nil {
+		return "", "", err
+	}
+
+	token := base64.RawURLEncoding.EncodeToString(tokenBytes)
+	return token, HashToken(token), nil
+}
+
+// HashToken devuelve el hash SHA-256 (hex) de un token opaco
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
 }
@@ -0,0 +1,246 @@
+package utils
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// Algoritmos de firma soportados para los access tokens
+const (
+	AlgorithmRS256 = "RS256"
+	AlgorithmEdDSA = "EdDSA"
+)
+
+// minRSAKeyBits es el tamaño mínimo aceptado para claves RSA
+const minRSAKeyBits = 2048
+
+// SigningKey es una clave del conjunto: con clave privada sirve para firmar,
+// y con la pública alcanza para verificar tokens firmados antes de una rotación
+type SigningKey struct {
+	ID        string // kid
+	Algorithm string // RS256 o EdDSA
+	private   crypto.Signer
+	public    crypto.PublicKey
+}
+
+// CanSign indica si la clave tiene parte privada
+func (k *SigningKey) CanSign() bool {
+	return k.private != nil
+}
+
+func (k *SigningKey) method() jwt.SigningMethod {
+	if k.Algorithm == AlgorithmEdDSA {
+		return jwt.SigningMethodEdDSA
+	}
+	return jwt.SigningMethodRS256
+}
+
+// KeySet contiene la clave activa con la que se firman los tokens nuevos y todas las claves
+// (activa y anteriores) con las que se aceptan tokens
+type KeySet struct {
+	issuer string
+	active *SigningKey
+	keys   map[string]*SigningKey
+}
+
+// NewKeySet arma el conjunto de claves; activeID debe corresponder a una clave con parte privada
+func NewKeySet(issuer string, activeID string, keys []*SigningKey) (*KeySet, error) {
+	set := &KeySet{issuer: issuer, keys: make(map[string]*SigningKey)}
+	for _, key := range keys {
+		if _, exists := set.keys[key.ID]; exists {
+			return nil, fmt.Errorf("duplicate JWT key id %q", key.ID)
+		}
+		set.keys[key.ID] = key
+	}
+
+	active, ok := set.keys[activeID]
+	if !ok {
+		return nil, fmt.Errorf("active JWT key %q not found", activeID)
+	}
+	if !active.CanSign() {
+		return nil, fmt.Errorf("active JWT key %q has no private key", activeID)
+	}
+	set.active = active
+
+	return set, nil
+}
+
+// ActiveKeyID devuelve el kid de la clave con la que se firma
+func (k *KeySet) ActiveKeyID() string {
+	return k.active.ID
+}
+
+// ParseSigningKeyPEM lee una clave privada (PKCS#8 o PKCS#1) o pública (PKIX) RSA o Ed25519.
+// Si id está vacío se usa el thumbprint RFC 7638 de la clave.
+func ParseSigningKeyPEM(id string, content []byte) (*SigningKey, error) {
+	block, _ := pem.Decode(content)
+	if block == nil {
+		return nil, fmt.Errorf("no PEM block found")
+	}
+
+	key := &SigningKey{ID: id}
+	switch block.Type {
+	case "PRIVATE KEY":
+		parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		signer, ok := parsed.(crypto.Signer)
+		if !ok {
+			return nil, fmt.Errorf("unsupported private key type %T", parsed)
+		}
+		key.private = signer
+		key.public = signer.Public()
+	case "RSA PRIVATE KEY":
+		parsed, err := x509.ParsePKCS1PrivateKey(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		key.private = parsed
+		key.public = parsed.Public()
+	case "PUBLIC KEY":
+		parsed, err := x509.ParsePKIXPublicKey(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		key.public = parsed
+	default:
+		return nil, fmt.Errorf("unsupported PEM block %q", block.Type)
+	}
+
+	switch public := key.public.(type) {
+	case *rsa.PublicKey:
+		if public.N.BitLen() < minRSAKeyBits {
+			return nil, fmt.Errorf("RSA key must have at least %d bits", minRSAKeyBits)
+		}
+		key.Algorithm = AlgorithmRS256
+	case ed25519.PublicKey:
+		key.Algorithm = AlgorithmEdDSA
+	default:
+		return nil, fmt.Errorf("unsupported key type %T (use RSA or Ed25519)", key.public)
+	}
+
+	if key.ID == "" {
+		key.ID = key.Thumbprint()
+	}
+	return key, nil
+}
+
+// GenerateSigningKey crea una clave nueva (RS256 de 3072 bits o EdDSA) y la devuelve junto con su PEM PKCS#8
+func GenerateSigningKey(algorithm string) (*SigningKey, []byte, error) {
+	var private crypto.Signer
+	switch algorithm {
+	case AlgorithmRS256:
+		rsaKey, err := rsa.GenerateKey(rand.Reader, 3072)
+		if err != nil {
+			return nil, nil, err
+		}
+		private = rsaKey
+	case AlgorithmEdDSA:
+		_, edKey, err := ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			return nil, nil, err
+		}
+		private = edKey
+	default:
+		return nil, nil, fmt.Errorf("unsupported algorithm %q (use %s or %s)", algorithm, AlgorithmRS256, AlgorithmEdDSA)
+	}
+
+	der, err := x509.MarshalPKCS8PrivateKey(private)
+	if err != nil {
+		return nil, nil, err
+	}
+	content := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})
+
+	key, err := ParseSigningKeyPEM("", content)
+	if err != nil {
+		return nil, nil, err
+	}
+	return key, content, nil
+}
+
+// JWK es la representación pública de una clave según RFC 7517
+type JWK struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	N   string `json:"n,omitempty"`   // RSA: módulo
+	E   string `json:"e,omitempty"`   // RSA: exponente
+	Crv string `json:"crv,omitempty"` // OKP: curva
+	X   string `json:"x,omitempty"`   // OKP: clave pública
+}
+
+// JWKSet es el documento publicado en /.well-known/jwks.json
+type JWKSet struct {
+	Keys []JWK `json:"keys"`
+}
+
+// JWK devuelve la parte pública de la clave
+func (k *SigningKey) JWK() JWK {
+	jwk := JWK{Kid: k.ID, Use: "sig", Alg: k.Algorithm}
+	switch public := k.public.(type) {
+	case *rsa.PublicKey:
+		jwk.Kty = "RSA"
+		jwk.N = base64.RawURLEncoding.EncodeToString(public.N.Bytes())
+		jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(public.E)).Bytes())
+	case ed25519.PublicKey:
+		jwk.Kty = "OKP"
+		jwk.Crv = "Ed25519"
+		jwk.X = base64.RawURLEncoding.EncodeToString(public)
+	}
+	return jwk
+}
+
+// Thumbprint calcula el thumbprint RFC 7638 de la clave pública (SHA-256, base64url)
+func (k *SigningKey) Thumbprint() string {
+	jwk := k.JWK()
+
+	// Solo los miembros requeridos, en orden lexicográfico y sin espacios
+	var canonical []byte
+	if jwk.Kty == "RSA" {
+		canonical, _ = json.Marshal(struct {
+			E   string `json:"e"`
+			Kty string `json:"kty"`
+			N   string `json:"n"`
+		}{jwk.E, jwk.Kty, jwk.N})
+	} else {
+		canonical, _ = json.Marshal(struct {
+			Crv string `json:"crv"`
+			Kty string `json:"kty"`
+			X   string `json:"x"`
+		}{jwk.Crv, jwk.Kty, jwk.X})
+	}
+
+	sum := sha256.Sum256(canonical)
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+// JWKS devuelve las claves públicas de verificación, con la clave activa primero
+func (k *KeySet) JWKS() JWKSet {
+	ids := make([]string, 0, len(k.keys))
+	for id := range k.keys {
+		if id != k.active.ID {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+
+	set := JWKSet{Keys: []JWK{k.active.JWK()}}
+	for _, id := range ids {
+		set.Keys = append(set.Keys, k.keys[id].JWK())
+	}
+	return set
+}
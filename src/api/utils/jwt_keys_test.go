@@ -0,0 +1,250 @@
+package utils
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// testSigningKey arma una clave a partir de una clave privada recién generada, pasando por PEM
+// igual que las que se leen de JWT_KEYS_DIR (RSA de 2048 bits para que los tests sean rápidos)
+func testSigningKey(t *testing.T, algorithm string) (*SigningKey, interface{}) {
+	t.Helper()
+
+	var private interface{}
+	switch algorithm {
+	case AlgorithmRS256:
+		rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+		if err != nil {
+			t.Fatal(err)
+		}
+		private = rsaKey
+	case AlgorithmEdDSA:
+		_, edKey, err := ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		private = edKey
+	}
+
+	der, err := x509.MarshalPKCS8PrivateKey(private)
+	if err != nil {
+		t.Fatal(err)
+	}
+	key, err := ParseSigningKeyPEM("", pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key, private
+}
+
+func testKeySet(t *testing.T, active *SigningKey, others ...*SigningKey) *KeySet {
+	t.Helper()
+	keys, err := NewKeySet("iycds2025-test", active.ID, append([]*SigningKey{active}, others...))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return keys
+}
+
+// signTestToken firma claims válidos con el método, kid y clave indicados, sin pasar por el KeySet
+func signTestToken(t *testing.T, method jwt.SigningMethod, kid string, key interface{}) string {
+	t.Helper()
+	now := time.Now()
+	token := jwt.NewWithClaims(method, &Claims{
+		ID: 1,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    "iycds2025-test",
+			ExpiresAt: jwt.NewNumericDate(now.Add(time.Minute)),
+			IssuedAt:  jwt.NewNumericDate(now),
+		},
+	})
+	token.Header["kid"] = kid
+	signed, err := token.SignedString(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return signed
+}
+
+func TestKeySetRoundTrip(t *testing.T) {
+	for _, algorithm := range []string{AlgorithmRS256, AlgorithmEdDSA} {
+		t.Run(algorithm, func(t *testing.T) {
+			key, _ := testSigningKey(t, algorithm)
+			keys := testKeySet(t, key)
+
+			token, err := keys.GenerateJWT(42, 7, "provider", []string{"services:create"}, false, true)
+			if err != nil {
+				t.Fatalf("GenerateJWT: %v", err)
+			}
+
+			parsed, _, err := jwt.NewParser().ParseUnverified(token, &Claims{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if parsed.Header["alg"] != algorithm || parsed.Header["kid"] != key.ID {
+				t.Errorf("header = %v, want alg %s and kid %s", parsed.Header, algorithm, key.ID)
+			}
+
+			claims, err := keys.ParseJWT(token)
+			if err != nil {
+				t.Fatalf("ParseJWT: %v", err)
+			}
+			if claims.ID != 42 || claims.SessionID != 7 || claims.Role != "provider" || !claims.EmailVerified {
+				t.Errorf("unexpected claims %+v", claims)
+			}
+		})
+	}
+}
+
+func TestKeySetAcceptsPreviousKey(t *testing.T) {
+	previous, _ := testSigningKey(t, AlgorithmRS256)
+	current, _ := testSigningKey(t, AlgorithmEdDSA)
+
+	token, err := testKeySet(t, previous).GenerateJWT(1, 1, "client", nil, false, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Después de rotar, los tokens firmados con la clave anterior siguen valiendo mientras esté en el conjunto
+	if _, err := testKeySet(t, current, previous).ParseJWT(token); err != nil {
+		t.Errorf("token signed with the previous key was rejected: %v", err)
+	}
+	if _, err := testKeySet(t, current).ParseJWT(token); err == nil {
+		t.Error("token signed with a removed key was accepted")
+	}
+}
+
+func TestKeySetRejects(t *testing.T) {
+	rsaKey, rsaPrivate := testSigningKey(t, AlgorithmRS256)
+	edKey, edPrivate := testSigningKey(t, AlgorithmEdDSA)
+	keys := testKeySet(t, rsaKey)
+
+	// Con la clave pública RSA como secreto HMAC se arma el ataque clásico de confusión de algoritmo
+	rsaPublicDER, err := x509.MarshalPKIXPublicKey(&rsaPrivate.(*rsa.PrivateKey).PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rsaPublicPEM := pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: rsaPublicDER})
+
+	tests := []struct {
+		name  string
+		token string
+	}{
+		{"unknown kid", signTestToken(t, jwt.SigningMethodEdDSA, edKey.ID, edPrivate)},
+		{"missing kid", signTestToken(t, jwt.SigningMethodRS256, "", rsaPrivate)},
+		{"EdDSA with RS256 kid", signTestToken(t, jwt.SigningMethodEdDSA, rsaKey.ID, edPrivate)},
+		{"HS256 with RS256 kid", signTestToken(t, jwt.SigningMethodHS256, rsaKey.ID, rsaPublicPEM)},
+		{"HS256 with RS256 kid and DER secret", signTestToken(t, jwt.SigningMethodHS256, rsaKey.ID, rsaPublicDER)},
+		{"tampered payload", tamperPayload(t, signTestToken(t, jwt.SigningMethodRS256, rsaKey.ID, rsaPrivate))},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if claims, err := keys.ParseJWT(tt.token); err == nil {
+				t.Fatalf("token accepted with claims %+v", claims)
+			}
+		})
+	}
+}
+
+// tamperPayload reemplaza el payload del token por otro con un ID de usuario distinto
+func tamperPayload(t *testing.T, token string) string {
+	t.Helper()
+	parts := strings.Split(token, ".")
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		t.Fatal(err)
+	}
+	payload = []byte(strings.Replace(string(payload), `"id":1`, `"id":2`, 1))
+	parts[1] = base64.RawURLEncoding.EncodeToString(payload)
+	return strings.Join(parts, ".")
+}
+
+func TestThumbprintRFCVectors(t *testing.T) {
+	// RFC 7638, sección 3.1
+	n, err := base64.RawURLEncoding.DecodeString("0vx7agoebGcQSuuPiLJXZptN9nndrQmbXEps2aiAFbWhM78LhWx4cbbfAAtVT86zwu1RK7aPFFxuhDR1L6tSoc_BJECPebWKRXjBZCiFV4n3oknjhMstn64tZ_2W-5JsGY4Hc5n9yBXArwl93lqt7_RN5w6Cf0h4QyQ5v-65YGjQR0_FDW2QvzqY368QQMicAtaSqzs8KJZgnYb9c7d0zgdAZHzu6qMQvRL5hajrn1n91CbOpbISD08qNLyrdkt-bFTWhAI4vMQFh6WeZu0fM4lFd2NcRwr3XPksINHaQ-G_xBniIqbw0Ls1jF44-csFCur-kEgU8awapJzKnqDKgw")
+	if err != nil {
+		t.Fatal(err)
+	}
+	rsaKey := &SigningKey{Algorithm: AlgorithmRS256, public: &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: 65537}}
+
+	// RFC 8037, apéndice A.3
+	x, err := base64.RawURLEncoding.DecodeString("11qYAYKxCrfVS_7TyWQHOg7hcvPapiMlrwIaaPcHURo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	edKey := &SigningKey{Algorithm: AlgorithmEdDSA, public: ed25519.PublicKey(x)}
+
+	tests := []struct {
+		name string
+		key  *SigningKey
+		want string
+	}{
+		{"RFC 7638 RSA", rsaKey, "NzbLsXh8uDCcd-6MNwXF4W_7noWXFZAfHkxZsRGC9Xs"},
+		{"RFC 8037 Ed25519", edKey, "kPrK_qmxVWaYVA9wwBF6Iuo3vVzz7TxHCTwXBygrS4k"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.key.Thumbprint(); got != tt.want {
+			t.Errorf("%s: Thumbprint() = %s, want %s", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestJWKSMatchesThumbprint(t *testing.T) {
+	active, _ := testSigningKey(t, AlgorithmEdDSA)
+	previous, _ := testSigningKey(t, AlgorithmRS256)
+	keys := testKeySet(t, active, previous)
+
+	// Se valida sobre el JSON publicado, como lo haría un cliente
+	content, err := json.Marshal(keys.JWKS())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var published struct {
+		Keys []map[string]string `json:"keys"`
+	}
+	if err := json.Unmarshal(content, &published); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(published.Keys) != 2 || published.Keys[0]["kid"] != active.ID {
+		t.Fatalf("JWKS = %s, want the active key first and one previous key", content)
+	}
+
+	for _, jwk := range published.Keys {
+		if jwk["use"] != "sig" {
+			t.Errorf("kid %s: use = %q, want sig", jwk["kid"], jwk["use"])
+		}
+		if _, ok := jwk["d"]; ok {
+			t.Errorf("kid %s: private key material published", jwk["kid"])
+		}
+
+		// Miembros requeridos por RFC 7638, en orden lexicográfico y sin espacios
+		var canonical string
+		switch jwk["kty"] {
+		case "RSA":
+			canonical = `{"e":"` + jwk["e"] + `","kty":"RSA","n":"` + jwk["n"] + `"}`
+		case "OKP":
+			canonical = `{"crv":"` + jwk["crv"] + `","kty":"OKP","x":"` + jwk["x"] + `"}`
+		default:
+			t.Fatalf("unexpected kty %q", jwk["kty"])
+		}
+		sum := sha256.Sum256([]byte(canonical))
+		if want := base64.RawURLEncoding.EncodeToString(sum[:]); jwk["kid"] != want {
+			t.Errorf("kid %s does not match the RFC 7638 thumbprint %s", jwk["kid"], want)
+		}
+	}
+}
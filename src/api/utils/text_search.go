@@ -0,0 +1,167 @@
+package utils
+
+import (
+	"encoding/json"
+	"html"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"iycds2025_api/src/api/core/entities"
+)
+
+// MinTokenLength es el largo mínimo de un término indexable; coincide con
+// innodb_ft_min_token_size de MySQL para que todas las implementaciones indexen lo mismo
+const MinTokenLength = 3
+
+// accentFolder reemplaza las letras acentuadas del español (y del portugués) por su letra base
+var accentFolder = strings.NewReplacer(
+	"á", "a", "à", "a", "â", "a", "ã", "a", "ä", "a",
+	"é", "e", "è", "e", "ê", "e", "ë", "e",
+	"í", "i", "ì", "i", "î", "i", "ï", "i",
+	"ó", "o", "ò", "o", "ô", "o", "õ", "o", "ö", "o",
+	"ú", "u", "ù", "u", "û", "u", "ü", "u",
+	"ñ", "n", "ç", "c",
+)
+
+// spanishStopwords son palabras demasiado frecuentes para aportar relevancia a una búsqueda
+var spanishStopwords = map[string]bool{
+	"al": true, "ante": true, "con": true, "contra": true, "como": true, "cual": true,
+	"del": true, "desde": true, "donde": true, "durante": true, "el": true, "ella": true,
+	"ellas": true, "ellos": true, "entre": true, "era": true, "esa": true, "ese": true,
+	"eso": true, "esta": true, "este": true, "esto": true, "hacia": true, "hasta": true,
+	"las": true, "les": true, "los": true, "mas": true, "mis": true, "muy": true,
+	"nos": true, "nuestro": true, "otra": true, "otro": true, "para": true, "pero": true,
+	"por": true, "que": true, "quien": true, "sin": true, "sobre": true, "son": true,
+	"sus": true, "tambien": true, "todo": true, "todos": true, "una": true, "uno": true,
+	"unos": true, "unas": true, "usted": true, "ustedes": true,
+}
+
+// FoldAccents pasa el texto a minúsculas y quita los acentos ("Plomería" -> "plomeria")
+func FoldAccents(text string) string {
+	return accentFolder.Replace(strings.ToLower(text))
+}
+
+// Tokenize separa un texto en términos de búsqueda: minúsculas, sin acentos, sin
+// signos de puntuación, sin stopwords y sin términos más cortos que MinTokenLength
+func Tokenize(text string) []string {
+	words := strings.FieldsFunc(FoldAccents(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	tokens := make([]string, 0, len(words))
+	for _, word := range words {
+		if utf8.RuneCountInString(word) < MinTokenLength || spanishStopwords[word] {
+			continue
+		}
+		tokens = append(tokens, word)
+	}
+	return tokens
+}
+
+// UniqueTokens devuelve los términos de un texto sin repetidos, en orden de aparición
+func UniqueTokens(text string) []string {
+	seen := make(map[string]bool)
+	var tokens []string
+	for _, token := range Tokenize(text) {
+		if !seen[token] {
+			seen[token] = true
+			tokens = append(tokens, token)
+		}
+	}
+	return tokens
+}
+
+// SearchDocument devuelve los términos indexables de un servicio: los del título por un
+// lado y los de la descripción, la categoría y las zonas de cobertura por otro
+func SearchDocument(service *entities.Service) ([]string, []string) {
+	bodyParts := []string{service.Description, service.Category}
+
+	var zones []entities.Zone
+	if err := json.Unmarshal([]byte(service.Zones), &zones); err == nil {
+		for _, zone := range zones {
+			bodyParts = append(bodyParts, zone.Province, zone.Locality, zone.Neighborhood)
+		}
+	}
+
+	return Tokenize(service.Title), Tokenize(strings.Join(bodyParts, " "))
+}
+
+// MatchesTerm indica si un término indexado coincide con un término buscado; se admite
+// coincidencia por prefijo para que "plom" encuentre "plomero"
+func MatchesTerm(token, term string) bool {
+	return strings.HasPrefix(token, term)
+}
+
+// HighlightSnippet devuelve un fragmento de texto HTML-escapado alrededor de la primera
+// coincidencia, con cada término encontrado envuelto en <mark>...</mark>.
+// Si no hay coincidencias devuelve el comienzo del texto.
+func HighlightSnippet(text string, terms []string, maxRunes int) string {
+	runes := []rune(text)
+
+	// Ubicar cada palabra del texto original para poder resaltarla sin perder los acentos
+	type wordSpan struct{ start, end int }
+	var matches []wordSpan
+	for i := 0; i < len(runes); {
+		if !unicode.IsLetter(runes[i]) && !unicode.IsDigit(runes[i]) {
+			i++
+			continue
+		}
+		start := i
+		for i < len(runes) && (unicode.IsLetter(runes[i]) || unicode.IsDigit(runes[i])) {
+			i++
+		}
+		word := FoldAccents(string(runes[start:i]))
+		for _, term := range terms {
+			if MatchesTerm(word, term) {
+				matches = append(matches, wordSpan{start, i})
+				break
+			}
+		}
+	}
+
+	// Elegir la ventana del fragmento: centrada cerca de la primera coincidencia
+	from, to := 0, len(runes)
+	if maxRunes > 0 && len(runes) > maxRunes {
+		if len(matches) > 0 {
+			from = matches[0].start - maxRunes/4
+			if from < 0 {
+				from = 0
+			}
+		}
+		to = from + maxRunes
+		if to > len(runes) {
+			to = len(runes)
+			from = to - maxRunes
+		}
+		// No cortar palabras al principio ni al final
+		for from > 0 && from < to && !unicode.IsSpace(runes[from-1]) {
+			from++
+		}
+		for to < len(runes) && to > from && !unicode.IsSpace(runes[to]) {
+			to--
+		}
+	}
+
+	var builder strings.Builder
+	if from > 0 {
+		builder.WriteString("…")
+	}
+	position := from
+	for _, match := range matches {
+		if match.start < from || match.end > to {
+			continue
+		}
+		builder.WriteString(html.EscapeString(string(runes[position:match.start])))
+		builder.WriteString("<mark>")
+		builder.WriteString(html.EscapeString(string(runes[match.start:match.end])))
+		builder.WriteString("</mark>")
+		position = match.end
+	}
+	builder.WriteString(html.EscapeString(string(runes[position:to])))
+	if to < len(runes) {
+		builder.WriteString("…")
+	}
+
+	return strings.TrimSpace(builder.String())
+}
@@ -0,0 +1,124 @@
+package utils
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFoldAccents(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"Plomería", "plomeria"},
+		{"ELECTRICIDAD", "electricidad"},
+		{"Niñera Güemes", "ninera guemes"},
+		{"Ação Çedilha", "acao cedilha"},
+		{"sin acentos", "sin acentos"},
+	}
+
+	for _, tt := range tests {
+		if got := FoldAccents(tt.input); got != tt.want {
+			t.Errorf("FoldAccents(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestTokenize(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  []string
+	}{
+		{"acentos y mayúsculas", "Plomería URGENTE", []string{"plomeria", "urgente"}},
+		{"puntuación", "gas, agua/luz; 24hs!", []string{"gas", "agua", "luz", "24hs"}},
+		{"stopwords", "clases para todos los niveles", []string{"clases", "niveles"}},
+		{"términos cortos", "TV de 50 en casa", []string{"casa"}},
+		{"vacío", "   ", []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Tokenize(tt.input); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Tokenize(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUniqueTokens(t *testing.T) {
+	got := UniqueTokens("plomero Plomero plomería plomero")
+	want := []string{"plomero", "plomeria"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("UniqueTokens = %q, want %q", got, want)
+	}
+}
+
+func TestMatchesTerm(t *testing.T) {
+	tests := []struct {
+		token string
+		term  string
+		want  bool
+	}{
+		{"plomero", "plom", true},
+		{"plomero", "plomero", true},
+		{"plomero", "plomeros", false},
+		{"desplome", "plom", false},
+	}
+
+	for _, tt := range tests {
+		if got := MatchesTerm(tt.token, tt.term); got != tt.want {
+			t.Errorf("MatchesTerm(%q, %q) = %v, want %v", tt.token, tt.term, got, tt.want)
+		}
+	}
+}
+
+func TestHighlightSnippet(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		terms    []string
+		maxRunes int
+		want     string
+	}{
+		{
+			name:  "conserva los acentos del texto original",
+			text:  "Plomería y gasista matriculado",
+			terms: []string{"plomeria"},
+			want:  "<mark>Plomería</mark> y gasista matriculado",
+		},
+		{
+			name:  "prefijo",
+			text:  "Electricista matriculado",
+			terms: []string{"elec", "matri"},
+			want:  "<mark>Electricista</mark> <mark>matriculado</mark>",
+		},
+		{
+			name:  "escapa el HTML del texto",
+			text:  "Arreglos <b>urgentes</b> & baratos",
+			terms: []string{"urgentes"},
+			want:  "Arreglos &lt;b&gt;<mark>urgentes</mark>&lt;/b&gt; &amp; baratos",
+		},
+		{
+			name:  "sin coincidencias devuelve el comienzo",
+			text:  "Clases de guitarra para principiantes",
+			terms: []string{"piano"},
+			want:  "Clases de guitarra para principiantes",
+		},
+		{
+			name:     "recorta alrededor de la coincidencia sin cortar palabras",
+			text:     "uno dos tres cuatro cinco seis siete ocho nueve diez once doce trece",
+			terms:    []string{"ocho"},
+			maxRunes: 20,
+			want:     "…<mark>ocho</mark> nueve diez…",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := HighlightSnippet(tt.text, tt.terms, tt.maxRunes); got != tt.want {
+				t.Errorf("HighlightSnippet = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -3,19 +3,37 @@ package utils
 import (
 	"encoding/json"
 	"fmt"
+	"sort"
 	"strings"
 	"time"
+
+	"iycds2025_api/src/api/core/entities"
 )
 
-// DayAvailability representa la disponibilidad de un día
-type DayAvailability struct {
-	Available bool   `json:"available"`
-	Start     string `json:"start"`
-	End       string `json:"end"`
-}
+const (
+	// DefaultSlotDuration es la duración de turno usada cuando el servicio no define una
+	DefaultSlotDuration = 30
+	// MinSlotDuration y MaxSlotDuration limitan la duración de un turno en minutos
+	MinSlotDuration = 15
+	MaxSlotDuration = 480
+	// MaxBufferMinutes limita los minutos libres entre turnos
+	MaxBufferMinutes = 120
+	// MaxRangesPerDay limita la cantidad de rangos horarios por día
+	MaxRangesPerDay = 6
+)
+
+const (
+	// ExceptionClosed marca fechas en las que el servicio no atiende
+	ExceptionClosed = "closed"
+	// ExceptionCustomHours marca fechas con horarios que reemplazan a los semanales
+	ExceptionCustomHours = "custom_hours"
+)
 
-// GenerateTimeSlots genera slots de 30 minutos entre start y end
-func GenerateTimeSlots(startTime, endTime string) ([]string, error) {
+var weekDays = []string{"monday", "tuesday", "wednesday", "thursday", "friday", "saturday", "sunday"}
+
+// GenerateTimeSlots genera turnos de slotDuration minutos entre start y end,
+// dejando bufferMinutes libres entre un turno y el siguiente
+func GenerateTimeSlots(startTime, endTime string, slotDuration, bufferMinutes int) ([]string, error) {
 	start, err := time.Parse("15:04", startTime)
 	if err != nil {
 		return nil, fmt.Errorf("invalid start time format: %s", startTime)
@@ -30,72 +48,229 @@ func GenerateTimeSlots(startTime, endTime string) ([]string, error) {
 		return nil, fmt.Errorf("start time must be before end time")
 	}
 
+	if slotDuration <= 0 {
+		return nil, fmt.Errorf("slot duration must be positive")
+	}
+
+	duration := time.Duration(slotDuration) * time.Minute
+	buffer := time.Duration(bufferMinutes) * time.Minute
+
 	var slots []string
 	current := start
 
-	// Generar slots de 30 minutos
 	for current.Before(end) {
-		slotEnd := current.Add(30 * time.Minute)
+		slotEnd := current.Add(duration)
 		if slotEnd.After(end) {
 			break // No crear slot si se pasa del horario final
 		}
 
-		slot := fmt.Sprintf("%s-%s", 
-			current.Format("15:04"), 
+		slot := fmt.Sprintf("%s-%s",
+			current.Format("15:04"),
 			slotEnd.Format("15:04"))
 		slots = append(slots, slot)
-		
-		current = slotEnd
+
+		current = slotEnd.Add(buffer)
 	}
 
 	return slots, nil
 }
 
-// GetDayAvailabilityFromJSON extrae la disponibilidad de un día específico del JSON
-func GetDayAvailabilityFromJSON(availabilityJSON string, dayName string) (*DayAvailability, error) {
-	var availability map[string]interface{}
-	err := json.Unmarshal([]byte(availabilityJSON), &availability)
-	if err != nil {
+// ParseAvailability convierte el JSON de disponibilidad guardado en un servicio al modelo tipado
+func ParseAvailability(availabilityJSON string) (*entities.Availability, error) {
+	var availability entities.Availability
+	if err := json.Unmarshal([]byte(availabilityJSON), &availability); err != nil {
 		return nil, fmt.Errorf("failed to parse availability JSON: %w", err)
 	}
 
-	dayData, exists := availability[strings.ToLower(dayName)]
-	if !exists {
-		return &DayAvailability{Available: false}, nil
+	if availability.SlotDuration == 0 {
+		availability.SlotDuration = DefaultSlotDuration
+	}
+
+	return &availability, nil
+}
+
+// GenerateDaySlots genera todos los turnos de un día de la semana según la disponibilidad del servicio
+func GenerateDaySlots(availability *entities.Availability, dayName string) ([]string, error) {
+	day := availability.Day(dayName)
+	if day == nil || !day.Available {
+		return nil, nil
+	}
+
+	return GenerateRangeSlots(availability, day.Ranges)
+}
+
+// GenerateRangeSlots genera los turnos de una lista de rangos con la duración y el buffer del servicio
+func GenerateRangeSlots(availability *entities.Availability, ranges []entities.TimeRange) ([]string, error) {
+	var slots []string
+	for _, timeRange := range ranges {
+		rangeSlots, err := GenerateTimeSlots(timeRange.Start, timeRange.End, availability.SlotDuration, availability.BufferMinutes)
+		if err != nil {
+			return nil, err
+		}
+		slots = append(slots, rangeSlots...)
+	}
+
+	return slots, nil
+}
+
+// ValidateAvailability valida la disponibilidad enviada al crear o actualizar un servicio.
+// Completa la duración por defecto y ordena los rangos de cada día.
+func ValidateAvailability(availability *entities.Availability) error {
+	if availability.SlotDuration == 0 {
+		availability.SlotDuration = DefaultSlotDuration
+	}
+	if availability.SlotDuration < MinSlotDuration || availability.SlotDuration > MaxSlotDuration || availability.SlotDuration%5 != 0 {
+		return fmt.Errorf("slot_duration must be a multiple of 5 between %d and %d minutes", MinSlotDuration, MaxSlotDuration)
+	}
+	if availability.BufferMinutes < 0 || availability.BufferMinutes > MaxBufferMinutes || availability.BufferMinutes%5 != 0 {
+		return fmt.Errorf("buffer_minutes must be a multiple of 5 between 0 and %d", MaxBufferMinutes)
+	}
+
+	availableDays := 0
+	for _, dayName := range weekDays {
+		day := availability.Day(dayName)
+		if day == nil || !day.Available {
+			continue
+		}
+
+		if err := ValidateTimeRanges(availability, day.Ranges); err != nil {
+			return fmt.Errorf("%s: %w", dayName, err)
+		}
+		availableDays++
+	}
+
+	if availableDays == 0 {
+		return fmt.Errorf("at least one day must be available")
+	}
+
+	return nil
+}
+
+// GenerateDateSlots genera los turnos de una fecha concreta aplicando primero las
+// excepciones que la cubren: un día cerrado no tiene turnos y un horario especial
+// reemplaza a los rangos semanales. Si hay varios horarios especiales gana el más reciente.
+func GenerateDateSlots(availability *entities.Availability, exceptions []*entities.ServiceException, date string) ([]string, error) {
+	var customHours *entities.ServiceException
+	for _, exception := range exceptions {
+		if date < exception.StartDate || date > exception.EndDate {
+			continue
+		}
+		switch exception.Type {
+		case ExceptionClosed:
+			return nil, nil
+		case ExceptionCustomHours:
+			if customHours == nil || exception.ID > customHours.ID {
+				customHours = exception
+			}
+		}
+	}
+
+	if customHours != nil {
+		return GenerateRangeSlots(availability, customHours.Ranges)
 	}
 
-	dayMap, ok := dayData.(map[string]interface{})
-	if !ok {
-		return &DayAvailability{Available: false}, nil
+	dayName, err := GetDayOfWeek(date)
+	if err != nil {
+		return nil, err
 	}
 
-	// Verificar si está marcado como no disponible
-	if available, exists := dayMap["available"]; exists {
-		if avail, ok := available.(bool); ok && !avail {
-			return &DayAvailability{Available: false}, nil
+	return GenerateDaySlots(availability, dayName)
+}
+
+// ValidateTimeRanges valida, normaliza a HH:MM y ordena los rangos horarios de un día:
+// formato válido, sin superposiciones y con lugar para al menos un turno en cada rango
+func ValidateTimeRanges(availability *entities.Availability, ranges []entities.TimeRange) error {
+	if len(ranges) == 0 {
+		return fmt.Errorf("at least one time range is required")
+	}
+	if len(ranges) > MaxRangesPerDay {
+		return fmt.Errorf("at most %d time ranges are allowed per day", MaxRangesPerDay)
+	}
+
+	for i := range ranges {
+		timeRange := &ranges[i]
+		start, errStart := time.Parse("15:04", timeRange.Start)
+		end, errEnd := time.Parse("15:04", timeRange.End)
+		if errStart != nil || errEnd != nil {
+			return fmt.Errorf("invalid time range %s-%s, use HH:MM", timeRange.Start, timeRange.End)
+		}
+		if !start.Before(end) {
+			return fmt.Errorf("time range %s-%s must start before it ends", timeRange.Start, timeRange.End)
+		}
+		// time.Parse acepta "9:00": se guarda como "09:00" para que coincida con los turnos generados
+		timeRange.Start = start.Format("15:04")
+		timeRange.End = end.Format("15:04")
+
+		slots, err := GenerateTimeSlots(timeRange.Start, timeRange.End, availability.SlotDuration, 0)
+		if err != nil {
+			return err
+		}
+		if len(slots) == 0 {
+			return fmt.Errorf("time range %s-%s is shorter than the slot duration", timeRange.Start, timeRange.End)
 		}
 	}
 
-	// Extraer start y end
-	start, startExists := dayMap["start"]
-	end, endExists := dayMap["end"]
+	// Se comparan minutos del día y no los strings
+	sort.Slice(ranges, func(i, j int) bool {
+		return minuteOfDay(ranges[i].Start) < minuteOfDay(ranges[j].Start)
+	})
+	for i := 1; i < len(ranges); i++ {
+		if minuteOfDay(ranges[i].Start) < minuteOfDay(ranges[i-1].End) {
+			return fmt.Errorf("time ranges %s-%s and %s-%s overlap",
+				ranges[i-1].Start, ranges[i-1].End, ranges[i].Start, ranges[i].End)
+		}
+	}
+
+	return nil
+}
+
+// minuteOfDay devuelve los minutos desde la medianoche de una hora ya validada
+func minuteOfDay(value string) int {
+	parsed, _ := time.Parse("15:04", value)
+	return parsed.Hour()*60 + parsed.Minute()
+}
 
-	if !startExists || !endExists {
-		return &DayAvailability{Available: false}, nil
+// ParseTimeSlot separa un slot "HH:MM-HH:MM" en su hora de inicio y de fin
+func ParseTimeSlot(timeSlot string) (time.Time, time.Time, error) {
+	startStr, endStr, found := strings.Cut(timeSlot, "-")
+	if !found {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid time slot format: %s", timeSlot)
+	}
+
+	start, err := time.Parse("15:04", startStr)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid time slot format: %s", timeSlot)
+	}
+	end, err := time.Parse("15:04", endStr)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid time slot format: %s", timeSlot)
 	}
 
-	startStr, ok1 := start.(string)
-	endStr, ok2 := end.(string)
+	return start, end, nil
+}
 
-	if !ok1 || !ok2 {
-		return &DayAvailability{Available: false}, nil
+// SlotsOverlap indica si dos slots "HH:MM-HH:MM" se superponen en el tiempo
+func SlotsOverlap(a, b string) bool {
+	aStart, aEnd, err := ParseTimeSlot(a)
+	if err != nil {
+		return a == b
 	}
+	bStart, bEnd, err := ParseTimeSlot(b)
+	if err != nil {
+		return a == b
+	}
+
+	return aStart.Before(bEnd) && bStart.Before(aEnd)
+}
 
-	return &DayAvailability{
-		Available: true,
-		Start:     startStr,
-		End:       endStr,
-	}, nil
+// IsSlotOccupied indica si un slot se superpone con alguno de los horarios ocupados
+func IsSlotOccupied(slot string, occupied []string) bool {
+	for _, occupiedSlot := range occupied {
+		if SlotsOverlap(slot, occupiedSlot) {
+			return true
+		}
+	}
+	return false
 }
 
 // ValidateTimeFormat valida que el formato de hora sea HH:MM
@@ -134,15 +309,13 @@ func GetDayOfWeekInSpanish(weekday time.Weekday) string {
 	return dayNames[weekday]
 }
 
-// IsDateInFuture verifica si una fecha es futura (no permite fechas pasadas)
-func IsDateInFuture(dateStr string) bool {
-	date, err := time.Parse("2006-01-02", dateStr)
-	if err != nil {
+// IsDateInFuture verifica si una fecha es hoy o posterior en la zona horaria indicada
+// (no permite fechas pasadas)
+func IsDateInFuture(dateStr string, loc *time.Location) bool {
+	if !ValidateDateFormat(dateStr) {
 		return false
 	}
 
-	today := time.Now().Truncate(24 * time.Hour)
-	return date.After(today) || date.Equal(today)
+	// Las fechas YYYY-MM-DD se comparan lexicográficamente
+	return dateStr >= TodayIn(loc)
 }
-
-
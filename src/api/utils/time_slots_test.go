@@ -0,0 +1,147 @@
+package utils
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"iycds2025_api/src/api/core/entities"
+)
+
+func TestValidateTimeRanges(t *testing.T) {
+	availability := &entities.Availability{SlotDuration: 30}
+
+	tests := []struct {
+		name    string
+		ranges  []entities.TimeRange
+		want    []entities.TimeRange // rangos normalizados y ordenados
+		wantErr string
+	}{
+		{
+			name:   "single range",
+			ranges: []entities.TimeRange{{Start: "09:00", End: "12:00"}},
+			want:   []entities.TimeRange{{Start: "09:00", End: "12:00"}},
+		},
+		{
+			name:   "ranges are sorted",
+			ranges: []entities.TimeRange{{Start: "14:00", End: "18:00"}, {Start: "09:00", End: "12:00"}},
+			want:   []entities.TimeRange{{Start: "09:00", End: "12:00"}, {Start: "14:00", End: "18:00"}},
+		},
+		{
+			name:   "adjacent ranges do not overlap",
+			ranges: []entities.TimeRange{{Start: "12:00", End: "14:00"}, {Start: "09:00", End: "12:00"}},
+			want:   []entities.TimeRange{{Start: "09:00", End: "12:00"}, {Start: "12:00", End: "14:00"}},
+		},
+		{
+			name:   "single-digit hour is normalized",
+			ranges: []entities.TimeRange{{Start: "9:00", End: "12:00"}},
+			want:   []entities.TimeRange{{Start: "09:00", End: "12:00"}},
+		},
+		{
+			name:   "single-digit hours on both ends",
+			ranges: []entities.TimeRange{{Start: "8:30", End: "9:30"}},
+			want:   []entities.TimeRange{{Start: "08:30", End: "09:30"}},
+		},
+		{
+			// Comparando strings "9:00" quedaría después de "10:00"
+			name:   "mixed one and two digit hours are sorted by time",
+			ranges: []entities.TimeRange{{Start: "10:00", End: "12:00"}, {Start: "7:00", End: "9:00"}},
+			want:   []entities.TimeRange{{Start: "07:00", End: "09:00"}, {Start: "10:00", End: "12:00"}},
+		},
+		{
+			name:    "mixed digits overlap is detected",
+			ranges:  []entities.TimeRange{{Start: "10:00", End: "12:00"}, {Start: "9:00", End: "11:00"}},
+			wantErr: "overlap",
+		},
+		{
+			name:    "single-digit end overlaps next range",
+			ranges:  []entities.TimeRange{{Start: "8:00", End: "9:30"}, {Start: "09:00", End: "10:00"}},
+			wantErr: "overlap",
+		},
+		{
+			name:    "overlapping ranges",
+			ranges:  []entities.TimeRange{{Start: "09:00", End: "12:00"}, {Start: "11:00", End: "13:00"}},
+			wantErr: "overlap",
+		},
+		{
+			name:    "start after end",
+			ranges:  []entities.TimeRange{{Start: "12:00", End: "9:00"}},
+			wantErr: "must start before it ends",
+		},
+		{
+			name:    "empty range",
+			ranges:  []entities.TimeRange{{Start: "09:00", End: "9:00"}},
+			wantErr: "must start before it ends",
+		},
+		{
+			name:    "invalid format",
+			ranges:  []entities.TimeRange{{Start: "9am", End: "12:00"}},
+			wantErr: "use HH:MM",
+		},
+		{
+			name:    "hour out of range",
+			ranges:  []entities.TimeRange{{Start: "09:00", End: "24:00"}},
+			wantErr: "use HH:MM",
+		},
+		{
+			name:    "shorter than slot duration",
+			ranges:  []entities.TimeRange{{Start: "9:00", End: "9:20"}},
+			wantErr: "shorter than the slot duration",
+		},
+		{
+			name:    "no ranges",
+			ranges:  []entities.TimeRange{},
+			wantErr: "at least one time range",
+		},
+		{
+			name: "too many ranges",
+			ranges: []entities.TimeRange{
+				{Start: "01:00", End: "02:00"}, {Start: "03:00", End: "04:00"}, {Start: "05:00", End: "06:00"},
+				{Start: "07:00", End: "08:00"}, {Start: "09:00", End: "10:00"}, {Start: "11:00", End: "12:00"},
+				{Start: "13:00", End: "14:00"},
+			},
+			wantErr: "at most",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateTimeRanges(availability, tt.ranges)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("got error %v, want it to mention %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ValidateTimeRanges: %v", err)
+			}
+			if !reflect.DeepEqual(tt.ranges, tt.want) {
+				t.Errorf("ranges = %v, want %v", tt.ranges, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateAvailabilitySingleDigitHours(t *testing.T) {
+	availability := &entities.Availability{
+		Monday: &entities.DaySchedule{
+			Available: true,
+			Ranges:    []entities.TimeRange{{Start: "14:00", End: "16:00"}, {Start: "9:00", End: "10:00"}},
+		},
+	}
+
+	if err := ValidateAvailability(availability); err != nil {
+		t.Fatalf("ValidateAvailability: %v", err)
+	}
+
+	// Los turnos generados usan el mismo formato que los rangos guardados
+	slots, err := GenerateDaySlots(availability, "monday")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"09:00-09:30", "09:30-10:00", "14:00-14:30", "14:30-15:00", "15:00-15:30", "15:30-16:00"}
+	if !reflect.DeepEqual(slots, want) {
+		t.Errorf("slots = %v, want %v", slots, want)
+	}
+}
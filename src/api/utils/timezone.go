@@ -0,0 +1,64 @@
+package utils
+
+import (
+	"fmt"
+	"time"
+)
+
+// DefaultTimezone es la zona horaria que se asigna a los servicios que no indican otra
+const DefaultTimezone = "America/Argentina/Buenos_Aires"
+
+// ValidateTimezone verifica que el nombre sea una zona horaria IANA válida (ej: "America/Argentina/Cordoba")
+func ValidateTimezone(name string) error {
+	if name == "" || name == "Local" {
+		return fmt.Errorf("timezone must be an IANA name like %q", DefaultTimezone)
+	}
+	if _, err := time.LoadLocation(name); err != nil {
+		return fmt.Errorf("unknown timezone %q", name)
+	}
+	return nil
+}
+
+// LoadLocation devuelve la zona horaria de un servicio, usando DefaultTimezone si está vacía o es inválida
+func LoadLocation(name string) *time.Location {
+	if name != "" {
+		if loc, err := time.LoadLocation(name); err == nil {
+			return loc
+		}
+	}
+	loc, err := time.LoadLocation(DefaultTimezone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+// TodayIn devuelve la fecha actual (YYYY-MM-DD) en la zona horaria indicada
+func TodayIn(loc *time.Location) string {
+	return time.Now().In(loc).Format("2006-01-02")
+}
+
+// SlotInstants devuelve el inicio y el fin absolutos de un slot "HH:MM-HH:MM" en una fecha y zona horaria
+func SlotInstants(dateStr, timeSlot string, loc *time.Location) (time.Time, time.Time, error) {
+	date, err := time.ParseInLocation("2006-01-02", dateStr, loc)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	start, end, err := ParseTimeSlot(timeSlot)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+
+	startsAt := time.Date(date.Year(), date.Month(), date.Day(), start.Hour(), start.Minute(), 0, 0, loc)
+	endsAt := time.Date(date.Year(), date.Month(), date.Day(), end.Hour(), end.Minute(), 0, 0, loc)
+	return startsAt, endsAt, nil
+}
+
+// HasSlotStarted indica si un slot de una fecha ya comenzó en la zona horaria del servicio
+func HasSlotStarted(dateStr, timeSlot string, loc *time.Location) bool {
+	startsAt, _, err := SlotInstants(dateStr, timeSlot, loc)
+	if err != nil {
+		return false
+	}
+	return !startsAt.After(time.Now())
+}
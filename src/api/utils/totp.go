@@ -0,0 +1,145 @@
+package utils
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Parámetros TOTP (RFC 6238) compatibles con las apps de autenticación habituales
+const (
+	TOTPDigits = 6
+	TOTPPeriod = 30 * time.Second
+	// totpSkew es la cantidad de intervalos aceptados antes y después del actual (desfase de reloj)
+	totpSkew = 1
+	// totpSecretSize son los bytes del secreto; 20 bytes es el largo recomendado para HMAC-SHA1
+	totpSecretSize = 20
+	// recoveryCodeSize son los caracteres de un código de recuperación (sin el guion)
+	recoveryCodeSize = 10
+)
+
+var totpEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// GenerateTOTPSecret crea un secreto TOTP aleatorio codificado en base32, como lo esperan las apps
+func GenerateTOTPSecret() (string, error) {
+	secret := make([]byte, totpSecretSize)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+	return totpEncoding.EncodeToString(secret), nil
+}
+
+// TOTPURI arma la URI otpauth:// que se muestra como código QR para registrar la cuenta en la app
+func TOTPURI(issuer string, account string, secret string) string {
+	label := url.PathEscape(issuer) + ":" + url.PathEscape(account)
+
+	params := url.Values{}
+	params.Set("secret", secret)
+	params.Set("issuer", issuer)
+	params.Set("algorithm", "SHA1")
+	params.Set("digits", fmt.Sprintf("%d", TOTPDigits))
+	params.Set("period", fmt.Sprintf("%d", int(TOTPPeriod.Seconds())))
+
+	return "otpauth://totp/" + label + "?" + strings.ReplaceAll(params.Encode(), "+", "%20")
+}
+
+// TOTPStep devuelve el intervalo TOTP al que pertenece el instante indicado
+func TOTPStep(t time.Time) int64 {
+	return t.Unix() / int64(TOTPPeriod.Seconds())
+}
+
+// TOTPCode calcula el código del intervalo indicado (HOTP de RFC 4226 con el intervalo como contador)
+func TOTPCode(secret string, step int64) (string, error) {
+	key, err := totpEncoding.DecodeString(strings.ToUpper(secret))
+	if err != nil {
+		return "", fmt.Errorf("invalid TOTP secret: %v", err)
+	}
+
+	var counter [8]byte
+	binary.BigEndian.PutUint64(counter[:], uint64(step))
+
+	mac := hmac.New(sha1.New, key)
+	mac.Write(counter[:])
+	sum := mac.Sum(nil)
+
+	// Truncamiento dinámico: los 4 bits bajos del último byte indican desde dónde leer
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	modulo := uint32(1)
+	for i := 0; i < TOTPDigits; i++ {
+		modulo *= 10
+	}
+	return fmt.Sprintf("%0*d", TOTPDigits, value%modulo), nil
+}
+
+// ValidateTOTP verifica un código contra el secreto en el instante indicado, tolerando un intervalo
+// de desfase. Devuelve el intervalo que coincidió para que el llamador pueda impedir que se reutilice.
+func ValidateTOTP(secret string, code string, t time.Time) (int64, bool) {
+	code = strings.TrimSpace(code)
+	if !IsTOTPCode(code) {
+		return 0, false
+	}
+
+	current := TOTPStep(t)
+	for step := current - totpSkew; step <= current+totpSkew; step++ {
+		expected, err := TOTPCode(secret, step)
+		if err != nil {
+			return 0, false
+		}
+		if hmac.Equal([]byte(expected), []byte(code)) {
+			return step, true
+		}
+	}
+	return 0, false
+}
+
+// IsTOTPCode indica si el texto tiene el formato de un código TOTP (solo dígitos, del largo esperado)
+func IsTOTPCode(code string) bool {
+	if len(code) != TOTPDigits {
+		return false
+	}
+	for _, c := range code {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// GenerateRecoveryCode crea un código de recuperación de un solo uso con el formato xxxxx-xxxxx
+func GenerateRecoveryCode() (string, error) {
+	raw := make([]byte, recoveryCodeSize)
+	if _, err := rand.Read(raw); err != nil {
+		return "", err
+	}
+
+	// Alfabeto base32 en minúsculas: no incluye 0 ni 1, que se confunden con o y l al copiarlos a mano.
+	// 256 es múltiplo de 32, así que el módulo no sesga la distribución.
+	const alphabet = "abcdefghijklmnopqrstuvwxyz234567"
+	code := make([]byte, recoveryCodeSize)
+	for i, b := range raw {
+		code[i] = alphabet[int(b)%len(alphabet)]
+	}
+
+	half := recoveryCodeSize / 2
+	return string(code[:half]) + "-" + string(code[half:]), nil
+}
+
+// NormalizeRecoveryCode quita espacios y guiones y pasa a minúsculas, para comparar el código
+// tal como lo escribe el usuario con el que se guardó
+func NormalizeRecoveryCode(code string) string {
+	code = strings.ToLower(code)
+	return strings.Map(func(r rune) rune {
+		if r == '-' || r == ' ' {
+			return -1
+		}
+		return r
+	}, code)
+}
@@ -0,0 +1,170 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// rfc6238Secret es la clave SHA-1 de los vectores del RFC 6238 ("12345678901234567890") en base32
+const rfc6238Secret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+// Vectores del apéndice B del RFC 6238 para SHA-1. El RFC usa 8 dígitos; con 6 el código son
+// los últimos 6 dígitos, porque ambos son el mismo valor truncado módulo 10^n.
+var rfc6238Vectors = []struct {
+	unix int64
+	code string
+}{
+	{59, "287082"},          // 94287082
+	{1111111109, "081804"},  // 07081804
+	{1111111111, "050471"},  // 14050471
+	{1234567890, "005924"},  // 89005924
+	{2000000000, "279037"},  // 69279037
+	{20000000000, "353130"}, // 65353130
+}
+
+func TestTOTPCodeRFC6238(t *testing.T) {
+	for _, tt := range rfc6238Vectors {
+		step := TOTPStep(time.Unix(tt.unix, 0))
+		got, err := TOTPCode(rfc6238Secret, step)
+		if err != nil {
+			t.Fatalf("TOTPCode(%d): %v", tt.unix, err)
+		}
+		if got != tt.code {
+			t.Errorf("TOTPCode at %d = %s, want %s", tt.unix, got, tt.code)
+		}
+	}
+}
+
+func TestValidateTOTPRFC6238(t *testing.T) {
+	for _, tt := range rfc6238Vectors {
+		at := time.Unix(tt.unix, 0)
+		step, ok := ValidateTOTP(rfc6238Secret, tt.code, at)
+		if !ok {
+			t.Errorf("ValidateTOTP rejected %s at %d", tt.code, tt.unix)
+			continue
+		}
+		if want := TOTPStep(at); step != want {
+			t.Errorf("ValidateTOTP at %d matched step %d, want %d", tt.unix, step, want)
+		}
+	}
+}
+
+func TestValidateTOTPSkew(t *testing.T) {
+	now := time.Unix(1234567890, 0)
+	current := TOTPStep(now)
+
+	tests := []struct {
+		name   string
+		offset int64
+		valid  bool
+	}{
+		{"two steps behind", -2, false},
+		{"one step behind", -1, true},
+		{"current step", 0, true},
+		{"one step ahead", 1, true},
+		{"two steps ahead", 2, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, err := TOTPCode(rfc6238Secret, current+tt.offset)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			step, ok := ValidateTOTP(rfc6238Secret, code, now)
+			if ok != tt.valid {
+				t.Fatalf("ValidateTOTP(code of step %+d) = %v, want %v", tt.offset, ok, tt.valid)
+			}
+			// Se devuelve el intervalo del código, no el actual, para poder impedir que se reutilice
+			if ok && step != current+tt.offset {
+				t.Errorf("matched step %d, want %d", step, current+tt.offset)
+			}
+		})
+	}
+}
+
+func TestValidateTOTPRejectsMalformedCodes(t *testing.T) {
+	now := time.Unix(1234567890, 0)
+
+	tests := []struct {
+		name string
+		code string
+	}{
+		{"empty", ""},
+		{"five digits", "05924"},
+		{"seven digits", "9005924"},
+		{"eight digit RFC code", "89005924"},
+		{"letters", "00592a"},
+		{"sign", "+05924"},
+		{"inner space", "005 924"},
+		{"non ascii digits", "００５９２４"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, ok := ValidateTOTP(rfc6238Secret, tt.code, now); ok {
+				t.Errorf("ValidateTOTP accepted %q", tt.code)
+			}
+		})
+	}
+
+	// Los espacios alrededor sí se toleran (el usuario los pega desde la app)
+	if _, ok := ValidateTOTP(rfc6238Secret, " 005924\n", now); !ok {
+		t.Error("ValidateTOTP rejected a valid code surrounded by spaces")
+	}
+}
+
+func TestTOTPSecret(t *testing.T) {
+	// El secreto se acepta en minúsculas, como lo copian algunos usuarios
+	got, err := TOTPCode(strings.ToLower(rfc6238Secret), TOTPStep(time.Unix(59, 0)))
+	if err != nil || got != "287082" {
+		t.Errorf("lowercase secret: got (%q, %v), want 287082", got, err)
+	}
+
+	if _, err := TOTPCode("not base32!", 1); err == nil {
+		t.Error("TOTPCode accepted an invalid secret")
+	}
+	if _, ok := ValidateTOTP("not base32!", "287082", time.Unix(59, 0)); ok {
+		t.Error("ValidateTOTP accepted a code for an invalid secret")
+	}
+
+	secret, err := GenerateTOTPSecret()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := TOTPCode(secret, 1); err != nil {
+		t.Errorf("generated secret %q is not valid: %v", secret, err)
+	}
+}
+
+func TestNormalizeRecoveryCode(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"abcde-fghij", "abcdefghij"},
+		{"ABCDE-FGHIJ", "abcdefghij"},
+		{" abcde fghij ", "abcdefghij"},
+		{"ab-cde-fg hij", "abcdefghij"},
+		{"abcdefghij", "abcdefghij"},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		if got := NormalizeRecoveryCode(tt.input); got != tt.want {
+			t.Errorf("NormalizeRecoveryCode(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+
+	// Un código generado coincide consigo mismo escrito en mayúsculas y sin guion
+	code, err := GenerateRecoveryCode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	typed := strings.ToUpper(strings.ReplaceAll(code, "-", ""))
+	if NormalizeRecoveryCode(typed) != NormalizeRecoveryCode(code) {
+		t.Errorf("recovery code %q does not match %q once normalized", code, typed)
+	}
+}
@@ -0,0 +1,15 @@
+package utils
+
+import (
+	"net/url"
+	"strings"
+)
+
+// IsHTTPURL indica si el texto es una URL absoluta http o https con host
+func IsHTTPURL(raw string) bool {
+	parsed, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil {
+		return false
+	}
+	return (parsed.Scheme == "http" || parsed.Scheme == "https") && parsed.Host != ""
+}